@@ -0,0 +1,189 @@
+package generate
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+)
+
+// api 请求字段来源
+const (
+	ApiFieldSourcePath  = "path"  // 路由路径参数，gincontext.BindPathParams 绑定
+	ApiFieldSourceQuery = "query" // 查询参数，ShouldBindQuery 绑定
+	ApiFieldSourceBody  = "body"  // JSON 请求体，ShouldBindJSON 绑定
+)
+
+// ApiField api 请求、响应字段，用于生成 dto 结构体字段、controller 的参数绑定与路由路径
+type ApiField struct {
+	FieldName string // 结构体字段名，如 OrderID
+	TagName   string // json/uri/form 标签名，如 orderID
+	FieldType string // Go 类型
+	Source    string // 请求字段来源：path、query、body，响应字段为空
+	Binding   string // binding 校验规则
+	Comment   string // 字段说明
+	DocType   string // swagger 路径参数类型：int、number、bool、string
+}
+
+// ApiFields api 的请求、响应字段
+type ApiFields struct {
+	RequestFields   []ApiField // 请求字段
+	ResponseFields  []ApiField // 响应字段
+	PathParams      []ApiField // 路由路径参数，按声明顺序拼入路由路径
+	BindQuery       bool       // 是否绑定查询参数
+	BindBody        bool       // 是否绑定 JSON 请求体
+	RequestImports  []string   // 请求结构体需要的导入
+	ResponseImports []string   // 响应结构体需要的导入
+}
+
+// defaultApiFieldSource 请求字段未配置来源时的默认值：GET、DELETE 为 query，其余为 body
+func defaultApiFieldSource(httpMethod string) string {
+	switch strings.ToUpper(httpMethod) {
+	case http.MethodGet, http.MethodDelete:
+		return ApiFieldSourceQuery
+	}
+	return ApiFieldSourceBody
+}
+
+// buildApiFields 校验 api 的请求、响应字段配置并转换为模板参数。
+// 未配置请求字段时按 http 方法绑定（GET、DELETE 绑定查询参数，其余绑定 JSON 请求体），与之前生成的空结构体保持一致。
+// 同一请求不能同时包含 query 与 body 字段：两次绑定都会校验整个结构体，先绑定的一方会因另一方的 required 字段未赋值而失败。
+func buildApiFields(apiCfg ApiEndpointConfig) (*ApiFields, error) {
+	fields := &ApiFields{}
+	requestFields, err := convertApiFields(apiCfg.Request, apiCfg.HttpMethod, true)
+	if err != nil {
+		return nil, err
+	}
+	responseFields, err := convertApiFields(apiCfg.Response, apiCfg.HttpMethod, false)
+	if err != nil {
+		return nil, err
+	}
+	fields.RequestFields = requestFields
+	fields.ResponseFields = responseFields
+	if len(requestFields) == 0 {
+		fields.BindQuery = defaultApiFieldSource(apiCfg.HttpMethod) == ApiFieldSourceQuery
+		fields.BindBody = !fields.BindQuery
+	}
+	for _, field := range requestFields {
+		switch field.Source {
+		case ApiFieldSourcePath:
+			fields.PathParams = append(fields.PathParams, field)
+		case ApiFieldSourceQuery:
+			fields.BindQuery = true
+		case ApiFieldSourceBody:
+			fields.BindBody = true
+		}
+	}
+	if fields.BindQuery && fields.BindBody {
+		return nil, fmt.Errorf("api %s request fields cannot mix query and body sources", apiCfg.FunctionName)
+	}
+	fields.RequestImports = apiFieldImports(requestFields)
+	fields.ResponseImports = apiFieldImports(responseFields)
+	return fields, nil
+}
+
+// convertApiFields 转换请求或响应字段配置
+func convertApiFields(fieldCfgs []ApiFieldConfig, httpMethod string, isRequest bool) ([]ApiField, error) {
+	kind := "response"
+	if isRequest {
+		kind = "request"
+	}
+	var fields []ApiField
+	nameSet := make(map[string]struct{}, len(fieldCfgs))
+	for _, fieldCfg := range fieldCfgs {
+		if fieldCfg.Name == "" || fieldCfg.Type == "" {
+			return nil, fmt.Errorf("api %s field requires name and type: %+v", kind, fieldCfg)
+		}
+		tagName := gutil.FirstLetterToLower(fieldCfg.Name)
+		if strings.Contains(fieldCfg.Name, "_") {
+			tagName = SnakeToLowerCamelWithID(fieldCfg.Name)
+		}
+		fieldName := gutil.FirstLetterToUpper(tagName)
+		if !token.IsIdentifier(fieldName) {
+			return nil, fmt.Errorf("api %s field name %s is not a valid identifier", kind, fieldCfg.Name)
+		}
+		if _, exists := nameSet[fieldName]; exists {
+			return nil, fmt.Errorf("api %s field %s is declared more than once", kind, fieldCfg.Name)
+		}
+		nameSet[fieldName] = struct{}{}
+
+		field := ApiField{
+			FieldName: fieldName,
+			TagName:   tagName,
+			FieldType: fieldCfg.Type,
+			Binding:   fieldCfg.Validate,
+			Comment:   fieldCfg.Description,
+			DocType:   apiFieldDocType(fieldCfg.Type),
+		}
+		if !isRequest {
+			if fieldCfg.Source != "" || fieldCfg.Validate != "" {
+				return nil, fmt.Errorf("api response field %s: source and validate only apply to request fields", fieldCfg.Name)
+			}
+			fields = append(fields, field)
+			continue
+		}
+
+		field.Source = fieldCfg.Source
+		if field.Source == "" {
+			field.Source = defaultApiFieldSource(httpMethod)
+		}
+		switch field.Source {
+		case ApiFieldSourcePath:
+			if strings.HasPrefix(field.FieldType, "[]") {
+				return nil, fmt.Errorf("api request field %s: path parameter cannot be a slice", fieldCfg.Name)
+			}
+			// 路径参数不能为空，缺省时补充 required
+			if !strings.Contains(","+field.Binding+",", ",required,") {
+				field.Binding = strings.TrimSuffix("required,"+field.Binding, ",")
+			}
+		case ApiFieldSourceQuery, ApiFieldSourceBody:
+		default:
+			return nil, fmt.Errorf("api request field %s: invalid source %q, supported: path, query, body", fieldCfg.Name, field.Source)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// apiFieldDocType 字段类型对应的 swagger 参数类型
+func apiFieldDocType(fieldType string) string {
+	switch {
+	case IsNumID(fieldType) || IsIntID(fieldType):
+		return "int"
+	case fieldType == "float32" || fieldType == "float64":
+		return "number"
+	case fieldType == "bool":
+		return "bool"
+	}
+	return "string"
+}
+
+// apiFieldImports 收集字段类型需要的导入，切片与指针按元素类型处理
+func apiFieldImports(fields []ApiField) []string {
+	importSet := make(map[string]struct{})
+	for _, field := range fields {
+		if importInfo, ok := lookupFieldTypeImport(field.FieldType); ok {
+			importSet[importInfo.ImportPath] = struct{}{}
+		}
+	}
+	imports := make([]string, 0, len(importSet))
+	for importPath := range importSet {
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// apiRoutePath api 的 gin 路由路径，路径参数位于资源与动作之间，如 /orders/:orderID/refund
+func apiRoutePath(resourcePath, action string, pathParams []ApiField) string {
+	var builder strings.Builder
+	builder.WriteString("/" + resourcePath)
+	for _, param := range pathParams {
+		builder.WriteString("/:" + param.TagName)
+	}
+	builder.WriteString("/" + action)
+	return builder.String()
+}
@@ -0,0 +1,228 @@
+package generate
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestBuildApiFields(t *testing.T) {
+	apiFields, err := buildApiFields(ApiEndpointConfig{
+		FunctionName: "Refund",
+		HttpMethod:   "POST",
+		Request: []ApiFieldConfig{
+			{Name: "order_id", Type: "uint", Source: "path", Description: "订单ID"},
+			{Name: "reason", Type: "string", Validate: "required,max=255"},
+			{Name: "itemIDs", Type: "[]uint"},
+		},
+		Response: []ApiFieldConfig{
+			{Name: "refundedAt", Type: "time.Time"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildApiFields error: %v", err)
+	}
+	if len(apiFields.PathParams) != 1 || apiFields.PathParams[0].FieldName != "OrderID" || apiFields.PathParams[0].TagName != "orderID" {
+		t.Errorf("path params = %+v", apiFields.PathParams)
+	}
+	if apiFields.PathParams[0].Binding != "required" || apiFields.PathParams[0].DocType != "int" {
+		t.Errorf("path param = %+v", apiFields.PathParams[0])
+	}
+	if source := apiFields.RequestFields[1].Source; source != ApiFieldSourceBody {
+		t.Errorf("POST default source = %s, want body", source)
+	}
+	if !apiFields.BindBody || apiFields.BindQuery {
+		t.Errorf("bind flags = query %v, body %v", apiFields.BindQuery, apiFields.BindBody)
+	}
+	if len(apiFields.RequestImports) != 0 || strings.Join(apiFields.ResponseImports, ",") != "time" {
+		t.Errorf("imports = %v, %v", apiFields.RequestImports, apiFields.ResponseImports)
+	}
+	if got := apiRoutePath("orders", "refund", apiFields.PathParams); got != "/orders/:orderID/refund" {
+		t.Errorf("route path = %s", got)
+	}
+
+	// 未配置请求字段时按 http 方法绑定
+	apiFields, err = buildApiFields(ApiEndpointConfig{FunctionName: "Export", HttpMethod: "GET"})
+	if err != nil || !apiFields.BindQuery || apiFields.BindBody {
+		t.Errorf("GET without fields = %+v, %v", apiFields, err)
+	}
+
+	invalidConfigs := map[string]ApiEndpointConfig{
+		"missing type":    {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "reason"}}},
+		"invalid name":    {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "2fa", Type: "string"}}},
+		"duplicated":      {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "orderID", Type: "uint"}, {Name: "order_id", Type: "uint"}}},
+		"invalid source":  {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "token", Type: "string", Source: "header"}}},
+		"slice path":      {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "ids", Type: "[]uint", Source: "path"}}},
+		"query and body":  {HttpMethod: "POST", Request: []ApiFieldConfig{{Name: "page", Type: "int", Source: "query"}, {Name: "reason", Type: "string"}}},
+		"response source": {HttpMethod: "POST", Response: []ApiFieldConfig{{Name: "refundID", Type: "uint", Source: "body"}}},
+	}
+	for name, apiCfg := range invalidConfigs {
+		if _, err := buildApiFields(apiCfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// TestApiTemplateTypedFields 配置请求、响应字段后，dto 生成带标签的字段，controller 按来源绑定，路由包含路径参数
+func TestApiTemplateTypedFields(t *testing.T) {
+	endpoints, err := buildApiEndpoints(ApiConfig{
+		ApiEndpointConfig: ApiEndpointConfig{
+			FunctionName: "Refund",
+			HttpMethod:   "POST",
+			Description:  "订单退款",
+			ApiDocTag:    "订单",
+			Request: []ApiFieldConfig{
+				{Name: "orderID", Type: "uint", Source: "path", Description: "订单ID"},
+				{Name: "reason", Type: "string", Validate: "required,max=255", Description: "退款原因"},
+			},
+			Response: []ApiFieldConfig{
+				{Name: "refundID", Type: "uint", Description: "退款单ID"},
+				{Name: "refundedAt", Type: "time.Time"},
+			},
+		},
+	}, "orders")
+	if err != nil {
+		t.Fatalf("buildApiEndpoints error: %v", err)
+	}
+	params := newTestApiExtraParams(endpoints)
+	cases := map[string][]string{
+		"request.go.tpl": {
+			"OrderID uint   `json:\"-\" uri:\"orderID\" binding:\"required\"` // 订单ID",
+			"Reason  string `json:\"reason\" binding:\"required,max=255\"`    // 退款原因",
+		},
+		"response.go.tpl": {
+			`"time"`,
+			"RefundID   uint      `json:\"refundID\"` // 退款单ID",
+			"RefundedAt time.Time `json:\"refundedAt\"`",
+		},
+		"controller.go.tpl": {
+			`// @Param orderID path int true "订单ID"`,
+			"// @Param req body dtoorder.OrderRefundReq true \"订单退款\"",
+			"// @Router /v1/demoapp/orders/{orderID}/refund [post]",
+			"if err := gincontext.BindPathParams(ctx, &req); err != nil {",
+			"if err := ctx.ShouldBindJSON(&req); err != nil {",
+		},
+		"router.go.tpl": {
+			`v1RouterGroup.POST("/orders/:orderID/refund", orderCtr.Refund)`,
+		},
+	}
+	for name, wants := range cases {
+		out := renderApiTemplate(t, name, params)
+		for _, want := range wants {
+			// gofmt 会对齐结构体字段，比较前压缩连续空白
+			if !strings.Contains(compactSpaces(out), compactSpaces(want)) {
+				t.Errorf("%s missing %q:\n%s", name, want, out)
+			}
+		}
+	}
+	if out := renderApiTemplate(t, "controller.go.tpl", params); strings.Contains(out, "ShouldBindQuery") {
+		t.Errorf("controller should not bind query without query fields:\n%s", out)
+	}
+}
+
+// TestApiTemplateMultipleEndpoints endpoints 中的多个接口在一次渲染中生成，新建文件时接口声明包含全部方法
+func TestApiTemplateMultipleEndpoints(t *testing.T) {
+	endpoints, err := buildApiEndpoints(ApiConfig{
+		ApiEndpointConfig: ApiEndpointConfig{ApiDocTag: "订单", FunctionName: "Ignored", HttpMethod: "GET"},
+		Endpoints: []ApiEndpointConfig{
+			{FunctionName: "approve", HttpMethod: "post", Description: "审批订单", Request: []ApiFieldConfig{{Name: "orderID", Type: "uint", Source: "path"}}},
+			{FunctionName: "Reject", HttpMethod: "POST", Description: "驳回订单", Request: []ApiFieldConfig{
+				{Name: "orderID", Type: "uint", Source: "path"},
+				{Name: "reason", Type: "string", Validate: "required"},
+			}},
+			{FunctionName: "Cancel", HttpMethod: "PUT", Description: "取消订单", ApiDocTag: "订单取消"},
+		},
+	}, "orders")
+	if err != nil {
+		t.Fatalf("buildApiEndpoints error: %v", err)
+	}
+	var routes []string
+	for _, endpoint := range endpoints {
+		routes = append(routes, endpoint.HttpMethod+" "+endpoint.RoutePath+" "+endpoint.ApiDocTag)
+	}
+	want := "POST /orders/:orderID/approve 订单,POST /orders/:orderID/reject 订单,PUT /orders/cancel 订单取消"
+	if got := strings.Join(routes, ","); got != want {
+		t.Errorf("endpoints = %s, want %s", got, want)
+	}
+
+	params := newTestApiExtraParams(endpoints)
+	cases := map[string][]string{
+		"controller.go.tpl": {
+			"type OrderCtr interface {\n\tApprove(ctx *gin.Context)\n\tReject(ctx *gin.Context)\n\tCancel(ctx *gin.Context)\n}",
+			"func (ctr *orderCtr) Approve(ctx *gin.Context) {",
+			"func (ctr *orderCtr) Reject(ctx *gin.Context) {",
+			"// @Router /v1/demoapp/orders/cancel [put]",
+		},
+		"service.go.tpl": {
+			"Approve(ctx *gin.Context, req *dtoorder.OrderApproveReq) (*dtoorder.OrderApproveResp, error)",
+			"func (svc *orderSvc) Cancel(ctx *gin.Context, req *dtoorder.OrderCancelReq) (*dtoorder.OrderCancelResp, error) {",
+		},
+		"request.go.tpl": {
+			"type OrderApproveReq struct {",
+			"type OrderRejectReq struct {",
+			"Reason  string `json:\"reason\" binding:\"required\"`",
+			"type OrderCancelReq struct {\n}",
+		},
+		"response.go.tpl": {
+			"type OrderApproveResp struct {\n}",
+			"type OrderCancelResp struct {\n}",
+		},
+		"router.go.tpl": {
+			`v1RouterGroup.POST("/orders/:orderID/approve", orderCtr.Approve)`,
+			`v1RouterGroup.POST("/orders/:orderID/reject", orderCtr.Reject)`,
+			`v1RouterGroup.PUT("/orders/cancel", orderCtr.Cancel)`,
+		},
+	}
+	for name, wants := range cases {
+		out := renderApiTemplate(t, name, params)
+		for _, want := range wants {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s missing %q:\n%s", name, want, out)
+			}
+		}
+	}
+
+	invalidConfigs := map[string]ApiConfig{
+		"missing method": {Endpoints: []ApiEndpointConfig{{FunctionName: "Approve"}}},
+		"duplicated":     {Endpoints: []ApiEndpointConfig{{FunctionName: "approve", HttpMethod: "POST"}, {FunctionName: "Approve", HttpMethod: "PUT"}}},
+	}
+	for name, apiCfg := range invalidConfigs {
+		if _, err := buildApiEndpoints(apiCfg, "orders"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func newTestApiExtraParams(endpoints []ApiEndpoint) ApiExtraParams {
+	return ApiExtraParams{
+		AppInfo:              AppInfo{AppName: "demoapp", BaseModulePath: "github.com/example", AppModuleName: "demoapp"},
+		PackageName:          "order",
+		Description:          "订单",
+		IsNewRouter:          true,
+		StructName:           "Order",
+		StructNameLowerCamel: "order",
+		Endpoints:            endpoints,
+		RequestImports:       mergeApiImports(endpoints, true),
+		ResponseImports:      mergeApiImports(endpoints, false),
+	}
+}
+
+// renderApiTemplate 渲染内置 api 模板并 gofmt，渲染结果不是合法 Go 代码时测试失败
+func renderApiTemplate(t *testing.T, name string, params ApiExtraParams) string {
+	t.Helper()
+	tpl, err := template.New(name).Funcs(apiTplFuncMap()).ParseFS(TemplatesFS, "generate/api/"+name)
+	if err != nil {
+		t.Fatalf("parse api template %s: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, name, params); err != nil {
+		t.Fatalf("render api template %s: %v", name, err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("api template %s renders invalid Go: %v\n%s", name, err, buf.String())
+	}
+	return string(formatted)
+}
@@ -0,0 +1,149 @@
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// receiverMethods 返回文件中接收者类型为 receiverName（含指针接收者）的方法名集合
+func receiverMethods(goFilepath, receiverName string) (map[string]struct{}, error) {
+	file, parseErr := parser.ParseFile(token.NewFileSet(), goFilepath, nil, 0)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse file %s error: %v", goFilepath, parseErr)
+	}
+	methods := make(map[string]struct{})
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+		recvType := funcDecl.Recv.List[0].Type
+		if star, isStar := recvType.(*ast.StarExpr); isStar {
+			recvType = star.X
+		}
+		if ident, isIdent := recvType.(*ast.Ident); isIdent && ident.Name == receiverName {
+			methods[funcDecl.Name.Name] = struct{}{}
+		}
+	}
+	return methods, nil
+}
+
+// addMethodsToInterface 将接收者的多个方法一次性追加到接口声明中，方法签名取自接收者方法的定义，
+// 接口中已存在的方法跳过，整个文件只解析、写入一次
+func addMethodsToInterface(goFilepath, receiverName, interfaceName string, methodNames []string) error {
+	src, readErr := os.ReadFile(goFilepath)
+	if readErr != nil {
+		return fmt.Errorf("read file %s error: %v", goFilepath, readErr)
+	}
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, goFilepath, src, parser.ParseComments)
+	if parseErr != nil {
+		return fmt.Errorf("parse file %s error: %v", goFilepath, parseErr)
+	}
+
+	var interfaceType *ast.InterfaceType
+	signatures := make(map[string]string)
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.TypeSpec:
+			if iface, ok := n.Type.(*ast.InterfaceType); ok && n.Name.Name == interfaceName {
+				interfaceType = iface
+			}
+		case *ast.FuncDecl:
+			if n.Recv == nil || len(n.Recv.List) == 0 {
+				return false
+			}
+			recvType := n.Recv.List[0].Type
+			if star, isStar := recvType.(*ast.StarExpr); isStar {
+				recvType = star.X
+			}
+			if ident, isIdent := recvType.(*ast.Ident); isIdent && ident.Name == receiverName {
+				// 签名为参数列表起始至返回值结束的源码，如 (ctx *gin.Context) error
+				signatures[n.Name.Name] = string(src[fset.Position(n.Type.Params.Pos()).Offset:fset.Position(n.Type.End()).Offset])
+			}
+			return false
+		}
+		return true
+	})
+	if interfaceType == nil {
+		return fmt.Errorf("interface %s not found in %s", interfaceName, goFilepath)
+	}
+	existing := make(map[string]struct{})
+	for _, method := range interfaceType.Methods.List {
+		for _, name := range method.Names {
+			existing[name.Name] = struct{}{}
+		}
+	}
+
+	var builder strings.Builder
+	for _, methodName := range methodNames {
+		if _, ok := existing[methodName]; ok {
+			continue
+		}
+		signature, ok := signatures[methodName]
+		if !ok {
+			return fmt.Errorf("method %s of %s not found in %s", methodName, receiverName, goFilepath)
+		}
+		builder.WriteString("\t" + methodName + signature + "\n")
+	}
+	if builder.Len() == 0 {
+		return nil
+	}
+	edit := insertBeforeClosingBrace(src, fset.Position(interfaceType.Methods.Closing).Offset, builder.String())
+	return writeFormattedFile(goFilepath, applyTextEdits(src, []textEdit{edit}))
+}
+
+// appendStatementsToFunc 将多条语句一次性追加到函数体末尾
+func appendStatementsToFunc(goFilepath, funcName string, statements []string) error {
+	src, readErr := os.ReadFile(goFilepath)
+	if readErr != nil {
+		return fmt.Errorf("read file %s error: %v", goFilepath, readErr)
+	}
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, goFilepath, src, parser.ParseComments)
+	if parseErr != nil {
+		return fmt.Errorf("parse file %s error: %v", goFilepath, parseErr)
+	}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || funcDecl.Name.Name != funcName || funcDecl.Body == nil {
+			continue
+		}
+		var builder strings.Builder
+		for _, statement := range statements {
+			builder.WriteString("\t" + statement + "\n")
+		}
+		edit := insertBeforeClosingBrace(src, fset.Position(funcDecl.Body.Rbrace).Offset, builder.String())
+		return writeFormattedFile(goFilepath, applyTextEdits(src, []textEdit{edit}))
+	}
+	return fmt.Errorf("func %s not found in %s", funcName, goFilepath)
+}
+
+// insertBeforeClosingBrace 在右花括号所在行之前插入内容，右花括号与其他内容同行（如 interface{}）时在其之前换行插入
+func insertBeforeClosingBrace(src []byte, closeOffset int, text string) textEdit {
+	lineStart := lineStartOffset(src, closeOffset)
+	if strings.TrimSpace(string(src[lineStart:closeOffset])) != "" {
+		return textEdit{Start: closeOffset, End: closeOffset, Text: "\n" + text}
+	}
+	return textEdit{Start: lineStart, End: lineStart, Text: text}
+}
+
+// writeFormattedFile gofmt 后写入文件，格式化失败说明插入的内容有误，直接返回错误
+func writeFormattedFile(goFilepath string, src []byte) error {
+	formatted, formatErr := format.Source(src)
+	if formatErr != nil {
+		return fmt.Errorf("format file %s error: %v", goFilepath, formatErr)
+	}
+	if err := trackFiles(goFilepath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(goFilepath, formatted, 0644); err != nil {
+		return fmt.Errorf("write file %s error: %v", goFilepath, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddMethodsToInterface(t *testing.T) {
+	goFile := filepath.Join(t.TempDir(), "order.go")
+	src := `package svcorder
+
+import "github.com/gin-gonic/gin"
+
+type OrderSvc interface {
+	Detail(ctx *gin.Context) error
+}
+
+type orderSvc struct{}
+
+func (svc *orderSvc) Detail(ctx *gin.Context) error { return nil }
+
+// Approve 审批订单
+func (svc *orderSvc) Approve(ctx *gin.Context, req *ApproveReq) (*ApproveResp, error) { return nil, nil }
+
+func (svc *orderSvc) Reject(ctx *gin.Context, reason string) error { return nil }
+`
+	if err := os.WriteFile(goFile, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := addMethodsToInterface(goFile, "orderSvc", "OrderSvc", []string{"Detail", "Approve", "Reject"}); err != nil {
+		t.Fatalf("addMethodsToInterface error: %v", err)
+	}
+	content := readFile(t, goFile)
+	want := "type OrderSvc interface {\n" +
+		"\tDetail(ctx *gin.Context) error\n" +
+		"\tApprove(ctx *gin.Context, req *ApproveReq) (*ApproveResp, error)\n" +
+		"\tReject(ctx *gin.Context, reason string) error\n" +
+		"}"
+	if !strings.Contains(content, want) {
+		t.Errorf("interface not updated:\n%s", content)
+	}
+
+	methods, err := receiverMethods(goFile, "orderSvc")
+	if err != nil {
+		t.Fatalf("receiverMethods error: %v", err)
+	}
+	if len(methods) != 3 {
+		t.Errorf("receiver methods = %v", methods)
+	}
+
+	if err := addMethodsToInterface(goFile, "orderSvc", "OrderSvc", []string{"Cancel"}); err == nil {
+		t.Error("expected error for method without definition")
+	}
+	if err := addMethodsToInterface(goFile, "orderSvc", "MissingSvc", []string{"Detail"}); err == nil {
+		t.Error("expected error for missing interface")
+	}
+}
+
+func TestAppendStatementsToFunc(t *testing.T) {
+	goFile := filepath.Join(t.TempDir(), "order.go")
+	src := `package router
+
+func orderRouter(groups *RouterGroups) {
+	orderCtr := NewOrderCtr()
+	v1RouterGroup := groups.V1
+	// 订单详情
+	v1RouterGroup.GET("/orders/:orderID", orderCtr.Detail)
+}
+
+func emptyRouter() {}
+`
+	if err := os.WriteFile(goFile, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	statements := []string{
+		`v1RouterGroup.POST("/orders/:orderID/approve", orderCtr.Approve)`,
+		`v1RouterGroup.POST("/orders/:orderID/reject", orderCtr.Reject)`,
+	}
+	if err := appendStatementsToFunc(goFile, "orderRouter", statements); err != nil {
+		t.Fatalf("appendStatementsToFunc error: %v", err)
+	}
+	content := readFile(t, goFile)
+	want := "\t// 订单详情\n" +
+		"\tv1RouterGroup.GET(\"/orders/:orderID\", orderCtr.Detail)\n" +
+		"\tv1RouterGroup.POST(\"/orders/:orderID/approve\", orderCtr.Approve)\n" +
+		"\tv1RouterGroup.POST(\"/orders/:orderID/reject\", orderCtr.Reject)\n" +
+		"}"
+	if !strings.Contains(content, want) {
+		t.Errorf("router func not updated:\n%s", content)
+	}
+
+	// 函数体与右花括号同行
+	if err := appendStatementsToFunc(goFile, "emptyRouter", []string{"println()"}); err != nil {
+		t.Fatalf("appendStatementsToFunc error: %v", err)
+	}
+	if content := readFile(t, goFile); !strings.Contains(content, "func emptyRouter() {\n\tprintln()\n}") {
+		t.Errorf("empty func not updated:\n%s", content)
+	}
+
+	if err := appendStatementsToFunc(goFile, "missingRouter", statements); err == nil {
+		t.Error("expected error for missing func")
+	}
+}
@@ -0,0 +1,589 @@
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+	"golang.org/x/mod/modfile"
+)
+
+// apiRoute 从 internal/router 路由注册中解析出的接口
+type apiRoute struct {
+	Method      string // HTTP 方法，大写，如 GET
+	Path        string // gin 路由路径，如 /v1/demoapp/users/:userID
+	OperationID string // 操作名，由控制器变量名与方法名组成，如 userCreate
+	Tags        []string
+	Summary     string
+	Description string
+	Request     types.Type // 请求结构体，未解析到时为 nil
+	BindBody    bool       // 请求体绑定（ShouldBindJSON 等）
+	BindQuery   bool       // 查询参数绑定（ShouldBindQuery 等）
+	Response    types.Type // gincontext.Success 返回的数据类型，为 nil 时表示字符串
+}
+
+// apiScanResult 接口扫描结果
+type apiScanResult struct {
+	Routes []apiRoute
+	loader *goSourceLoader
+}
+
+// httpRouteMethods gin RouterGroup 上注册路由的方法
+var httpRouteMethods = map[string]struct{}{
+	"GET": {}, "POST": {}, "PUT": {}, "DELETE": {}, "PATCH": {}, "HEAD": {}, "OPTIONS": {},
+}
+
+// scanApiRoutes 解析应用 internal/router 下的路由注册，并结合控制器方法体推断请求、响应类型
+func scanApiRoutes(appDir string, appInfo AppInfo) (*apiScanResult, error) {
+	loader, loaderErr := newGoSourceLoader(appDir, appInfo.ProjectRootPath)
+	if loaderErr != nil {
+		return nil, loaderErr
+	}
+	routerDir := filepath.Join(appDir, "internal", "router")
+	routerFiles, parseErr := loader.parseDir(routerDir)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse router dir error: %v", parseErr)
+	}
+	if len(routerFiles) == 0 {
+		return nil, fmt.Errorf("no router files found in %s", routerDir)
+	}
+
+	var routes []apiRoute
+	operationIDs := make(map[string]int)
+	for _, file := range routerFiles {
+		imports := fileImports(file)
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			for _, reg := range scanRouterFunc(funcDecl, imports, appInfo.AppName) {
+				route := apiRoute{
+					Method: reg.Method,
+					Path:   reg.Path,
+				}
+				operationID := strings.TrimSuffix(reg.CtrVar, "Ctr") + gutil.FirstLetterToUpper(reg.Handler)
+				operationIDs[operationID]++
+				if count := operationIDs[operationID]; count > 1 {
+					operationID = fmt.Sprintf("%s%d", operationID, count)
+				}
+				route.OperationID = operationID
+				if reg.CtrPkgPath != "" {
+					if err := loader.resolveHandler(&route, reg.CtrPkgPath, reg.Handler); err != nil {
+						fmt.Printf("[Api] Skipped handler analysis for %s %s: %v\n", route.Method, route.Path, err)
+					}
+				}
+				routes = append(routes, route)
+			}
+		}
+	}
+	return &apiScanResult{Routes: routes, loader: loader}, nil
+}
+
+// routerRegistration 路由函数中的一条注册语句
+type routerRegistration struct {
+	Method     string
+	Path       string
+	CtrVar     string // 控制器变量名，如 userCtr
+	CtrPkgPath string // 控制器包导入路径
+	Handler    string // 控制器方法名
+}
+
+// scanRouterFunc 解析路由函数：记录路由组变量的前缀与控制器变量的来源包，再收集 group.METHOD(path, ctr.Handler) 注册
+func scanRouterFunc(funcDecl *ast.FuncDecl, imports map[string]string, appName string) []routerRegistration {
+	groupPrefixes := make(map[string]string)
+	ctrPackages := make(map[string]string)
+	var registrations []routerRegistration
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			lhs, isIdent := node.Lhs[0].(*ast.Ident)
+			call, isCall := node.Rhs[0].(*ast.CallExpr)
+			if !isIdent || !isCall {
+				return true
+			}
+			sel, isSel := call.Fun.(*ast.SelectorExpr)
+			if !isSel {
+				return true
+			}
+			recv, _ := sel.X.(*ast.Ident)
+			switch {
+			case sel.Sel.Name == "MustGetGroup" || sel.Sel.Name == "GetGroup":
+				groupPrefixes[lhs.Name] = routerGroupPrefix(call, appName)
+			case sel.Sel.Name == "Group" && recv != nil:
+				if parentPrefix, ok := groupPrefixes[recv.Name]; ok && len(call.Args) > 0 {
+					groupPrefixes[lhs.Name] = joinRoutePath(parentPrefix, stringLiteral(call.Args[0]))
+				}
+			case recv != nil && strings.HasPrefix(sel.Sel.Name, "New"):
+				if pkgPath, ok := imports[recv.Name]; ok {
+					ctrPackages[lhs.Name] = pkgPath
+				}
+			}
+		case *ast.CallExpr:
+			sel, isSel := node.Fun.(*ast.SelectorExpr)
+			if !isSel || len(node.Args) < 2 {
+				return true
+			}
+			if _, isMethod := httpRouteMethods[sel.Sel.Name]; !isMethod {
+				return true
+			}
+			group, isIdent := sel.X.(*ast.Ident)
+			if !isIdent {
+				return true
+			}
+			prefix, isGroup := groupPrefixes[group.Name]
+			if !isGroup {
+				return true
+			}
+			handler, isHandlerSel := node.Args[len(node.Args)-1].(*ast.SelectorExpr)
+			if !isHandlerSel {
+				return true
+			}
+			ctrVar, _ := handler.X.(*ast.Ident)
+			if ctrVar == nil {
+				return true
+			}
+			registrations = append(registrations, routerRegistration{
+				Method:     sel.Sel.Name,
+				Path:       joinRoutePath(prefix, stringLiteral(node.Args[0])),
+				CtrVar:     ctrVar.Name,
+				CtrPkgPath: ctrPackages[ctrVar.Name],
+				Handler:    handler.Sel.Name,
+			})
+		}
+		return true
+	})
+	return registrations
+}
+
+// routerGroupPrefix 路由组前缀：ginserver.ApiVersionV1 对应 /v1/<app>，与控制器 @Router 注释一致；字符串参数直接作为前缀
+func routerGroupPrefix(call *ast.CallExpr, appName string) string {
+	if len(call.Args) == 0 {
+		return ""
+	}
+	if sel, ok := call.Args[0].(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "ApiVersion") {
+		version := strings.ToLower(strings.TrimPrefix(sel.Sel.Name, "ApiVersion"))
+		return "/" + version + "/" + appName
+	}
+	return joinRoutePath("", stringLiteral(call.Args[0]))
+}
+
+func joinRoutePath(prefix, path string) string {
+	joined := strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	if joined != "/" {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+	return joined
+}
+
+func stringLiteral(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// fileImports 文件内导入的包名到导入路径的映射，未显式命名时取导入路径推断的包名
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := guessPackageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// guessPackageName 由导入路径推断包名，如 gopkg.in/yaml.v3 -> yaml，github.com/foo/bar/v2 -> bar
+func guessPackageName(importPath string) string {
+	segments := strings.Split(importPath, "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 1 && len(name) > 1 && name[0] == 'v' && isDigits(name[1:]) {
+		name = segments[len(segments)-2]
+	}
+	if idx := strings.Index(name, ".v"); idx > 0 && isDigits(name[idx+2:]) {
+		name = name[:idx]
+	}
+	return strings.ReplaceAll(name, "-", "")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveHandler 在控制器包中查找处理方法，解析请求变量、绑定方式、响应数据类型及 swag 注释
+func (l *goSourceLoader) resolveHandler(route *apiRoute, ctrPkgPath, handlerName string) error {
+	pkg, loadErr := l.load(ctrPkgPath)
+	if loadErr != nil {
+		return loadErr
+	}
+	var funcDecl *ast.FuncDecl
+	for _, file := range pkg.files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Name.Name == handlerName && fn.Body != nil {
+				funcDecl = fn
+				break
+			}
+		}
+	}
+	if funcDecl == nil {
+		return fmt.Errorf("method %s not found in %s", handlerName, ctrPkgPath)
+	}
+
+	applySwagComments(route, funcDecl)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			if route.Request == nil && len(node.Names) == 1 && node.Type != nil {
+				if obj := pkg.info.Defs[node.Names[0]]; obj != nil && isValidType(obj.Type()) {
+					route.Request = obj.Type()
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "ShouldBindJSON", "BindJSON", "ShouldBindBodyWithJSON":
+				route.BindBody = true
+			case "ShouldBindQuery", "BindQuery":
+				route.BindQuery = true
+			case "ShouldBind", "Bind":
+				if route.Method == "GET" || route.Method == "DELETE" {
+					route.BindQuery = true
+				} else {
+					route.BindBody = true
+				}
+			case "Success":
+				if len(node.Args) < 2 {
+					return true
+				}
+				dataType := pkg.info.TypeOf(node.Args[1])
+				if !isValidType(dataType) {
+					return true
+				}
+				if basic, isBasic := dataType.Underlying().(*types.Basic); isBasic && basic.Info()&types.IsString != 0 {
+					return true
+				}
+				if ptr, isPtr := dataType.(*types.Pointer); isPtr {
+					dataType = ptr.Elem()
+				}
+				route.Response = dataType
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+// applySwagComments 读取控制器方法上的 @Tags、@Summary 注释，首行描述作为接口说明
+func applySwagComments(route *apiRoute, funcDecl *ast.FuncDecl) {
+	if funcDecl.Doc == nil {
+		return
+	}
+	for _, comment := range funcDecl.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		switch {
+		case strings.HasPrefix(line, "@Tags "):
+			for _, tag := range strings.Split(strings.TrimPrefix(line, "@Tags "), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					route.Tags = append(route.Tags, tag)
+				}
+			}
+		case strings.HasPrefix(line, "@Summary "):
+			route.Summary = strings.TrimSpace(strings.TrimPrefix(line, "@Summary "))
+		case !strings.HasPrefix(line, "@") && route.Description == "" && line != "":
+			route.Description = strings.TrimSpace(strings.TrimPrefix(line, funcDecl.Name.Name))
+		}
+	}
+}
+
+func isValidType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	basic, ok := t.(*types.Basic)
+	return !ok || basic.Kind() != types.Invalid
+}
+
+// loadedPackage 已类型检查的包
+type loadedPackage struct {
+	pkg   *types.Package
+	info  *types.Info
+	files []*ast.File
+}
+
+// goSourceLoader 基于源码的类型加载器：工作区内模块从源码类型检查，标准库使用默认导入器，
+// 其余外部依赖返回空包（接口文档用到的 golib 类型见 wellKnownTypes），类型错误均忽略
+type goSourceLoader struct {
+	fset        *token.FileSet
+	moduleDirs  map[string]string // 模块路径 -> 目录
+	packages    map[string]*loadedPackage
+	stdImporter types.Importer
+	fieldDocs   map[*types.Var]string
+}
+
+func newGoSourceLoader(appDir, projectRoot string) (*goSourceLoader, error) {
+	moduleDirs := make(map[string]string)
+	appModulePath, modErr := readModulePath(filepath.Join(appDir, "go.mod"))
+	if modErr != nil {
+		return nil, modErr
+	}
+	moduleDirs[appModulePath] = appDir
+
+	goWorkPath := filepath.Join(projectRoot, "go.work")
+	if content, readErr := os.ReadFile(goWorkPath); readErr == nil {
+		workFile, parseErr := modfile.ParseWork(goWorkPath, content, nil)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse go.work error: %v", parseErr)
+		}
+		for _, use := range workFile.Use {
+			moduleDir := filepath.Join(projectRoot, use.Path)
+			if modulePath, err := readModulePath(filepath.Join(moduleDir, "go.mod")); err == nil {
+				moduleDirs[modulePath] = moduleDir
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	return &goSourceLoader{
+		fset:        fset,
+		moduleDirs:  moduleDirs,
+		packages:    make(map[string]*loadedPackage),
+		stdImporter: importer.Default(),
+		fieldDocs:   make(map[*types.Var]string),
+	}, nil
+}
+
+func readModulePath(goModPath string) (string, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("read %s error: %v", goModPath, err)
+	}
+	modulePath := modfile.ModulePath(content)
+	if modulePath == "" {
+		return "", fmt.Errorf("module declaration not found in %s", goModPath)
+	}
+	return modulePath, nil
+}
+
+// Import 实现 types.Importer
+func (l *goSourceLoader) Import(importPath string) (*types.Package, error) {
+	pkg, err := l.load(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.pkg, nil
+}
+
+// load 加载并缓存包
+func (l *goSourceLoader) load(importPath string) (*loadedPackage, error) {
+	if pkg, ok := l.packages[importPath]; ok {
+		return pkg, nil
+	}
+	loaded := &loadedPackage{}
+	l.packages[importPath] = loaded
+
+	if dir, isLocal := l.localDir(importPath); isLocal {
+		files, parseErr := l.parseDir(dir)
+		if parseErr != nil {
+			delete(l.packages, importPath)
+			return nil, parseErr
+		}
+		loaded.files = files
+		loaded.info = &types.Info{
+			Types: make(map[ast.Expr]types.TypeAndValue),
+			Defs:  make(map[*ast.Ident]types.Object),
+			Uses:  make(map[*ast.Ident]types.Object),
+		}
+		conf := types.Config{Importer: l, Error: func(error) {}}
+		loaded.pkg, _ = conf.Check(importPath, l.fset, files, loaded.info)
+		l.collectFieldDocs(loaded)
+		return loaded, nil
+	}
+
+	if !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		if pkg, err := l.stdImporter.Import(importPath); err == nil {
+			loaded.pkg = pkg
+			return loaded, nil
+		}
+	}
+	loaded.pkg = types.NewPackage(importPath, guessPackageName(importPath))
+	for _, known := range wellKnownTypes[importPath] {
+		l.declareWellKnownType(loaded.pkg, known)
+	}
+	loaded.pkg.MarkComplete()
+	return loaded, nil
+}
+
+// localDir 工作区模块内的包对应的目录
+func (l *goSourceLoader) localDir(importPath string) (string, bool) {
+	var matched string
+	for modulePath := range l.moduleDirs {
+		if (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) && len(modulePath) > len(matched) {
+			matched = modulePath
+		}
+	}
+	if matched == "" {
+		return "", false
+	}
+	return filepath.Join(l.moduleDirs[matched], filepath.FromSlash(strings.TrimPrefix(importPath, matched))), true
+}
+
+// parseDir 解析目录下的非测试 Go 文件，按文件名排序
+func (l *goSourceLoader) parseDir(dir string) ([]*ast.File, error) {
+	entries, readErr := os.ReadDir(dir)
+	if readErr != nil {
+		return nil, readErr
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var files []*ast.File
+	for _, name := range names {
+		file, parseErr := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// collectFieldDocs 记录结构体字段的行尾注释或文档注释，作为字段描述
+func (l *goSourceLoader) collectFieldDocs(pkg *loadedPackage) {
+	for _, file := range pkg.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if !ok || len(field.Names) == 0 {
+				return true
+			}
+			doc := field.Comment
+			if doc == nil {
+				doc = field.Doc
+			}
+			if doc == nil {
+				return true
+			}
+			text := strings.TrimSpace(doc.Text())
+			for _, name := range field.Names {
+				if v, isVar := pkg.info.Defs[name].(*types.Var); isVar {
+					l.fieldDocs[v] = text
+				}
+			}
+			return true
+		})
+	}
+}
+
+// wellKnownField 预置类型的字段
+type wellKnownField struct {
+	Name string
+	Kind types.BasicKind // Invalid 表示 any
+	Tag  string
+	Doc  string
+}
+
+// wellKnownType 预置类型：无法从源码加载的外部依赖中，接口 DTO 常用的结构体（与 golib 定义保持一致）
+type wellKnownType struct {
+	Name   string
+	Fields []wellKnownField
+}
+
+var wellKnownTypes = map[string][]wellKnownType{
+	"github.com/morehao/golib/biz/gobject": {
+		{
+			Name: "PageQuery",
+			Fields: []wellKnownField{
+				{Name: "Page", Kind: types.Int, Tag: `json:"page" form:"page"`, Doc: "页码"},
+				{Name: "PageSize", Kind: types.Int, Tag: `json:"pageSize" form:"pageSize" binding:"max=1000"`, Doc: "每页数据条数"},
+			},
+		},
+		{
+			Name: "OperatorBaseInfo",
+			Fields: []wellKnownField{
+				{Name: "CreatedBy", Kind: types.Uint, Tag: `json:"createdBy"`, Doc: "创建人id"},
+				{Name: "CreatedAt", Kind: types.Int64, Tag: `json:"createdAt"`, Doc: "创建时间"},
+				{Name: "UpdatedBy", Kind: types.Uint, Tag: `json:"updatedBy"`, Doc: "更新人id"},
+				{Name: "UpdatedAt", Kind: types.Int64, Tag: `json:"updatedAt"`, Doc: "更新时间"},
+			},
+		},
+	},
+	"github.com/morehao/golib/biz/gcontext/gincontext": {
+		{
+			Name: "DtoRender",
+			Fields: []wellKnownField{
+				{Name: "Code", Kind: types.Int, Tag: `json:"code"`, Doc: "错误码，0 表示成功"},
+				{Name: "Msg", Kind: types.String, Tag: `json:"msg"`, Doc: "提示信息"},
+				{Name: "Data", Kind: types.Invalid, Tag: `json:"data"`, Doc: "业务数据"},
+				{Name: "RequestID", Kind: types.String, Tag: `json:"requestID"`, Doc: "请求 ID"},
+			},
+		},
+	},
+}
+
+func (l *goSourceLoader) declareWellKnownType(pkg *types.Package, known wellKnownType) {
+	vars := make([]*types.Var, 0, len(known.Fields))
+	tags := make([]string, 0, len(known.Fields))
+	for _, field := range known.Fields {
+		var fieldType types.Type = types.NewInterfaceType(nil, nil)
+		if field.Kind != types.Invalid {
+			fieldType = types.Typ[field.Kind]
+		}
+		v := types.NewField(token.NoPos, pkg, field.Name, fieldType, false)
+		l.fieldDocs[v] = field.Doc
+		vars = append(vars, v)
+		tags = append(tags, field.Tag)
+	}
+	typeName := types.NewTypeName(token.NoPos, pkg, known.Name, nil)
+	types.NewNamed(typeName, types.NewStruct(vars, tags), nil)
+	pkg.Scope().Insert(typeName)
+}
+
+// wellKnownNamed 取预置类型，用于构造响应外层结构
+func (l *goSourceLoader) wellKnownNamed(importPath, name string) types.Type {
+	pkg, err := l.load(importPath)
+	if err != nil || pkg.pkg == nil {
+		return nil
+	}
+	obj := pkg.pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil
+	}
+	return obj.Type()
+}
@@ -0,0 +1,411 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/morehao/golib/gutil"
+	"github.com/spf13/cobra"
+)
+
+// 客户端语言
+const clientLangTS = "ts"
+
+// tplModeClient 客户端模板子目录
+const tplModeClient = "client"
+
+var clientCmd = &cobra.Command{
+	Use:   "client",
+	Short: "Generate a typed API client for the frontend",
+	Long: `Read the routes and dto structs of an app (same analysis as the openapi command) and write a typed
+TypeScript client to frontend/src/api/<app>/: interfaces for every request/response struct and a fetch-based
+function per endpoint. Files are fully regenerated and only rewritten when their content changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, _ := cmd.Flags().GetString("app")
+		lang, _ := cmd.Flags().GetString("lang")
+		outputDir, _ := cmd.Flags().GetString("output")
+		if appName == "" {
+			fmt.Println("Please provide an app name using --app flag")
+			return
+		}
+		if lang != clientLangTS {
+			fmt.Printf("Unsupported client language: %s, only %s is supported\n", lang, clientLangTS)
+			return
+		}
+		currentDir, _ := os.Getwd()
+		appDir, resolveErr := resolveAppDir(currentDir, appName)
+		if resolveErr != nil {
+			fmt.Printf("Resolve app directory error: %v\n", resolveErr)
+			return
+		}
+		if err := runInTransaction(func() error { return genTSClient(appDir, outputDir) }); err != nil {
+			fmt.Printf("Error generating: %v\n", err)
+			return
+		}
+		fmt.Println("Generated successfully")
+	},
+}
+
+func init() {
+	clientCmd.Flags().StringP("app", "a", "", "App name to generate the client for (e.g., demoapp)")
+	clientCmd.Flags().String("lang", clientLangTS, "Client language, only ts is supported")
+	clientCmd.Flags().StringP("output", "o", "", "Output directory (default frontend/src/api/<app>)")
+	Cmd.AddCommand(clientCmd)
+}
+
+// tsClientData 客户端模板参数
+type tsClientData struct {
+	AppName     string
+	Interfaces  []tsInterface
+	Functions   []tsFunction
+	TypeImports []string // index.ts 引用的类型
+}
+
+type tsInterface struct {
+	Name   string
+	Fields []tsField
+}
+
+type tsField struct {
+	Name        string
+	Type        string
+	Description string
+	Optional    bool
+}
+
+type tsFunction struct {
+	Name         string
+	Summary      string
+	Method       string
+	Path         string // gin 路由路径
+	Params       string // 参数列表，如 req: UserUpdateReq, init?: RequestInit
+	Destructure  string // 拆分路径参数与请求体的语句，无需拆分时为空
+	PathExpr     string // 请求路径表达式
+	Options      string // request 的 options 参数
+	ResponseType string
+}
+
+// genTSClient 扫描应用接口并生成 TypeScript 客户端
+func genTSClient(appDir, outputDir string) error {
+	appInfo, appInfoErr := GetAppInfo(appDir)
+	if appInfoErr != nil {
+		return fmt.Errorf("get app info error: %v", appInfoErr)
+	}
+	workDir = appDir
+	if cfg == nil {
+		// 配置文件可选，仅用于 template_dir 等自定义模板配置
+		cfg = &Config{}
+		if configFilepath := filepath.Join(appDir, "config", "code_gen.yaml"); gutil.FileExists(configFilepath) {
+			gutil.LoadYamlConfig(configFilepath, &cfg)
+		}
+		cfg.appInfo = *appInfo
+	}
+	if outputDir == "" {
+		frontendDir, findErr := findFrontendDir(appInfo.ProjectRootPath)
+		if findErr != nil {
+			return findErr
+		}
+		outputDir = filepath.Join(frontendDir, "src", "api", appInfo.AppName)
+	}
+
+	scanRes, scanErr := scanApiRoutes(appDir, *appInfo)
+	if scanErr != nil {
+		return scanErr
+	}
+	data := buildTSClientData(appInfo.AppName, scanRes)
+
+	tplDir, tplErr := prepareTemplateDir(tplModeClient)
+	if tplErr != nil {
+		return tplErr
+	}
+	defer os.RemoveAll(tplDir)
+
+	clientFiles := []string{"types.ts", "request.ts", "index.ts"}
+	for _, name := range clientFiles {
+		if err := trackFiles(filepath.Join(outputDir, name)); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("create output dir error: %v", err)
+	}
+	for _, name := range clientFiles {
+		tpl, parseErr := template.New(name + ".tpl").Funcs(template.FuncMap{"join": strings.Join}).ParseFiles(filepath.Join(tplDir, name+".tpl"))
+		if parseErr != nil {
+			return fmt.Errorf("parse template %s error: %v", name, parseErr)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("render %s error: %v", name, err)
+		}
+		content := append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+		targetPath := filepath.Join(outputDir, name)
+		if existing, readErr := os.ReadFile(targetPath); readErr == nil && bytes.Equal(existing, content) {
+			fmt.Printf("[Client] Unchanged: %s\n", targetPath)
+			continue
+		}
+		if err := os.WriteFile(targetPath, content, 0644); err != nil {
+			return fmt.Errorf("write %s error: %v", name, err)
+		}
+		fmt.Printf("[Client] Generated: %s\n", targetPath)
+	}
+	return nil
+}
+
+// findFrontendDir 查找前端目录：create project 生成的仓库中 frontend/ 与 backend/ 同级，也兼容位于项目根目录下
+func findFrontendDir(projectRoot string) (string, error) {
+	for _, dir := range []string{projectRoot, filepath.Dir(projectRoot)} {
+		frontendDir := filepath.Join(dir, "frontend")
+		if info, err := os.Stat(frontendDir); err == nil && info.IsDir() {
+			return frontendDir, nil
+		}
+	}
+	return "", fmt.Errorf("frontend directory not found near %s, use --output to specify the output directory", projectRoot)
+}
+
+// tsTypeBuilder 将 go/types 类型转换为 TypeScript 类型，具名结构体生成 interface
+type tsTypeBuilder struct {
+	schemaBuilder *openAPISchemaBuilder
+	names         map[*types.TypeName]string
+	usedNames     map[string]struct{}
+	interfaces    []tsInterface
+}
+
+// buildTSClientData 将扫描到的接口转换为客户端模板参数：先处理请求类型（未标记 required 的字段可选），再处理响应类型
+func buildTSClientData(appName string, scanRes *apiScanResult) *tsClientData {
+	builder := &tsTypeBuilder{
+		schemaBuilder: newOpenAPISchemaBuilder(scanRes.loader),
+		names:         make(map[*types.TypeName]string),
+		usedNames:     make(map[string]struct{}),
+	}
+	requestTypes := make([]string, len(scanRes.Routes))
+	for i, route := range scanRes.Routes {
+		if route.Request != nil {
+			requestTypes[i] = builder.typeOf(route.Request, true)
+		}
+	}
+
+	data := &tsClientData{AppName: appName}
+	typeImports := make(map[string]struct{})
+	for i, route := range scanRes.Routes {
+		responseType := "string"
+		if route.Response != nil {
+			responseType = builder.typeOf(route.Response, false)
+		}
+		fn := tsFunction{
+			Name:         route.OperationID,
+			Summary:      route.Summary,
+			Method:       route.Method,
+			Path:         route.Path,
+			ResponseType: responseType,
+		}
+
+		// 路径参数优先取请求结构体中 uri 标签对应的字段
+		_, pathParams := openAPIPath(route.Path)
+		reqFields := make(map[string]struct{})
+		for _, field := range builder.schemaBuilder.requestFields(route.Request) {
+			if field.URIName != "" {
+				reqFields[field.URIName] = struct{}{}
+			}
+		}
+		var params, inReqParams []string
+		for _, name := range pathParams {
+			if _, ok := reqFields[name]; ok {
+				inReqParams = append(inReqParams, name)
+			} else {
+				params = append(params, name+": string | number")
+			}
+		}
+		if requestTypes[i] != "" {
+			params = append(params, "req: "+requestTypes[i])
+		}
+		params = append(params, "init?: RequestInit")
+		fn.Params = strings.Join(params, ", ")
+
+		payloadKey := ""
+		switch {
+		case route.BindBody && requestTypes[i] != "":
+			payloadKey = "body"
+		case route.BindQuery && requestTypes[i] != "":
+			payloadKey = "query"
+		}
+		destructured := payloadKey != "" && len(inReqParams) > 0
+		if destructured {
+			fn.Destructure = fmt.Sprintf("const { %s, ...%s } = req;", strings.Join(inReqParams, ", "), payloadKey)
+		}
+		fn.PathExpr = tsPathExpr(route.Path, reqFields, destructured)
+		switch {
+		case payloadKey == "":
+			fn.Options = "{ init }"
+		case destructured:
+			fn.Options = fmt.Sprintf("{ %s, init }", payloadKey)
+		default:
+			fn.Options = fmt.Sprintf("{ %s: req, init }", payloadKey)
+		}
+		data.Functions = append(data.Functions, fn)
+
+		for _, typeName := range []string{requestTypes[i], responseType} {
+			for name := range builder.usedNames {
+				if tsTypeReferences(typeName, name) {
+					typeImports[name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	sort.Slice(builder.interfaces, func(i, j int) bool {
+		return builder.interfaces[i].Name < builder.interfaces[j].Name
+	})
+	data.Interfaces = builder.interfaces
+	for name := range typeImports {
+		data.TypeImports = append(data.TypeImports, name)
+	}
+	sort.Strings(data.TypeImports)
+	return data
+}
+
+// tsTypeReferences 判断类型表达式是否引用了指定名称，如 UserPageListItem[] 引用 UserPageListItem
+func tsTypeReferences(typeExpr, name string) bool {
+	for _, token := range strings.FieldsFunc(typeExpr, func(r rune) bool {
+		return !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}) {
+		if token == name {
+			return true
+		}
+	}
+	return false
+}
+
+// tsPathExpr 请求路径表达式：无路径参数时为字符串字面量，否则为模板字符串
+func tsPathExpr(ginPath string, reqFields map[string]struct{}, destructured bool) string {
+	hasParam := false
+	path := ginPathParamPattern.ReplaceAllStringFunc(ginPath, func(match string) string {
+		hasParam = true
+		name := match[1:]
+		if _, inReq := reqFields[name]; inReq && !destructured {
+			name = "req." + name
+		}
+		return "${encodeURIComponent(String(" + name + "))}"
+	})
+	if !hasParam {
+		return "'" + path + "'"
+	}
+	return "`" + path + "`"
+}
+
+// typeOf 返回类型对应的 TypeScript 类型表达式，isRequest 表示类型来自请求参数
+func (b *tsTypeBuilder) typeOf(t types.Type, isRequest bool) string {
+	switch typ := t.(type) {
+	case *types.Pointer:
+		return b.typeOf(typ.Elem(), isRequest)
+	case *types.Alias:
+		return b.typeOf(types.Unalias(typ), isRequest)
+	case *types.Named:
+		obj := typ.Obj()
+		if obj.Pkg() != nil {
+			switch obj.Pkg().Path() + "." + obj.Name() {
+			case "time.Time":
+				return "string"
+			case "encoding/json.RawMessage":
+				return "unknown"
+			}
+		}
+		st, isStruct := typ.Underlying().(*types.Struct)
+		if !isStruct {
+			return b.typeOf(typ.Underlying(), isRequest)
+		}
+		if name, ok := b.names[obj]; ok {
+			return name
+		}
+		name := obj.Name()
+		if _, taken := b.usedNames[name]; taken && obj.Pkg() != nil {
+			name = gutil.FirstLetterToUpper(obj.Pkg().Name()) + name
+		}
+		b.names[obj] = name
+		b.usedNames[name] = struct{}{}
+		b.interfaces = append(b.interfaces, tsInterface{Name: name, Fields: b.fieldsOf(st, isRequest)})
+		return name
+	case *types.Basic:
+		info := typ.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return "boolean"
+		case info&types.IsNumeric != 0:
+			return "number"
+		case info&types.IsString != 0:
+			return "string"
+		}
+		return "unknown"
+	case *types.Slice:
+		if basic, ok := typ.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return "string"
+		}
+		return tsArrayOf(b.typeOf(typ.Elem(), isRequest))
+	case *types.Array:
+		return tsArrayOf(b.typeOf(typ.Elem(), isRequest))
+	case *types.Map:
+		return "Record<string, " + b.typeOf(typ.Elem(), isRequest) + ">"
+	case *types.Struct:
+		var parts []string
+		for _, field := range b.fieldsOf(typ, isRequest) {
+			optional := ""
+			if field.Optional {
+				optional = "?"
+			}
+			parts = append(parts, fmt.Sprintf("%s%s: %s", field.Name, optional, field.Type))
+		}
+		return "{ " + strings.Join(parts, "; ") + " }"
+	}
+	return "unknown"
+}
+
+func tsArrayOf(elem string) string {
+	if strings.ContainsAny(elem, "| ") && !strings.HasPrefix(elem, "Record<") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+// fieldsOf 结构体字段：属性名依次取 json、uri、form 标签，请求类型中未标记 required 的字段可选，oneof 规则生成字面量联合类型
+func (b *tsTypeBuilder) fieldsOf(st *types.Struct, isRequest bool) []tsField {
+	var fields []tsField
+	for _, field := range b.schemaBuilder.flattenFields(st) {
+		name := field.JSONName
+		if name == "" {
+			name = field.URIName
+		}
+		if name == "" {
+			name = field.QueryName
+		}
+		if name == "" {
+			continue
+		}
+		fieldType := b.typeOf(field.Var.Type(), isRequest)
+		if len(field.Schema.Enum) > 0 {
+			literals := make([]string, 0, len(field.Schema.Enum))
+			for _, value := range field.Schema.Enum {
+				if str, isStr := value.(string); isStr {
+					literals = append(literals, strconv.Quote(str))
+				} else {
+					literals = append(literals, fmt.Sprint(value))
+				}
+			}
+			fieldType = strings.Join(literals, " | ")
+		}
+		_, isPointer := field.Var.Type().(*types.Pointer)
+		fields = append(fields, tsField{
+			Name:        name,
+			Type:        fieldType,
+			Description: field.Schema.Description,
+			Optional:    isPointer || isRequest && !field.Required,
+		})
+	}
+	return fields
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateTSClient 生成 TypeScript 客户端，重复生成时文件不变
+func TestGenerateTSClient(t *testing.T) {
+	resetGenerateState()
+	restore := chdirToExample(t)
+	defer restore()
+	if err := os.MkdirAll("frontend", 0755); err != nil {
+		t.Fatalf("mkdir frontend: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "client", "--lang", "ts", "--app", "demoapp"); err != nil {
+			t.Errorf("Failed to execute client command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	outputDir := filepath.Join("frontend", "src", "api", "demoapp")
+
+	typesSrc := readFile(t, filepath.Join(outputDir, "types.ts"))
+	for _, want := range []string{
+		"export interface ApiResponse<T> {",
+		"export interface UserDetailResp {\n  /** 自增 ID */\n  userID: number;",
+		"  list: UserPageListItem[];",
+		"export interface UserPageListReq {\n  /** 页码 */\n  page?: number;",
+		// uri 字段保留为路径参数，binding:required 字段必填
+		"export interface UserUpdateReq {\n  /** 自增 ID */\n  userID: number;",
+		"  createdAt: number;",
+	} {
+		if !strings.Contains(typesSrc, want) {
+			t.Errorf("types.ts missing %q:\n%s", want, typesSrc)
+		}
+	}
+
+	indexSrc := readFile(t, filepath.Join(outputDir, "index.ts"))
+	for _, want := range []string{
+		"import type { UserCreateReq, UserCreateResp, UserDeleteReq, UserDetailReq, UserDetailResp, UserPageListReq, UserPageListResp, UserUpdateReq } from './types';",
+		"export function userCreate(req: UserCreateReq, init?: RequestInit): Promise<UserCreateResp> {\n  return request<UserCreateResp>('POST', '/v1/demoapp/users', { body: req, init });",
+		"return request<UserPageListResp>('GET', '/v1/demoapp/users', { query: req, init });",
+		"return request<UserDetailResp>('GET', `/v1/demoapp/users/${encodeURIComponent(String(req.userID))}`, { init });",
+		"  const { userID, ...body } = req;\n  return request<string>('PUT', `/v1/demoapp/users/${encodeURIComponent(String(userID))}`, { body, init });",
+	} {
+		if !strings.Contains(indexSrc, want) {
+			t.Errorf("index.ts missing %q:\n%s", want, indexSrc)
+		}
+	}
+	if !strings.Contains(readFile(t, filepath.Join(outputDir, "request.ts")), "export async function request<T>(") {
+		t.Errorf("request.ts missing request function")
+	}
+
+	// 重复生成不改动文件
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "client", "--app", "demoapp"); err != nil {
+			t.Errorf("Failed to execute client command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if count := strings.Count(output, "[Client] Unchanged:"); count != 3 {
+		t.Errorf("second run should leave 3 files unchanged, got %d:\n%s", count, output)
+	}
+	if readFile(t, filepath.Join(outputDir, "index.ts")) != indexSrc {
+		t.Errorf("index.ts changed on regeneration")
+	}
+}
+
+func TestGenerateClientUnsupportedLang(t *testing.T) {
+	resetGenerateState()
+	restore := chdirToExample(t)
+	defer restore()
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "client", "--lang", "dart", "--app", "demoapp"); err != nil {
+			t.Errorf("Failed to execute client command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Unsupported client language: dart") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+}
@@ -0,0 +1,34 @@
+package generate
+
+import "fmt"
+
+// countPrimaryKeys 表的主键列数，大于 1 时为复合主键
+func countPrimaryKeys(columns []ColumnSchema) int {
+	count := 0
+	for _, column := range columns {
+		if column.IsPrimaryKey {
+			count++
+		}
+	}
+	return count
+}
+
+// buildKeyFields 复合主键的各列，按表中列顺序排列，单列主键或无主键时返回 nil。
+// 复合主键表生成 <Struct>Key 类型，DAO、service 与路由按全部主键列定位记录
+func buildKeyFields(fields []ModelField) []ModelField {
+	var keyFields []ModelField
+	for _, field := range fields {
+		if field.IsKeyField {
+			keyFields = append(keyFields, field)
+		}
+	}
+	return keyFields
+}
+
+// validateGrpcCompositeKey proto 中的主键统一为单个 id 字段，--with-grpc 暂不支持复合主键表
+func validateGrpcCompositeKey(tableName string, columns []ColumnSchema) error {
+	if countPrimaryKeys(columns) > 1 {
+		return fmt.Errorf("--with-grpc does not support table %s with a composite primary key", tableName)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// compositeKeyDDL 以 (user_id, role_id) 为复合主键的关联表
+const compositeKeyDDL = "CREATE TABLE `user_role` (\n" +
+	"  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',\n" +
+	"  `role_id` bigint unsigned NOT NULL COMMENT '角色ID',\n" +
+	"  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',\n" +
+	"  `created_by` bigint unsigned NOT NULL DEFAULT 0 COMMENT '创建人',\n" +
+	"  `updated_by` bigint unsigned NOT NULL DEFAULT 0 COMMENT '更新人',\n" +
+	"  `deleted_by` bigint unsigned NOT NULL DEFAULT 0 COMMENT '删除人',\n" +
+	"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+	"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+	"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+	"  PRIMARY KEY (`user_id`, `role_id`),\n" +
+	"  UNIQUE KEY `uk_remark` (`remark`)\n" +
+	") ENGINE=InnoDB COMMENT='用户角色表';\n"
+
+// TestGenerateModuleCompositeKey 复合主键表生成 <Struct>Key 类型、按 Key 查询删除的 DAO 方法与携带全部主键列的路由，
+// 主键列不进入 BaseInfo，--with-grpc 拒绝复合主键表
+func TestGenerateModuleCompositeKey(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "user_role.sql")
+	if err := os.WriteFile(ddlFile, []byte(compositeKeyDDL), 0644); err != nil {
+		t.Fatal(err)
+	}
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: userrole
+  description: 用户角色
+  table_name: user_role
+`)
+	if err := os.MkdirAll(filepath.Join("pkg", "testsetup"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	files := map[string][]string{
+		filepath.Join(appDir, "model", "user_role.go"): {
+			"type UserRoleKey struct { UserID uint // 用户ID RoleID uint // 角色ID }",
+			"func (e UserRoleEntity) Key() UserRoleKey {",
+			"func (l UserRoleEntityList) ToMap() map[UserRoleKey]UserRoleEntity {",
+			"m[v.Key()] = v",
+			"UserID uint `gorm:\"column:user_id;type:bigint unsigned;not null;default '';comment:用户ID;primaryKey\"`",
+			"DeletedAt gorm.DeletedAt",
+		},
+		filepath.Join(appDir, "dao", "user_role.go"): {
+			"func (d *UserRoleDao) GetByKey(ctx context.Context, key model.UserRoleKey) (*model.UserRoleEntity, error) {",
+			`Where("user_id = ? AND role_id = ?", key.UserID, key.RoleID).Take(&entity)`,
+			"func (d *UserRoleDao) UpdateMapByKey(ctx context.Context, key model.UserRoleKey, updateMap map[string]any) error {",
+			"func (d *UserRoleDao) DeleteByKey(ctx context.Context, key model.UserRoleKey, deletedBy uint) error {",
+			`Update("deleted_by", deletedBy)`,
+		},
+		filepath.Join(appDir, "internal", "dto", "dtouserrole", "request.go"): {
+			"type UserRoleCreateReq struct { UserID uint `json:\"userID\" form:\"userID\" binding:\"required\"` // 用户ID",
+			"type UserRoleDetailReq struct { UserID uint `json:\"-\" uri:\"userID\" binding:\"required\"` // 用户ID RoleID uint `json:\"-\" uri:\"roleID\" binding:\"required\"` // 角色ID }",
+		},
+		filepath.Join(appDir, "internal", "dto", "dtouserrole", "response.go"): {
+			"type UserRoleCreateResp struct { UserID uint `json:\"userID\"` // 用户ID RoleID uint `json:\"roleID\"` // 角色ID }",
+		},
+		filepath.Join(appDir, "internal", "service", "svcuserrole", "user_role.go"): {
+			"key := model.UserRoleKey{ UserID: req.UserID, RoleID: req.RoleID, }",
+			"userRoleEntity, err := dao.NewUserRoleDao().GetByKey(ctx, key)",
+			"if userRoleEntity == nil {",
+			"dao.NewUserRoleDao().DeleteByKey(ctx, key, deletedBy)",
+			"dao.NewUserRoleDao().UpdateMapByKey(ctx, key, updateMap)",
+			"if existRemarkEntity != nil && existRemarkEntity.Key() != key { return code.GetError(code.UserRoleAlreadyExistError) }",
+			`updateMap := map[string]any{ "remark": updateEntity.Remark, }`,
+			"UserID: insertEntity.UserID,",
+			"RoleID: v.RoleID,",
+		},
+		filepath.Join(appDir, "internal", "controller", "ctruserrole", "user_role.go"): {
+			"// @Param userID path int true \"userID\" // @Param roleID path int true \"roleID\"",
+			"// @Router /v1/demoapp/user-roles/{userID}/{roleID} [delete]",
+		},
+		filepath.Join(appDir, "internal", "router", "userrole.go"): {
+			`v1RouterGroup.GET("/user-roles/:userID/:roleID", userRoleCtr.Detail)`,
+			`v1RouterGroup.DELETE("/user-roles/:userID/:roleID", userRoleCtr.Delete)`,
+		},
+		filepath.Join(appDir, "internal", "controller", "ctruserrole", "user_role_test.go"): {
+			`router.PUT(userRoleTestPath+"/:userID/:roleID", userRoleCtr.Update)`,
+			`itemPath := fmt.Sprintf("%s/%v/%v", userRoleTestPath, createData.UserID, createData.RoleID)`,
+		},
+		filepath.Join(appDir, "internal", "service", "svcuserrole", "user_role_test.go"): {
+			"assert.Equal(t, createResp.RoleID, detailResp.RoleID)",
+			"UserID: uint(sampleRunID%1000000000 + 1),",
+		},
+	}
+	for file, wants := range files {
+		content := compactSpaces(readFile(t, file))
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated file %s is not valid Go: %v", file, err)
+		}
+	}
+	if modelSrc := readFile(t, filepath.Join(appDir, "model", "user_role.go")); strings.Contains(modelSrc, "gorm.Model") || strings.Contains(modelSrc, "v.ID") {
+		t.Errorf("composite key model should not embed gorm.Model or key on ID:\n%s", modelSrc)
+	}
+	// 主键列与 ID 一样不进入 BaseInfo
+	objectSrc := readFile(t, filepath.Join(appDir, "object", "objuserrole", "user_role.go"))
+	if strings.Contains(objectSrc, "UserID") || strings.Contains(objectSrc, "RoleID") {
+		t.Errorf("object should not contain key fields:\n%s", objectSrc)
+	}
+	for _, file := range []string{
+		filepath.Join(appDir, "internal", "dto", "dtouserrole", "request.go"),
+		filepath.Join(appDir, "internal", "dto", "dtouserrole", "response.go"),
+		filepath.Join(appDir, "internal", "service", "svcuserrole", "user_role.go"),
+	} {
+		if content := readFile(t, file); strings.Contains(content, "UserRoleID") {
+			t.Errorf("%s should not reference a single UserRoleID:\n%s", file, content)
+		}
+	}
+
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--with-grpc")
+	})
+	if want := "--with-grpc does not support table user_role with a composite primary key"; !strings.Contains(output, want) {
+		t.Errorf("output missing %q:\n%s", want, output)
+	}
+}
@@ -23,12 +23,46 @@ func buildLayerNameMap(serviceName string) map[codegen.LayerName]codegen.LayerNa
 }
 
 type Config struct {
-	DatabaseDSN string       `yaml:"database_dsn"` // 数据库连接字符串，格式：schema://dsn
-	ServiceName string       `yaml:"service_name"` // 服务名
-	Module      ModuleConfig `yaml:"module"`       // 模块生成配置
-	Model       ModelConfig  `yaml:"model"`        // 模型生成配置
-	Api         ApiConfig    `yaml:"api"`          // 控制器生成配置
-	appInfo     AppInfo
+	DatabaseDSN   string               `yaml:"database_dsn"`   // 数据库连接字符串，格式：schema://dsn
+	SchemaSource  string               `yaml:"schema_source"`  // 表结构来源：db（默认，连接数据库）、ddl（解析 SQL DDL 文件）
+	DDLFiles      []string             `yaml:"ddl_files"`      // DDL 文件路径，支持 glob，相对路径基于项目根目录，schema_source 为 ddl 时生效
+	ServiceName   string               `yaml:"service_name"`   // 服务名
+	TemplateDir   string               `yaml:"template_dir"`   // 自定义模板目录，包含 module/model/api 子目录，相对路径基于项目根目录，默认 apps/<app>/config/codegen_tpl
+	ErrorCode     ErrorCodeConfig      `yaml:"error_code"`     // 错误码分配配置
+	NullableStyle string               `yaml:"nullable_style"` // 可空列的 Go 类型：pointer（*T）、sqlnull（sql.NullX）、value（默认，值类型）
+	TypeOverrides []TypeOverrideConfig `yaml:"type_overrides"` // 列类型到 Go 类型的自定义映射，按 table.column 或列类型匹配
+	SysFields     SysFieldConfig       `yaml:"sys_fields"`     // 系统字段（审计字段与软删除列）约定，不出现在请求 DTO 中
+	Module        ModuleConfig         `yaml:"module"`         // 模块生成配置
+	Model         ModelConfig          `yaml:"model"`          // 模型生成配置
+	Api           ApiConfig            `yaml:"api"`            // 控制器生成配置
+	appInfo       AppInfo
+}
+
+// ErrorCodeConfig 错误码分配配置，module 模式为每个模块分配独立的错误码区间
+type ErrorCodeConfig struct {
+	Base      int `yaml:"base"`       // 业务错误码起始值，默认 100100
+	BlockSize int `yaml:"block_size"` // 每个模块占用的错误码区间大小，默认 100
+}
+
+// TypeOverrideConfig 列的 Go 类型覆盖，column 与 db_type 二选一，column 匹配优先于 db_type，db_type 按配置顺序取首个匹配
+type TypeOverrideConfig struct {
+	DBType     string `yaml:"db_type"`    // 列类型匹配模式，支持 * 通配，不含长度时匹配该类型的任意长度，如 decimal、tinyint(1)、uuid
+	Column     string `yaml:"column"`     // 表名.列名，如 order.extra
+	GoType     string `yaml:"go_type"`    // Go 类型，如 decimal.Decimal、uuid.UUID、bool
+	Import     string `yaml:"import"`     // go_type 所在包的导入路径，如 github.com/shopspring/decimal，内置类型与 time、json、sql 包无需填写
+	Serializer string `yaml:"serializer"` // gorm 序列化器，如 json，列存储 JSON 而 go_type 为结构体时使用
+}
+
+// SysFieldConfig 系统字段约定，列名未配置时使用 gorm.Model 的默认列名，表中不存在的列忽略
+type SysFieldConfig struct {
+	CreatedAt  string   `yaml:"created_at"`  // 创建时间列，默认 created_at
+	UpdatedAt  string   `yaml:"updated_at"`  // 更新时间列，默认 updated_at
+	DeletedAt  string   `yaml:"deleted_at"`  // 软删除列，默认 gorm 方式为 deleted_at、flag 方式为 is_deleted
+	CreatedBy  string   `yaml:"created_by"`  // 创建人列，默认 created_by，service 创建时取请求上下文中的用户
+	UpdatedBy  string   `yaml:"updated_by"`  // 更新人列，默认 updated_by，service 创建、更新时取请求上下文中的用户
+	DeletedBy  string   `yaml:"deleted_by"`  // 删除人列，默认 deleted_by，service 删除时取请求上下文中的用户
+	SoftDelete string   `yaml:"soft_delete"` // 软删除方式：gorm（默认，gorm.DeletedAt 时间列）、flag（0/1 标记列）、none（物理删除）
+	Extra      []string `yaml:"extra"`       // 其他不由请求传入的列，如 tenant_id、version
 }
 
 type AppInfo struct {
@@ -40,24 +74,80 @@ type AppInfo struct {
 }
 
 type ModuleConfig struct {
-	PackageName string `yaml:"package_name"` // 包名
-	Description string `yaml:"description"`  // 描述
-	TableName   string `yaml:"table_name"`   // 表名
-	TablePrefix string `yaml:"table_prefix"` // 表名前缀，生成结构体名时会去除此前缀，如 iam_
+	PackageName string         `yaml:"package_name"` // 包名
+	Description string         `yaml:"description"`  // 描述
+	TableName   string         `yaml:"table_name"`   // 表名
+	TablePrefix string         `yaml:"table_prefix"` // 表名前缀，生成结构体名时会去除此前缀，如 iam_
+	Tables      []TableConfig  `yaml:"tables"`       // 批量生成的表列表，配置后忽略上面的单表配置
+	Relations   RelationConfig `yaml:"relations"`    // 关联关系识别配置，tables 中的单表配置优先
+	PageList    PageListConfig `yaml:"page_list"`    // 分页列表的筛选、排序与关键字搜索配置，tables 中的单表配置优先
+	WithGrpc    bool           `yaml:"with_grpc"`    // 是否同时生成 .proto 与委托给 service 的 gRPC 服务端，也可通过 --with-grpc 开启
 }
 
 type ModelConfig struct {
-	PackageName string `yaml:"package_name"` // 包名
-	Description string `yaml:"description"`  // 描述
-	TableName   string `yaml:"table_name"`   // 表名
-	TablePrefix string `yaml:"table_prefix"` // 表名前缀，生成结构体名时会去除此前缀，如 iam_
+	PackageName string         `yaml:"package_name"` // 包名
+	Description string         `yaml:"description"`  // 描述
+	TableName   string         `yaml:"table_name"`   // 表名
+	TablePrefix string         `yaml:"table_prefix"` // 表名前缀，生成结构体名时会去除此前缀，如 iam_
+	Tables      []TableConfig  `yaml:"tables"`       // 批量生成的表列表，配置后忽略上面的单表配置
+	Relations   RelationConfig `yaml:"relations"`    // 关联关系识别配置，tables 中的单表配置优先
+	PageList    PageListConfig `yaml:"page_list"`    // 分页列表的筛选、排序与关键字搜索配置，tables 中的单表配置优先
+}
+
+// TableConfig 批量生成时的单表配置
+type TableConfig struct {
+	TableName   string          `yaml:"table_name"`   // 表名
+	PackageName string          `yaml:"package_name"` // 包名，为空时取去除前缀后的表名，如 iam_user_role -> userrole
+	Description string          `yaml:"description"`  // 描述，为空时取表注释
+	Relations   *RelationConfig `yaml:"relations"`    // 关联关系识别配置，为空时使用 module/model 下的配置
+	PageList    *PageListConfig `yaml:"page_list"`    // 分页列表配置，为空时使用 module/model 下的配置
+}
+
+// RelationConfig 关联关系识别配置，识别到的关联生成 gorm belongsTo/hasMany 字段、DAO 预加载方法与 Cond 预加载选项
+type RelationConfig struct {
+	Detect  string   `yaml:"detect"`  // 识别方式：fk（默认，读取外键约束）、naming（外键约束及 <表名>_id 命名约定）、none（不生成关联）
+	Exclude []string `yaml:"exclude"` // 不生成的关联，填写外键列名、关联表名或关联字段名
+}
+
+// PageListConfig 分页列表配置，生成 PageListReq 的查询字段、Cond 字段与 BuildCondition 条件
+type PageListConfig struct {
+	Filters      []PageListFilterConfig `yaml:"filters"`       // 可筛选的列
+	Sort         []string               `yaml:"sort"`          // 允许排序的列（白名单），请求通过 orderBy=createdAt / orderBy=-createdAt 指定
+	DefaultSort  string                 `yaml:"default_sort"`  // 默认排序列，- 前缀表示降序，如 -created_at
+	Keyword      []string               `yaml:"keyword"`       // 关键字模糊匹配的列，任一列匹配即可
+	Pagination   string                 `yaml:"pagination"`    // 分页方式：offset（默认，按页码）、cursor（游标，按排序列与主键的 keyset 条件翻页）
+	CursorColumn string                 `yaml:"cursor_column"` // 游标分页的排序列，须有索引且非空，默认为主键，按该列与主键倒序返回
+}
+
+// PageListFilterConfig 筛选列配置
+type PageListFilterConfig struct {
+	Column string `yaml:"column"` // 列名
+	Op     string `yaml:"op"`     // 操作符：eq（默认）、in、like、range（时间与数值列）
 }
 
 type ApiConfig struct {
-	PackageName    string `yaml:"package_name"`    // 包名，如user
-	TargetFilename string `yaml:"target_filename"` // 目标文件名，生成的代码写入的目标文件名
-	FunctionName   string `yaml:"function_name"`   // 函数名
-	HttpMethod     string `yaml:"http_method"`     // http方法
-	ApiDocTag      string `yaml:"api_doc_tag"`     // api文档tag
-	Description    string `yaml:"description"`     // 描述
+	ApiEndpointConfig `yaml:",inline"` // 单个接口配置
+
+	PackageName    string              `yaml:"package_name"`    // 包名，如user
+	TargetFilename string              `yaml:"target_filename"` // 目标文件名，生成的代码写入的目标文件名
+	Endpoints      []ApiEndpointConfig `yaml:"endpoints"`       // 批量生成的接口列表，api_doc_tag 默认取上层配置，配置后忽略上面的单接口配置
+}
+
+// ApiEndpointConfig 接口配置
+type ApiEndpointConfig struct {
+	FunctionName string           `yaml:"function_name"` // 函数名
+	HttpMethod   string           `yaml:"http_method"`   // http方法
+	ApiDocTag    string           `yaml:"api_doc_tag"`   // api文档tag
+	Description  string           `yaml:"description"`   // 描述
+	Request      []ApiFieldConfig `yaml:"request"`       // 请求字段，source 为 path 的字段同时作为路由路径参数
+	Response     []ApiFieldConfig `yaml:"response"`      // 响应字段
+}
+
+// ApiFieldConfig api 请求、响应字段配置
+type ApiFieldConfig struct {
+	Name        string `yaml:"name"`        // 字段名，如 orderID、order_id
+	Type        string `yaml:"type"`        // Go 类型，如 uint、string、[]string、time.Time
+	Source      string `yaml:"source"`      // 请求字段来源：path、query、body，默认 GET、DELETE 为 query，其余为 body；响应字段不填
+	Validate    string `yaml:"validate"`    // binding 校验规则，如 required,max=64，path 字段固定包含 required
+	Description string `yaml:"description"` // 字段说明
 }
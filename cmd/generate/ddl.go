@@ -0,0 +1,960 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+)
+
+// ddlTokenKind DDL 词法单元类型
+type ddlTokenKind int
+
+const (
+	ddlTokenWord   ddlTokenKind = iota // 关键字、未加引号的标识符、数字
+	ddlTokenIdent                      // 加引号的标识符（`name` 或 "name"）
+	ddlTokenString                     // 字符串字面量（'xxx'）
+	ddlTokenSymbol                     // 符号，如 ( ) , . =
+)
+
+type ddlToken struct {
+	kind ddlTokenKind
+	text string
+}
+
+// is 判断 token 是否为指定关键字或符号（关键字不区分大小写，加引号的标识符不参与匹配）
+func (t ddlToken) is(s string) bool {
+	return (t.kind == ddlTokenWord || t.kind == ddlTokenSymbol) && strings.EqualFold(t.text, s)
+}
+
+// ddlIndex 表内索引定义
+type ddlIndex struct {
+	name     string
+	columns  []string
+	isUnique bool
+}
+
+// ddlForeignKey 单列外键约束，refColumn 为空表示引用被引用表的主键
+type ddlForeignKey struct {
+	column    string
+	refTable  string
+	refColumn string
+}
+
+// columnTypeWidthRegexp 匹配整型的显示宽度，如 int(11)，MySQL 8 information_schema 中已不再保留
+var columnTypeWidthRegexp = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|integer|bigint)\(\d+\)`)
+
+// ddlGoTypeMap 列基础类型到 Go 类型的映射，覆盖 MySQL 与 PostgreSQL 的常用类型
+var ddlGoTypeMap = map[string]string{
+	"tinyint":     "int8",
+	"smallint":    "int16",
+	"mediumint":   "int32",
+	"int":         "int",
+	"integer":     "int",
+	"bigint":      "int64",
+	"int2":        "int16",
+	"int4":        "int32",
+	"int8":        "int64",
+	"smallserial": "int16",
+	"serial2":     "int16",
+	"serial":      "int32",
+	"serial4":     "int32",
+	"bigserial":   "int64",
+	"serial8":     "int64",
+	"float":       "float32",
+	"float4":      "float32",
+	"real":        "float32",
+	"double":      "float64",
+	"float8":      "float64",
+	"decimal":     "float64",
+	"numeric":     "float64",
+	"bool":        "bool",
+	"boolean":     "bool",
+	"char":        "string",
+	"character":   "string",
+	"varchar":     "string",
+	"tinytext":    "string",
+	"text":        "string",
+	"mediumtext":  "string",
+	"longtext":    "string",
+	"enum":        "string",
+	"set":         "string",
+	"time":        "string",
+	"uuid":        "string",
+	"citext":      "string",
+	"inet":        "string",
+	"cidr":        "string",
+	"macaddr":     "string",
+	"date":        "time.Time",
+	"datetime":    "time.Time",
+	"timestamp":   "time.Time",
+	"timestamptz": "time.Time",
+	"json":        "json.RawMessage",
+	"jsonb":       "json.RawMessage",
+	"binary":      "[]byte",
+	"varbinary":   "[]byte",
+	"tinyblob":    "[]byte",
+	"blob":        "[]byte",
+	"mediumblob":  "[]byte",
+	"longblob":    "[]byte",
+	"bytea":       "[]byte",
+}
+
+// unsignedGoTypeMap 无符号整型映射，bigint unsigned 与 gorm.Model 的 uint 主键保持一致
+var unsignedGoTypeMap = map[string]string{
+	"int8":  "uint8",
+	"int16": "uint16",
+	"int32": "uint32",
+	"int":   "uint",
+	"int64": "uint",
+}
+
+// ColumnGoType 根据列数据类型推导 Go 类型，未识别的类型按 string 处理
+func ColumnGoType(columnType string) string {
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	baseType := columnType
+	if idx := strings.IndexAny(baseType, " ("); idx >= 0 {
+		baseType = baseType[:idx]
+	}
+	if strings.HasSuffix(columnType, "[]") {
+		return "string"
+	}
+	goType, ok := ddlGoTypeMap[baseType]
+	if !ok {
+		return "string"
+	}
+	if strings.Contains(columnType, "unsigned") {
+		if unsignedType, hasUnsigned := unsignedGoTypeMap[goType]; hasUnsigned {
+			return unsignedType
+		}
+	}
+	return goType
+}
+
+// detectDDLDialect 根据 DDL 内容特征推断方言：出现反引号、ENGINE=、AUTO_INCREMENT 视为 MySQL，否则视为 PostgreSQL
+func detectDDLDialect(content string) string {
+	upper := strings.ToUpper(content)
+	if strings.Contains(content, "`") || strings.Contains(upper, "ENGINE") || strings.Contains(upper, "AUTO_INCREMENT") {
+		return DBTypeMySQL
+	}
+	if strings.Contains(upper, "COMMENT ON ") || strings.Contains(upper, "SERIAL") {
+		return DBTypePostgres
+	}
+	return DBTypeMySQL
+}
+
+// ParseDDL 解析 SQL DDL 中的建表语句，支持 MySQL 与 PostgreSQL 方言：
+//   - CREATE TABLE 中的列定义、列级/表级主键、唯一约束与索引、列注释与表注释
+//   - 列级 REFERENCES、表级 FOREIGN KEY 与 ALTER TABLE ... ADD FOREIGN KEY 定义的单列外键
+//   - PostgreSQL 的 COMMENT ON TABLE/COLUMN、CREATE [UNIQUE] INDEX 与 CREATE TYPE ... AS ENUM
+//
+// 其余语句（CREATE DATABASE、INSERT 等）会被忽略。
+func ParseDDL(content, dialect string) ([]*TableSchema, error) {
+	statements, err := splitDDLStatements(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*TableSchema
+	tableMap := make(map[string]*TableSchema)
+	tableIndexes := make(map[string][]ddlIndex)
+	tableForeignKeys := make(map[string][]ddlForeignKey)
+	enumTypes := make(map[string][]string)
+	for _, statement := range statements {
+		tokens, tokenizeErr := tokenizeDDL(statement)
+		if tokenizeErr != nil {
+			return nil, tokenizeErr
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		switch {
+		case isCreateTable(tokens):
+			table, indexes, parseErr := parseCreateTable(tokens, dialect)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			if _, exists := tableMap[table.TableName]; !exists {
+				tables = append(tables, table)
+			}
+			tableMap[table.TableName] = table
+			tableIndexes[table.TableName] = indexes
+		case tokens[0].is("CREATE"):
+			if typeName, values, ok := parseCreateEnumType(tokens); ok {
+				enumTypes[typeName] = values
+				continue
+			}
+			tableName, index, ok := parseCreateIndex(tokens)
+			if ok {
+				tableIndexes[tableName] = append(tableIndexes[tableName], index)
+			}
+		case tokens[0].is("ALTER"):
+			if tableName, fk, ok := parseAlterTableForeignKey(tokens); ok {
+				tableForeignKeys[tableName] = append(tableForeignKeys[tableName], fk)
+			}
+		case tokens[0].is("COMMENT"):
+			applyCommentOn(tokens, tableMap)
+		}
+	}
+
+	for _, table := range tables {
+		applyIndexes(table, tableIndexes[table.TableName])
+		for i := range table.Columns {
+			// 列类型可能带 schema 前缀，如 public.order_status
+			typeName := table.Columns[i].ColumnType
+			if idx := strings.LastIndex(typeName, "."); idx >= 0 {
+				typeName = strings.TrimSpace(typeName[idx+1:])
+			}
+			table.Columns[i].EnumValues = enumTypes[typeName]
+		}
+		applyForeignKeys(table, tableForeignKeys[table.TableName])
+	}
+	// 未指定引用列的外键引用被引用表的主键
+	for _, table := range tables {
+		for i := range table.Columns {
+			column := &table.Columns[i]
+			if column.RefTableName == "" || column.RefColumnName != "" {
+				continue
+			}
+			column.RefColumnName = "id"
+			if refTable, ok := tableMap[column.RefTableName]; ok {
+				for _, refColumn := range refTable.Columns {
+					if refColumn.IsPrimaryKey {
+						column.RefColumnName = refColumn.ColumnName
+						break
+					}
+				}
+			}
+		}
+	}
+	return tables, nil
+}
+
+// splitDDLStatements 去除注释并按分号拆分语句（忽略引号内的分号与注释符）
+func splitDDLStatements(content string) ([]string, error) {
+	var statements []string
+	var current strings.Builder
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(runes, i)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in ddl near: %s", snippet(runes, i))
+			}
+			current.WriteString(string(runes[i : end+1]))
+			i = end
+		case c == '-' && i+1 < len(runes) && runes[i+1] == '-', c == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			current.WriteRune('\n')
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+			if end+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated block comment in ddl")
+			}
+			i = end + 1
+			current.WriteRune(' ')
+		case c == ';':
+			if s := strings.TrimSpace(current.String()); s != "" {
+				statements = append(statements, s)
+			}
+			current.Reset()
+		default:
+			current.WriteRune(c)
+		}
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		statements = append(statements, s)
+	}
+	return statements, nil
+}
+
+// closingQuote 返回从 start 开始的引号对应的结束位置，支持引号双写与反斜杠两种转义
+func closingQuote(runes []rune, start int) int {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && quote == '\'' {
+			i++
+			continue
+		}
+		if runes[i] == quote {
+			if i+1 < len(runes) && runes[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func snippet(runes []rune, start int) string {
+	end := start + 30
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return string(runes[start:end])
+}
+
+// tokenizeDDL 将单条语句切分为 token
+func tokenizeDDL(statement string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	runes := []rune(statement)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(runes, i)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in ddl near: %s", snippet(runes, i))
+			}
+			text := string(runes[i+1 : end])
+			kind := ddlTokenIdent
+			if c == '\'' {
+				kind = ddlTokenString
+				text = strings.ReplaceAll(text, "''", "'")
+				text = strings.ReplaceAll(text, `\'`, "'")
+			} else {
+				text = strings.ReplaceAll(text, string([]rune{c, c}), string(c))
+			}
+			tokens = append(tokens, ddlToken{kind: kind, text: text})
+			i = end
+		case c == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			tokens = append(tokens, ddlToken{kind: ddlTokenSymbol, text: "::"})
+			i++
+		case strings.ContainsRune("(),.=[]", c):
+			tokens = append(tokens, ddlToken{kind: ddlTokenSymbol, text: string(c)})
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r'\"`(),.=[]:", runes[i]) {
+				i++
+			}
+			if i == start {
+				// 未识别的单字符符号（如 -、+），单独作为 token
+				i++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlTokenWord, text: string(runes[start:i])})
+			i--
+		}
+	}
+	return tokens, nil
+}
+
+func isCreateTable(tokens []ddlToken) bool {
+	if len(tokens) < 3 || !tokens[0].is("CREATE") {
+		return false
+	}
+	for _, t := range tokens[1:] {
+		if t.is("TABLE") {
+			return true
+		}
+		if !t.is("TEMPORARY") && !t.is("TEMP") && !t.is("UNLOGGED") && !t.is("GLOBAL") && !t.is("LOCAL") {
+			return false
+		}
+	}
+	return false
+}
+
+// parseQualifiedName 解析 [schema.]name，返回最后一段名称与消费后的位置
+func parseQualifiedName(tokens []ddlToken, pos int) (string, int) {
+	if pos >= len(tokens) {
+		return "", pos
+	}
+	name := tokens[pos].text
+	pos++
+	for pos+1 < len(tokens) && tokens[pos].is(".") {
+		name = tokens[pos+1].text
+		pos += 2
+	}
+	return name, pos
+}
+
+// findClosingParen 返回与 start 处左括号匹配的右括号位置
+func findClosingParen(tokens []ddlToken, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].is("(") {
+			depth++
+		} else if tokens[i].is(")") {
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitByComma 按顶层逗号切分 token 序列
+func splitByComma(tokens []ddlToken) [][]ddlToken {
+	var parts [][]ddlToken
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.is(",") && depth == 0:
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+// parseColumnList 解析括号内的列名列表，忽略前缀长度与排序方向，如 (`name`(10) DESC, `code`)
+func parseColumnList(tokens []ddlToken) []string {
+	var columns []string
+	for _, part := range splitByComma(tokens) {
+		if len(part) > 0 && part[0].kind != ddlTokenSymbol {
+			columns = append(columns, part[0].text)
+		}
+	}
+	return columns
+}
+
+// parseCreateTable 解析 CREATE TABLE 语句
+func parseCreateTable(tokens []ddlToken, dialect string) (*TableSchema, []ddlIndex, error) {
+	pos := 1
+	for pos < len(tokens) && !tokens[pos].is("TABLE") {
+		pos++
+	}
+	pos++
+	if pos+2 < len(tokens) && tokens[pos].is("IF") && tokens[pos+1].is("NOT") && tokens[pos+2].is("EXISTS") {
+		pos += 3
+	}
+	tableName, pos := parseQualifiedName(tokens, pos)
+	if tableName == "" || pos >= len(tokens) || !tokens[pos].is("(") {
+		return nil, nil, fmt.Errorf("invalid create table statement for table %q", tableName)
+	}
+	end := findClosingParen(tokens, pos)
+	if end < 0 {
+		return nil, nil, fmt.Errorf("unbalanced parentheses in create table %s", tableName)
+	}
+
+	table := &TableSchema{TableName: tableName}
+	var indexes []ddlIndex
+	var foreignKeys []ddlForeignKey
+	var primaryKeys []string
+	for _, def := range splitByComma(tokens[pos+1 : end]) {
+		if len(def) == 0 {
+			continue
+		}
+		head := def[0]
+		if head.is("CONSTRAINT") && len(def) > 2 {
+			// CONSTRAINT name PRIMARY KEY/UNIQUE/FOREIGN KEY ...
+			constraintName := def[1].text
+			def = def[2:]
+			head = def[0]
+			if head.is("UNIQUE") {
+				if cols := constraintColumns(def); len(cols) > 0 {
+					indexes = append(indexes, ddlIndex{name: constraintName, columns: cols, isUnique: true})
+				}
+				continue
+			}
+		}
+		switch {
+		case head.kind != ddlTokenIdent && head.is("PRIMARY"):
+			primaryKeys = append(primaryKeys, constraintColumns(def)...)
+		case head.kind != ddlTokenIdent && (head.is("UNIQUE") || head.is("KEY") || head.is("INDEX") || head.is("FULLTEXT") || head.is("SPATIAL")):
+			index := ddlIndex{isUnique: head.is("UNIQUE")}
+			for i := 1; i < len(def); i++ {
+				if def[i].is("(") {
+					index.columns = parseColumnList(def[i+1 : findClosingParen(def, i)])
+					break
+				}
+				if !def[i].is("KEY") && !def[i].is("INDEX") && def[i].kind != ddlTokenSymbol {
+					index.name = def[i].text
+				}
+			}
+			if index.name == "" && len(index.columns) > 0 {
+				index.name = index.columns[0]
+			}
+			if len(index.columns) > 0 {
+				indexes = append(indexes, index)
+			}
+		case head.kind != ddlTokenIdent && head.is("FOREIGN"):
+			if fk, ok := parseForeignKeyDef(def); ok {
+				foreignKeys = append(foreignKeys, fk)
+			}
+		case head.kind != ddlTokenIdent && (head.is("CHECK") || head.is("EXCLUDE")):
+			continue
+		default:
+			column, isPrimary, isUnique, parseErr := parseColumnDef(def, dialect)
+			if parseErr != nil {
+				return nil, nil, fmt.Errorf("table %s: %v", tableName, parseErr)
+			}
+			table.Columns = append(table.Columns, column)
+			if isPrimary {
+				primaryKeys = append(primaryKeys, column.ColumnName)
+			}
+			if isUnique {
+				indexes = append(indexes, ddlIndex{name: column.ColumnName, columns: []string{column.ColumnName}, isUnique: true})
+			}
+		}
+	}
+
+	for _, pk := range primaryKeys {
+		for i := range table.Columns {
+			if table.Columns[i].ColumnName == pk {
+				table.Columns[i].IsPrimaryKey = true
+				table.Columns[i].IsNullable = false
+			}
+		}
+	}
+	applyForeignKeys(table, foreignKeys)
+
+	// 表选项：COMMENT [=] 'xxx'
+	for i := end + 1; i < len(tokens); i++ {
+		if tokens[i].is("COMMENT") {
+			j := i + 1
+			if j < len(tokens) && tokens[j].is("=") {
+				j++
+			}
+			if j < len(tokens) && tokens[j].kind == ddlTokenString {
+				table.Comment = tokens[j].text
+			}
+			break
+		}
+	}
+	return table, indexes, nil
+}
+
+// constraintColumns 提取约束定义中第一个括号内的列名
+func constraintColumns(def []ddlToken) []string {
+	for i, t := range def {
+		if t.is("(") {
+			closing := findClosingParen(def, i)
+			if closing < 0 {
+				return nil
+			}
+			return parseColumnList(def[i+1 : closing])
+		}
+	}
+	return nil
+}
+
+// columnConstraintKeywords 列类型之后可能出现的约束关键字，遇到即表示列类型结束
+var columnConstraintKeywords = map[string]struct{}{
+	"NOT": {}, "NULL": {}, "DEFAULT": {}, "AUTO_INCREMENT": {}, "AUTOINCREMENT": {}, "PRIMARY": {},
+	"UNIQUE": {}, "KEY": {}, "COMMENT": {}, "COLLATE": {}, "CHARACTER": {}, "CHARSET": {}, "ON": {},
+	"REFERENCES": {}, "CHECK": {}, "GENERATED": {}, "CONSTRAINT": {}, "VISIBLE": {}, "INVISIBLE": {},
+	"AS": {}, "STORAGE": {}, "COLUMN_FORMAT": {}, "SRID": {}, "IDENTITY": {},
+}
+
+// parseColumnDef 解析列定义，返回列信息及其是否为列级主键、唯一约束
+func parseColumnDef(def []ddlToken, dialect string) (ColumnSchema, bool, bool, error) {
+	column := ColumnSchema{ColumnName: def[0].text, IsNullable: true}
+	if len(def) < 2 {
+		return column, false, false, fmt.Errorf("column %s has no type", column.ColumnName)
+	}
+
+	// 列类型：直到遇到约束关键字为止，括号参数紧贴类型名
+	pos := 1
+	var typeBuilder strings.Builder
+	for pos < len(def) {
+		t := def[pos]
+		if t.kind == ddlTokenWord {
+			if _, isKeyword := columnConstraintKeywords[strings.ToUpper(t.text)]; isKeyword {
+				// character set / character varying：仅 "CHARACTER VARYING" 与 "CHARACTER(n)" 属于类型
+				if !(t.is("CHARACTER") && pos == 1) {
+					break
+				}
+			}
+		}
+		if t.is("(") {
+			closing := findClosingParen(def, pos)
+			if closing < 0 {
+				return column, false, false, fmt.Errorf("column %s has unbalanced type parentheses", column.ColumnName)
+			}
+			var args []string
+			for _, arg := range def[pos+1 : closing] {
+				if arg.is(",") {
+					args = append(args, ",")
+					continue
+				}
+				if arg.kind == ddlTokenString {
+					args = append(args, "'"+strings.ReplaceAll(arg.text, "'", "''")+"'")
+					continue
+				}
+				args = append(args, arg.text)
+			}
+			typeBuilder.WriteString("(" + strings.Join(args, "") + ")")
+			pos = closing + 1
+			continue
+		}
+		if t.is("[") || t.is("]") {
+			typeBuilder.WriteString(t.text)
+			pos++
+			continue
+		}
+		if typeBuilder.Len() > 0 {
+			typeBuilder.WriteString(" ")
+		}
+		typeBuilder.WriteString(strings.ToLower(t.text))
+		pos++
+	}
+	column.ColumnType = normalizeColumnType(typeBuilder.String())
+	column.FieldName = gutil.SnakeToPascal(column.ColumnName)
+	column.FieldType = ColumnGoType(column.ColumnType)
+
+	var isPrimary, isUnique bool
+	for pos < len(def) {
+		t := def[pos]
+		switch {
+		case t.is("NOT") && pos+1 < len(def) && def[pos+1].is("NULL"):
+			column.IsNullable = false
+			pos += 2
+		case t.is("NULL"):
+			pos++
+		case t.is("DEFAULT"):
+			var value string
+			column.HasDefault = pos+1 < len(def) && !def[pos+1].is("NULL")
+			value, pos = parseDefaultValue(def, pos+1)
+			column.DefaultValue = value
+		case t.is("AUTO_INCREMENT") || t.is("AUTOINCREMENT") || t.is("GENERATED"):
+			// 自增列与生成列（含 PostgreSQL identity）由数据库填充取值
+			column.HasDefault = true
+			pos++
+		case t.is("PRIMARY"):
+			isPrimary = true
+			column.IsNullable = false
+			pos++
+			if pos < len(def) && def[pos].is("KEY") {
+				pos++
+			}
+		case t.is("UNIQUE"):
+			isUnique = true
+			pos++
+			if pos < len(def) && def[pos].is("KEY") {
+				pos++
+			}
+		case t.is("COMMENT"):
+			if pos+1 < len(def) && def[pos+1].kind == ddlTokenString {
+				column.Comment = def[pos+1].text
+			}
+			pos += 2
+		case t.is("REFERENCES"):
+			column.RefTableName, column.RefColumnName, pos = parseReferences(def, pos+1)
+		case t.is("("):
+			// 跳过 CHECK (...)、GENERATED ... AS (...) 等表达式
+			if closing := findClosingParen(def, pos); closing > 0 {
+				pos = closing + 1
+			} else {
+				pos++
+			}
+		default:
+			pos++
+		}
+	}
+	if dialect == DBTypeSQLite {
+		column.FieldType = SQLiteColumnGoType(column.ColumnType)
+	}
+	if dialect == DBTypePostgres {
+		// PostgreSQL 的 serial 隐含 NOT NULL，未指定精度的 float 等价于 double precision
+		if strings.Contains(column.ColumnType, "serial") {
+			column.IsNullable = false
+			column.HasDefault = true
+		}
+		if column.ColumnType == "float" {
+			column.FieldType = "float64"
+		}
+	}
+	return column, isPrimary, isUnique, nil
+}
+
+// normalizeColumnType 规范化列类型：统一小写、去除整型显示宽度（保留 tinyint(1)）、合并 PostgreSQL 别名
+func normalizeColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(columnType), " ")
+	if !strings.HasPrefix(columnType, "tinyint(1)") {
+		columnType = columnTypeWidthRegexp.ReplaceAllString(columnType, "$1")
+	}
+	columnType = strings.Replace(columnType, "character varying", "varchar", 1)
+	columnType = strings.Replace(columnType, "double precision", "double", 1)
+	columnType = strings.Replace(columnType, "timestamp with time zone", "timestamptz", 1)
+	columnType = strings.Replace(columnType, "timestamp without time zone", "timestamp", 1)
+	columnType = strings.Replace(columnType, " zerofill", "", 1)
+	return columnType
+}
+
+// parseDefaultValue 解析 DEFAULT 之后的默认值表达式，字符串去除引号，NULL 视为无默认值
+func parseDefaultValue(def []ddlToken, pos int) (string, int) {
+	if pos >= len(def) {
+		return "", pos
+	}
+	var value string
+	t := def[pos]
+	switch {
+	case t.is("("):
+		closing := findClosingParen(def, pos)
+		if closing < 0 {
+			return "", len(def)
+		}
+		value = joinTokens(def[pos+1 : closing])
+		pos = closing + 1
+	case t.kind == ddlTokenString:
+		value = t.text
+		pos++
+	case t.is("NULL"):
+		pos++
+	case t.text == "-" || t.text == "+":
+		if pos+1 < len(def) {
+			value = t.text + def[pos+1].text
+			pos += 2
+		} else {
+			pos++
+		}
+	default:
+		value = t.text
+		pos++
+		// 函数调用，如 now()、CURRENT_TIMESTAMP(3)
+		if pos < len(def) && def[pos].is("(") {
+			if closing := findClosingParen(def, pos); closing > 0 {
+				value += "(" + joinTokens(def[pos+1:closing]) + ")"
+				pos = closing + 1
+			}
+		}
+	}
+	// 数字小数部分，如 0.00
+	if pos+1 < len(def) && def[pos].is(".") && def[pos+1].kind == ddlTokenWord {
+		value += "." + def[pos+1].text
+		pos += 2
+	}
+	// PostgreSQL 类型转换，如 'abc'::character varying
+	for pos < len(def) && def[pos].is("::") {
+		pos += 2
+		for pos < len(def) && def[pos].kind == ddlTokenWord {
+			if _, isKeyword := columnConstraintKeywords[strings.ToUpper(def[pos].text)]; isKeyword {
+				break
+			}
+			pos++
+		}
+	}
+	return value, pos
+}
+
+// joinTokens 将 token 还原为表达式文本
+func joinTokens(tokens []ddlToken) string {
+	var b strings.Builder
+	for i, t := range tokens {
+		text := t.text
+		if t.kind == ddlTokenString {
+			text = "'" + strings.ReplaceAll(text, "'", "''") + "'"
+		}
+		if i > 0 && t.kind != ddlTokenSymbol && tokens[i-1].kind != ddlTokenSymbol {
+			b.WriteString(" ")
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// parseCreateEnumType 解析 PostgreSQL 的 CREATE TYPE name AS ENUM ('a', 'b')，返回小写的类型名与取值
+func parseCreateEnumType(tokens []ddlToken) (string, []string, bool) {
+	if len(tokens) < 3 || !tokens[1].is("TYPE") {
+		return "", nil, false
+	}
+	typeName, pos := parseQualifiedName(tokens, 2)
+	if pos+2 >= len(tokens) || !tokens[pos].is("AS") || !tokens[pos+1].is("ENUM") || !tokens[pos+2].is("(") {
+		return "", nil, false
+	}
+	closing := findClosingParen(tokens, pos+2)
+	if closing < 0 {
+		return "", nil, false
+	}
+	var values []string
+	for _, t := range tokens[pos+3 : closing] {
+		if t.kind == ddlTokenString {
+			values = append(values, t.text)
+		}
+	}
+	return strings.ToLower(typeName), values, len(values) > 0
+}
+
+// parseCreateIndex 解析 CREATE [UNIQUE] INDEX [CONCURRENTLY] [IF NOT EXISTS] name ON [ONLY] table [USING method] (cols)
+func parseCreateIndex(tokens []ddlToken) (string, ddlIndex, bool) {
+	var index ddlIndex
+	pos := 1
+	if pos < len(tokens) && tokens[pos].is("UNIQUE") {
+		index.isUnique = true
+		pos++
+	}
+	if pos >= len(tokens) || !tokens[pos].is("INDEX") {
+		return "", index, false
+	}
+	pos++
+	for pos < len(tokens) && (tokens[pos].is("CONCURRENTLY") || tokens[pos].is("IF") || tokens[pos].is("NOT") || tokens[pos].is("EXISTS")) {
+		pos++
+	}
+	if pos < len(tokens) && !tokens[pos].is("ON") {
+		index.name, pos = parseQualifiedName(tokens, pos)
+	}
+	if pos >= len(tokens) || !tokens[pos].is("ON") {
+		return "", index, false
+	}
+	pos++
+	if pos < len(tokens) && tokens[pos].is("ONLY") {
+		pos++
+	}
+	tableName, pos := parseQualifiedName(tokens, pos)
+	for pos < len(tokens) && !tokens[pos].is("(") {
+		pos++
+	}
+	if pos >= len(tokens) {
+		return "", index, false
+	}
+	closing := findClosingParen(tokens, pos)
+	if closing < 0 {
+		return "", index, false
+	}
+	index.columns = parseColumnList(tokens[pos+1 : closing])
+	if index.name == "" && len(index.columns) > 0 {
+		index.name = index.columns[0]
+	}
+	return tableName, index, len(index.columns) > 0
+}
+
+// parseForeignKeyDef 解析表级外键约束 FOREIGN KEY (col) REFERENCES table [(col)]，多列外键不识别
+func parseForeignKeyDef(def []ddlToken) (ddlForeignKey, bool) {
+	var fk ddlForeignKey
+	if len(def) < 2 || !def[0].is("FOREIGN") || !def[1].is("KEY") {
+		return fk, false
+	}
+	columns := constraintColumns(def)
+	if len(columns) != 1 {
+		return fk, false
+	}
+	for i := 2; i < len(def); i++ {
+		if def[i].is("REFERENCES") {
+			fk.column = columns[0]
+			fk.refTable, fk.refColumn, _ = parseReferences(def, i+1)
+			return fk, fk.refTable != ""
+		}
+	}
+	return fk, false
+}
+
+// parseReferences 解析 REFERENCES 之后的 table [(col)]，返回被引用的表名、列名（多列引用时为空）与下一个位置
+func parseReferences(def []ddlToken, pos int) (string, string, int) {
+	refTable, pos := parseQualifiedName(def, pos)
+	if pos >= len(def) || !def[pos].is("(") {
+		return refTable, "", pos
+	}
+	closing := findClosingParen(def, pos)
+	if closing < 0 {
+		return refTable, "", len(def)
+	}
+	var refColumn string
+	if columns := parseColumnList(def[pos+1 : closing]); len(columns) == 1 {
+		refColumn = columns[0]
+	}
+	return refTable, refColumn, closing + 1
+}
+
+// parseAlterTableForeignKey 解析 ALTER TABLE [ONLY] t ADD [CONSTRAINT name] FOREIGN KEY (col) REFERENCES ...，
+// pg_dump 等工具导出的外键均为此形式
+func parseAlterTableForeignKey(tokens []ddlToken) (string, ddlForeignKey, bool) {
+	if len(tokens) < 3 || !tokens[1].is("TABLE") {
+		return "", ddlForeignKey{}, false
+	}
+	pos := 2
+	for pos < len(tokens) && (tokens[pos].is("ONLY") || tokens[pos].is("IF") || tokens[pos].is("EXISTS")) {
+		pos++
+	}
+	tableName, pos := parseQualifiedName(tokens, pos)
+	if pos >= len(tokens) || !tokens[pos].is("ADD") {
+		return "", ddlForeignKey{}, false
+	}
+	pos++
+	if pos+1 < len(tokens) && tokens[pos].is("CONSTRAINT") {
+		pos += 2
+	}
+	fk, ok := parseForeignKeyDef(tokens[pos:])
+	return tableName, fk, ok
+}
+
+// applyForeignKeys 将外键信息回填到列，列级 REFERENCES 已设置的保持不变
+func applyForeignKeys(table *TableSchema, foreignKeys []ddlForeignKey) {
+	for _, fk := range foreignKeys {
+		for i := range table.Columns {
+			column := &table.Columns[i]
+			if column.ColumnName == fk.column && column.RefTableName == "" {
+				column.RefTableName = fk.refTable
+				column.RefColumnName = fk.refColumn
+			}
+		}
+	}
+}
+
+// applyCommentOn 处理 PostgreSQL 的 COMMENT ON TABLE t IS '...' 与 COMMENT ON COLUMN t.c IS '...'
+func applyCommentOn(tokens []ddlToken, tableMap map[string]*TableSchema) {
+	if len(tokens) < 5 || !tokens[1].is("ON") {
+		return
+	}
+	isPos := -1
+	for i := 3; i < len(tokens); i++ {
+		if tokens[i].is("IS") {
+			isPos = i
+			break
+		}
+	}
+	if isPos < 0 || isPos+1 >= len(tokens) || tokens[isPos+1].kind != ddlTokenString {
+		return
+	}
+	comment := tokens[isPos+1].text
+
+	var names []string
+	for _, t := range tokens[3:isPos] {
+		if !t.is(".") {
+			names = append(names, t.text)
+		}
+	}
+	switch {
+	case tokens[2].is("TABLE") && len(names) >= 1:
+		if table, ok := tableMap[names[len(names)-1]]; ok {
+			table.Comment = comment
+		}
+	case tokens[2].is("COLUMN") && len(names) >= 2:
+		table, ok := tableMap[names[len(names)-2]]
+		if !ok {
+			return
+		}
+		for i := range table.Columns {
+			if table.Columns[i].ColumnName == names[len(names)-1] {
+				table.Columns[i].Comment = comment
+			}
+		}
+	}
+}
+
+// applyIndexes 将索引信息回填到列：Indexes 记录列所属的全部索引，IndexName 取首个定义的索引
+func applyIndexes(table *TableSchema, indexes []ddlIndex) {
+	for _, index := range indexes {
+		for seq, columnName := range index.columns {
+			for i := range table.Columns {
+				column := &table.Columns[i]
+				if column.ColumnName != columnName {
+					continue
+				}
+				column.Indexes = append(column.Indexes, ColumnIndex{Name: index.name, IsUnique: index.isUnique, Seq: seq + 1})
+				if column.IndexName == "" {
+					column.IndexName = index.name
+					column.IsUniqueIndex = index.isUnique
+				}
+			}
+		}
+	}
+}
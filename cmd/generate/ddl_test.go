@@ -0,0 +1,204 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseDDLMySQL 解析 ark 模板自带的 MySQL 建表脚本
+func TestParseDDLMySQL(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql"))
+	if err != nil {
+		t.Fatalf("read ddl file: %v", err)
+	}
+	tables, err := ParseDDL(string(content), DBTypeMySQL)
+	if err != nil {
+		t.Fatalf("ParseDDL() error = %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("len(tables) = %d, want 2", len(tables))
+	}
+
+	user := tables[0]
+	if user.TableName != "user" || user.Comment != "用户表" {
+		t.Errorf("table = %s(%s), want user(用户表)", user.TableName, user.Comment)
+	}
+	nameCol := findColumn(t, user, "name")
+	if nameCol.FieldType != "string" || nameCol.ColumnType != "varchar(255)" || nameCol.IsNullable || nameCol.Comment != "姓名" {
+		t.Errorf("name column = %+v", nameCol)
+	}
+
+	loginLog := tables[1]
+	if loginLog.Comment != "用户登录日志表" {
+		t.Errorf("table comment = %q, want 用户登录日志表", loginLog.Comment)
+	}
+	idCol := findColumn(t, loginLog, "id")
+	if !idCol.IsPrimaryKey || idCol.FieldType != "uint" || idCol.FieldName != "Id" {
+		t.Errorf("id column = %+v", idCol)
+	}
+	loginIPCol := findColumn(t, loginLog, "login_ip")
+	if !loginIPCol.IsNullable || loginIPCol.FieldType != "string" || loginIPCol.DefaultValue != "" {
+		t.Errorf("login_ip column = %+v", loginIPCol)
+	}
+	loginTimeCol := findColumn(t, loginLog, "login_time")
+	if loginTimeCol.FieldType != "time.Time" || loginTimeCol.DefaultValue != "CURRENT_TIMESTAMP" {
+		t.Errorf("login_time column = %+v", loginTimeCol)
+	}
+	userIDCol := findColumn(t, loginLog, "user_id")
+	if userIDCol.IndexName != "idx_user_id" || userIDCol.IsUniqueIndex {
+		t.Errorf("user_id column = %+v", userIDCol)
+	}
+}
+
+// TestParseDDLPostgres 解析 PostgreSQL 建表语句，注释与索引通过独立语句声明
+func TestParseDDLPostgres(t *testing.T) {
+	content := `
+-- 订单表
+CREATE TABLE IF NOT EXISTS public.orders (
+    id BIGSERIAL PRIMARY KEY,
+    order_no VARCHAR(64) NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    status SMALLINT NOT NULL DEFAULT 1,
+    remark TEXT DEFAULT 'it''s ok',
+    extra JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT uk_order_no UNIQUE (order_no)
+);
+COMMENT ON TABLE public.orders IS '订单表';
+COMMENT ON COLUMN public.orders.order_no IS '订单号';
+CREATE INDEX idx_orders_status ON public.orders (status);
+`
+	tables, err := ParseDDL(content, "")
+	if err != nil {
+		t.Fatalf("ParseDDL() error = %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("len(tables) = %d, want 1", len(tables))
+	}
+	orders := tables[0]
+	if orders.TableName != "orders" || orders.Comment != "订单表" {
+		t.Errorf("table = %s(%s), want orders(订单表)", orders.TableName, orders.Comment)
+	}
+
+	tests := []struct {
+		column       string
+		fieldType    string
+		isNullable   bool
+		defaultValue string
+	}{
+		{"id", "int64", false, ""},
+		{"amount", "float64", false, "0"},
+		{"status", "int16", false, "1"},
+		{"remark", "string", true, "it's ok"},
+		{"extra", "json.RawMessage", true, ""},
+		{"created_at", "time.Time", false, "now()"},
+	}
+	for _, tt := range tests {
+		col := findColumn(t, orders, tt.column)
+		if col.FieldType != tt.fieldType || col.IsNullable != tt.isNullable || col.DefaultValue != tt.defaultValue {
+			t.Errorf("column %s = %+v, want type=%s nullable=%v default=%q", tt.column, col, tt.fieldType, tt.isNullable, tt.defaultValue)
+		}
+	}
+	if col := findColumn(t, orders, "id"); !col.IsPrimaryKey {
+		t.Errorf("id should be primary key")
+	}
+	if col := findColumn(t, orders, "order_no"); col.Comment != "订单号" || col.IndexName != "uk_order_no" || !col.IsUniqueIndex {
+		t.Errorf("order_no column = %+v", col)
+	}
+	if col := findColumn(t, orders, "status"); col.IndexName != "idx_orders_status" {
+		t.Errorf("status index = %q, want idx_orders_status", col.IndexName)
+	}
+}
+
+func TestColumnGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       string
+	}{
+		{"bigint unsigned", "uint"},
+		{"int(11)", "int"},
+		{"tinyint(1)", "int8"},
+		{"varchar(255)", "string"},
+		{"decimal(10,2)", "float64"},
+		{"datetime", "time.Time"},
+		{"timestamptz", "time.Time"},
+		{"jsonb", "json.RawMessage"},
+		{"bytea", "[]byte"},
+		{"integer[]", "string"},
+		{"geometry", "string"},
+	}
+	for _, tt := range tests {
+		if got := ColumnGoType(tt.columnType); got != tt.want {
+			t.Errorf("ColumnGoType(%q) = %q, want %q", tt.columnType, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDDLTables(t *testing.T) {
+	baseDir := filepath.Join(repoTemplateDir(t), "ark")
+	tables, err := loadDDLTables([]string{"scripts/sql/*.sql"}, baseDir, "")
+	if err != nil {
+		t.Fatalf("loadDDLTables() error = %v", err)
+	}
+	if _, ok := tables["user_login_log"]; !ok {
+		t.Errorf("table user_login_log not loaded, got %v", tables)
+	}
+
+	if _, err := loadDDLTables(nil, baseDir, ""); err == nil {
+		t.Error("expected error when no ddl files configured")
+	}
+	if _, err := loadDDLTables([]string{"scripts/sql/not_exist.sql"}, baseDir, ""); err == nil {
+		t.Error("expected error when ddl file not found")
+	}
+}
+
+// TestGenerateModuleCodeFromDDL 基于 DDL 文件生成完整模块代码，无需数据库
+func TestGenerateModuleCodeFromDDL(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if _, statErr := os.Stat(filepath.Join("apps", "demoapp", "internal", "controller", "ctrloginlog", "user_login_log.go")); statErr != nil {
+		t.Errorf("generated controller file not found: %v", statErr)
+	}
+	if cfg.SchemaSource != SchemaSourceDDL {
+		t.Errorf("SchemaSource = %q, want %q", cfg.SchemaSource, SchemaSourceDDL)
+	}
+}
+
+// repoTemplateDir 返回仓库 template 目录的绝对路径
+func repoTemplateDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.Abs(filepath.Join("..", "..", "template"))
+	if err != nil {
+		t.Fatalf("resolve template dir: %v", err)
+	}
+	return dir
+}
+
+func findColumn(t *testing.T, table *TableSchema, columnName string) ColumnSchema {
+	t.Helper()
+	for _, column := range table.Columns {
+		if column.ColumnName == columnName {
+			return column
+		}
+	}
+	t.Fatalf("column %s not found in table %s", columnName, table.TableName)
+	return ColumnSchema{}
+}
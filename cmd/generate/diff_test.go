@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "same",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "insert into func",
+			before: "package router\n\nfunc RegisterRouter(groups *RouterGroups) {\n\tuserRouter(groups)\n}\n",
+			after:  "package router\n\nfunc RegisterRouter(groups *RouterGroups) {\n\tuserRouter(groups)\n\torderRouter(groups)\n}\n",
+			want: `--- a/router.go
++++ b/router.go
+@@ -2,4 +2,5 @@
+ 
+ func RegisterRouter(groups *RouterGroups) {
+ 	userRouter(groups)
++	orderRouter(groups)
+ }
+`,
+		},
+		{
+			name:   "empty before",
+			before: "",
+			after:  "a\n",
+			want:   "--- a/router.go\n+++ b/router.go\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name:   "replace line",
+			before: "a\nb\nc\n",
+			after:  "a\nx\nc\n",
+			want:   "--- a/router.go\n+++ b/router.go\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+	}
+	for _, tt := range tests {
+		got := unifiedDiff("a/router.go", "b/router.go", []byte(tt.before), []byte(tt.after))
+		if got != tt.want {
+			t.Errorf("%s: unifiedDiff() =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnifiedDiffSplitHunks(t *testing.T) {
+	var before, after []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		before = append(before, line)
+		if i == 2 || i == 17 {
+			line = strings.ToUpper(line)
+		}
+		after = append(after, line)
+	}
+	got := unifiedDiff("a/x", "b/x", []byte(strings.Join(before, "\n")+"\n"), []byte(strings.Join(after, "\n")+"\n"))
+	if strings.Count(got, "@@ -") != 2 {
+		t.Fatalf("expected 2 hunks, got:\n%s", got)
+	}
+	if !strings.Contains(got, "@@ -1,6 +1,6 @@\n a\n b\n-c\n+C\n") || !strings.Contains(got, "@@ -15,6 +15,6 @@\n o\n p\n q\n-r\n+R\n s\n t\n") {
+		t.Errorf("unexpected hunks:\n%s", got)
+	}
+}
@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/morehao/gocli/internal/scaffold"
+)
+
+// runDryRun 在临时覆盖目录中执行生成：复制应用目录与 pkg/code 后将生成根目录指向副本，
+// 生成结束后对比副本前后的快照，输出新增/修改的文件与 unified diff，项目目录不会被改动。
+// 副本中没有项目级模板目录，相对路径的 template_dir 在切换根目录前解析为项目中的绝对路径。
+func runDryRun(genFn func() error) error {
+	targetDirs, dirsErr := generateTargetDirs()
+	if dirsErr != nil {
+		return dirsErr
+	}
+	overlayRoot, mkErr := os.MkdirTemp("", "gocli-dry-run-")
+	if mkErr != nil {
+		return fmt.Errorf("create dry run dir error: %v", mkErr)
+	}
+	defer os.RemoveAll(overlayRoot)
+
+	for _, dir := range targetDirs {
+		srcDir := filepath.Join(cfg.appInfo.ProjectRootPath, dir)
+		if _, statErr := os.Stat(srcDir); os.IsNotExist(statErr) {
+			continue
+		}
+		if err := scaffold.CopyDirTree(srcDir, filepath.Join(overlayRoot, dir)); err != nil {
+			return fmt.Errorf("copy %s to dry run dir error: %v", dir, err)
+		}
+	}
+
+	originWorkDir, originRootPath, originTemplateDir := workDir, cfg.appInfo.ProjectRootPath, cfg.TemplateDir
+	if cfg.TemplateDir != "" && !filepath.IsAbs(cfg.TemplateDir) {
+		cfg.TemplateDir = filepath.Join(cfg.appInfo.ProjectRootPath, cfg.TemplateDir)
+	}
+	workDir = filepath.Join(overlayRoot, targetDirs[0])
+	cfg.appInfo.ProjectRootPath = overlayRoot
+	defer func() {
+		workDir, cfg.appInfo.ProjectRootPath, cfg.TemplateDir = originWorkDir, originRootPath, originTemplateDir
+	}()
+
+	before, snapshotErr := takeSnapshot(overlayRoot, targetDirs)
+	if snapshotErr != nil {
+		return snapshotErr
+	}
+	if err := genFn(); err != nil {
+		return err
+	}
+	after, snapshotErr := takeSnapshot(overlayRoot, targetDirs)
+	if snapshotErr != nil {
+		return snapshotErr
+	}
+	printDryRunReport(compareSnapshots(before, after))
+	return nil
+}
+
+// printDryRunReport 输出演练结果：文件清单及已有文件的 unified diff
+func printDryRunReport(changes []fileChange) {
+	if len(changes) == 0 {
+		fmt.Println("[DryRun] No files would be changed")
+		return
+	}
+	var createdCount, modifiedCount int
+	for _, change := range changes {
+		if change.Created {
+			createdCount++
+		} else {
+			modifiedCount++
+		}
+	}
+	fmt.Printf("[DryRun] %d files would be created, %d files would be modified\n", createdCount, modifiedCount)
+	for _, change := range changes {
+		switch {
+		case change.Created:
+			fmt.Printf("  created:  %s\n", filepath.ToSlash(change.Path))
+		case change.Deleted:
+			fmt.Printf("  deleted:  %s\n", filepath.ToSlash(change.Path))
+		default:
+			fmt.Printf("  modified: %s\n", filepath.ToSlash(change.Path))
+		}
+	}
+	for _, change := range changes {
+		if change.Created {
+			continue
+		}
+		path := filepath.ToSlash(change.Path)
+		fmt.Print(unifiedDiff("a/"+path, "b/"+path, change.Before, change.After))
+	}
+}
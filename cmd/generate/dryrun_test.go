@@ -0,0 +1,103 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateModuleDryRun 演练模式输出文件清单与 diff，且不写入任何文件
+func TestGenerateModuleDryRun(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+	before, err := takeSnapshot(".", []string{"apps", "pkg"})
+	if err != nil {
+		t.Fatalf("takeSnapshot() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--dry-run"); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Dry run finished, no files were written") {
+		t.Fatalf("dry run did not finish:\n%s", output)
+	}
+	for _, want := range []string{
+		"created:  apps/demoapp/internal/controller/ctrloginlog/user_login_log.go",
+		"created:  pkg/code/loginlog.go",
+		"modified: apps/demoapp/internal/router/router.go",
+		"modified: pkg/code/code.go",
+		"+++ b/apps/demoapp/internal/router/router.go",
+		"+\tuserLoginLogRouter(groups)",
+		"+\tregisterError(userLoginLogErrorMsgMap)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, output)
+		}
+	}
+
+	after, err := takeSnapshot(".", []string{"apps", "pkg"})
+	if err != nil {
+		t.Fatalf("takeSnapshot() error = %v", err)
+	}
+	if changes := compareSnapshots(before, after); len(changes) != 0 {
+		t.Errorf("dry run changed files: %+v", changes[0].Path)
+	}
+	if workDir == "" || !strings.HasPrefix(workDir, cfg.appInfo.ProjectRootPath) {
+		t.Errorf("work dir not restored: %s", workDir)
+	}
+	if _, statErr := os.Stat(filepath.Join("pkg", "code", "loginlog.go")); !os.IsNotExist(statErr) {
+		t.Errorf("code file should not be written, stat err = %v", statErr)
+	}
+}
+
+// TestGenerateModuleDryRunTemplateDir 演练模式使用项目中相对路径的 template_dir，与实际生成一致
+func TestGenerateModuleDryRunTemplateDir(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+template_dir: codegen_tpl
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+	customModuleDir := filepath.Join("codegen_tpl", "module")
+	if err := os.MkdirAll(customModuleDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(customModuleDir, "unknown.go.tpl"), []byte("unknown"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--dry-run"); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Dry run finished, no files were written") {
+		t.Fatalf("dry run did not finish:\n%s", output)
+	}
+	if !strings.Contains(output, "Ignored custom template module/unknown.go.tpl") {
+		t.Errorf("template_dir not applied in dry run:\n%s", output)
+	}
+	if cfg.TemplateDir != "codegen_tpl" {
+		t.Errorf("template_dir not restored: %s", cfg.TemplateDir)
+	}
+}
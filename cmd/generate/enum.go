@@ -0,0 +1,218 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/morehao/golib/gutil"
+	"gorm.io/gorm"
+)
+
+// EnumItem 枚举取值，用于生成类型化常量
+type EnumItem struct {
+	ConstName string // 常量名称，如 UserStatusEnabled
+	Value     string // 常量值的 Go 字面量，如 1、"paid"
+	RawValue  string // 原始取值，用于 binding oneof 与文档，如 1、paid
+	Label     string // 取值说明，如 启用，无说明时与原始取值相同
+}
+
+// enumValue 解析得到的取值与说明
+type enumValue struct {
+	value string
+	label string
+}
+
+// enumCommentItemRegexp 匹配注释中的单个取值，如 1-启用、active=激活
+var enumCommentItemRegexp = regexp.MustCompile(`^(-?[0-9A-Za-z_]+)\s*[-=]\s*(.+)$`)
+
+// enumIdentRegexp 可直接转换为常量名的取值或说明
+var enumIdentRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_\- ]*$`)
+
+// parseEnumComment 解析约定格式的枚举注释，如 "状态: 1-启用,2-禁用"、"类型：1=普通，2=会员"，
+// 返回冒号之前的描述与各取值；不符合约定（没有取值或存在无法解析的取值）时返回 false
+func parseEnumComment(comment string) (string, []enumValue, bool) {
+	idx := strings.IndexAny(comment, ":：")
+	if idx < 0 {
+		return "", nil, false
+	}
+	desc := strings.TrimSpace(comment[:idx])
+	_, colonSize := utf8.DecodeRuneInString(comment[idx:])
+	body := comment[idx+colonSize:]
+	parts := strings.FieldsFunc(body, func(r rune) bool {
+		return r == ',' || r == '，' || r == ';' || r == '；' || r == '、'
+	})
+	if len(parts) == 0 {
+		return "", nil, false
+	}
+	values := make([]enumValue, 0, len(parts))
+	for _, part := range parts {
+		match := enumCommentItemRegexp.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return "", nil, false
+		}
+		values = append(values, enumValue{value: match[1], label: strings.TrimSpace(match[2])})
+	}
+	return desc, values, true
+}
+
+// parseEnumColumnType 解析 MySQL ENUM 列类型中的取值，如 enum('pending','paid')
+func parseEnumColumnType(columnType string) []string {
+	columnType = strings.TrimSpace(columnType)
+	if !strings.HasPrefix(strings.ToLower(columnType), "enum(") || !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+	tokens, err := tokenizeDDL(columnType[len("enum(") : len(columnType)-1])
+	if err != nil {
+		return nil
+	}
+	var values []string
+	for _, t := range tokens {
+		if t.kind == ddlTokenString {
+			values = append(values, t.text)
+		}
+	}
+	return values
+}
+
+// columnEnumValues 获取列的枚举取值：优先使用列类型定义的取值（MySQL ENUM、PostgreSQL 枚举类型），
+// 说明取自约定格式的注释；列类型未定义取值时完全由注释决定。返回的描述用于枚举类型的注释。
+func columnEnumValues(column ColumnSchema) (string, []enumValue) {
+	typeValues := column.EnumValues
+	if len(typeValues) == 0 {
+		typeValues = parseEnumColumnType(column.ColumnType)
+	}
+	desc, commentValues, hasComment := parseEnumComment(column.Comment)
+	if !hasComment {
+		desc = column.Comment
+	}
+	if len(typeValues) == 0 {
+		// 仅凭注释判定时至少需要两个取值，避免将 "格式: yyyy-MM-dd" 之类的普通注释识别为枚举
+		if !hasComment || len(commentValues) < 2 {
+			return "", nil
+		}
+		return desc, commentValues
+	}
+
+	labelMap := make(map[string]string, len(commentValues))
+	for _, v := range commentValues {
+		labelMap[v.value] = v.label
+	}
+	values := make([]enumValue, 0, len(typeValues))
+	for _, v := range typeValues {
+		label := labelMap[v]
+		if label == "" {
+			label = v
+		}
+		values = append(values, enumValue{value: v, label: label})
+	}
+	return desc, values
+}
+
+// buildEnumItems 生成枚举常量，仅支持整型与字符串字段，整型字段的取值必须均为整数
+func buildEnumItems(typeName, fieldType string, values []enumValue) []EnumItem {
+	isInt := IsIntID(fieldType) || IsNumID(fieldType)
+	if !isInt && fieldType != "string" {
+		return nil
+	}
+	items := make([]EnumItem, 0, len(values))
+	nameSet := make(map[string]struct{}, len(values))
+	for i, v := range values {
+		item := EnumItem{RawValue: v.value, Label: v.label, Value: strconv.Quote(v.value)}
+		if isInt {
+			if _, err := strconv.ParseInt(v.value, 10, 64); err != nil {
+				return nil
+			}
+			item.Value = v.value
+		}
+		suffix := enumConstSuffix(v, isInt)
+		if suffix == "" {
+			suffix = strconv.Itoa(i + 1)
+		}
+		item.ConstName = typeName + suffix
+		if _, exists := nameSet[item.ConstName]; exists {
+			return nil
+		}
+		nameSet[item.ConstName] = struct{}{}
+		items = append(items, item)
+	}
+	return items
+}
+
+// enumConstSuffix 常量名后缀：字符串取值使用取值本身，整型取值使用英文说明（如 1-enabled），
+// 说明不是英文标识符时使用取值，负数以 Neg 开头；无法转换时返回空，由调用方按序号命名
+func enumConstSuffix(v enumValue, isInt bool) string {
+	name := v.value
+	if isInt {
+		if !enumIdentRegexp.MatchString(v.label) {
+			return strings.Replace(v.value, "-", "Neg", 1)
+		}
+		name = v.label
+	}
+	if !enumIdentRegexp.MatchString(name) {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' {
+				return r
+			}
+			return -1
+		}, name)
+	}
+	name = strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(name))
+	return gutil.SnakeToPascal(name)
+}
+
+// loadPostgresEnumValues 查询 PostgreSQL 表中枚举类型列的取值，key 为列名
+func loadPostgresEnumValues(db *gorm.DB, tableName string) (map[string][]string, error) {
+	var rows []struct {
+		ColumnName string `gorm:"column:column_name"`
+		EnumLabel  string `gorm:"column:enumlabel"`
+	}
+	err := db.Raw(`SELECT c.column_name, e.enumlabel
+FROM information_schema.columns c
+JOIN pg_type t ON t.typname = c.udt_name
+JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE c.table_schema = current_schema() AND c.table_name = ? AND c.data_type = 'USER-DEFINED'
+ORDER BY c.ordinal_position, e.enumsortorder`, tableName).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("get table %s enum values error: %v", tableName, err)
+	}
+	enumValues := make(map[string][]string)
+	for _, row := range rows {
+		enumValues[row.ColumnName] = append(enumValues[row.ColumnName], row.EnumLabel)
+	}
+	return enumValues, nil
+}
+
+// enumOneOf 拼接 binding oneof 的取值，含空格的取值使用单引号包裹
+func enumOneOf(items []EnumItem) string {
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if strings.Contains(item.RawValue, " ") {
+			values = append(values, "'"+item.RawValue+"'")
+			continue
+		}
+		values = append(values, item.RawValue)
+	}
+	return strings.Join(values, " ")
+}
+
+// enumList 拼接 swag enums 标签的取值
+func enumList(items []EnumItem) string {
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, item.RawValue)
+	}
+	return strings.Join(values, ",")
+}
+
+// HasEnumField 判断字段列表中是否含枚举字段，用于决定是否引入 "fmt" 包
+func HasEnumField(fields []ModelField) bool {
+	for _, field := range fields {
+		if field.EnumTypeName != "" {
+			return true
+		}
+	}
+	return false
+}
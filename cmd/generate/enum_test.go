@@ -0,0 +1,211 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnumComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		desc    string
+		values  []enumValue
+		ok      bool
+	}{
+		{"状态: 1-启用,2-禁用", "状态", []enumValue{{"1", "启用"}, {"2", "禁用"}}, true},
+		{"类型：1=普通，2=会员；3=管理员", "类型", []enumValue{{"1", "普通"}, {"2", "会员"}, {"3", "管理员"}}, true},
+		{"性别: -1-未知, 1-male, 2-female", "性别", []enumValue{{"-1", "未知"}, {"1", "male"}, {"2", "female"}}, true},
+		{"登录时间", "", nil, false},
+		{"备注: 最多 255 个字符", "", nil, false},
+		{"状态: 1-启用", "状态", []enumValue{{"1", "启用"}}, true},
+	}
+	for _, tt := range tests {
+		desc, values, ok := parseEnumComment(tt.comment)
+		if ok != tt.ok || desc != tt.desc || !reflect.DeepEqual(values, tt.values) {
+			t.Errorf("parseEnumComment(%q) = %q, %v, %v, want %q, %v, %v", tt.comment, desc, values, ok, tt.desc, tt.values, tt.ok)
+		}
+	}
+}
+
+func TestBuildModelFieldsEnum(t *testing.T) {
+	columns := []ColumnSchema{
+		{FieldName: "Id", FieldType: "uint", ColumnName: "id", IsPrimaryKey: true, Comment: "主键: 1-a,2-b"},
+		{FieldName: "Status", FieldType: "int8", ColumnName: "status", Comment: "状态: 1-enabled,2-disabled,-1-已删除"},
+		{FieldName: "PayType", FieldType: "string", ColumnName: "pay_type", ColumnType: "enum('alipay','wechat_pay')", Comment: "支付方式: alipay-支付宝"},
+		{FieldName: "Level", FieldType: "string", ColumnName: "level", ColumnType: "order_level", EnumValues: []string{"low", "high"}, Comment: "等级"},
+		{FieldName: "Remark", FieldType: "string", ColumnName: "remark", Comment: "备注"},
+		{FieldName: "Score", FieldType: "float64", ColumnName: "score", Comment: "评分: 1-差,2-好"},
+		{FieldName: "Kind", FieldType: "int", ColumnName: "kind", Comment: "类型: 1-普通"},
+	}
+	fields := buildModelFields(columns, "Order")
+
+	if fields[0].EnumTypeName != "" {
+		t.Errorf("primary key should not be enum: %+v", fields[0])
+	}
+	status := fields[1]
+	if status.EnumTypeName != "OrderStatus" || status.EnumDesc != "状态" || status.EnumOneOf != "1 2 -1" || status.EnumList != "1,2,-1" {
+		t.Errorf("status field = %+v", status)
+	}
+	wantStatus := []EnumItem{
+		{ConstName: "OrderStatusEnabled", Value: "1", RawValue: "1", Label: "enabled"},
+		{ConstName: "OrderStatusDisabled", Value: "2", RawValue: "2", Label: "disabled"},
+		{ConstName: "OrderStatusNeg1", Value: "-1", RawValue: "-1", Label: "已删除"},
+	}
+	if !reflect.DeepEqual(status.EnumItems, wantStatus) {
+		t.Errorf("status items = %+v", status.EnumItems)
+	}
+	wantPayType := []EnumItem{
+		{ConstName: "OrderPayTypeAlipay", Value: `"alipay"`, RawValue: "alipay", Label: "支付宝"},
+		{ConstName: "OrderPayTypeWechatPay", Value: `"wechat_pay"`, RawValue: "wechat_pay", Label: "wechat_pay"},
+	}
+	if !reflect.DeepEqual(fields[2].EnumItems, wantPayType) || fields[2].EnumDesc != "支付方式" {
+		t.Errorf("pay_type field = %+v", fields[2])
+	}
+	if fields[3].EnumTypeName != "OrderLevel" || fields[3].EnumDesc != "等级" || fields[3].EnumOneOf != "low high" {
+		t.Errorf("level field = %+v", fields[3])
+	}
+	for _, field := range fields[4:] {
+		if field.EnumTypeName != "" || field.EnumItems != nil {
+			t.Errorf("field %s should not be enum: %+v", field.FieldName, field)
+		}
+	}
+}
+
+func TestParseDDLPostgresEnumType(t *testing.T) {
+	ddl := `
+CREATE TYPE public.order_status AS ENUM ('pending', 'paid', 'cancelled');
+CREATE TABLE orders (
+    id BIGSERIAL PRIMARY KEY,
+    status public.order_status NOT NULL DEFAULT 'pending'
+);
+COMMENT ON COLUMN orders.status IS '订单状态: pending-待支付,paid-已支付,cancelled-已取消';`
+	tables, err := ParseDDL(ddl, DBTypePostgres)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	status := findColumn(t, tables[0], "status")
+	if !reflect.DeepEqual(status.EnumValues, []string{"pending", "paid", "cancelled"}) {
+		t.Fatalf("status enum values = %v", status.EnumValues)
+	}
+	field := buildModelFields(tables[0].Columns, "Orders")[1]
+	if field.EnumTypeName != "OrdersStatus" || field.EnumItems[1].ConstName != "OrdersStatusPaid" || field.EnumItems[1].Label != "已支付" {
+		t.Errorf("status field = %+v", field)
+	}
+}
+
+// TestGenerateModuleEnum 枚举列生成类型化常量、String 方法、取值映射与 oneof 校验
+func TestGenerateModuleEnum(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "order.sql")
+	ddl := "CREATE TABLE `shop_order` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态: 1-待支付,2-已支付,3-已取消',\n" +
+		"  `pay_type` enum('alipay','wechat') NOT NULL DEFAULT 'alipay' COMMENT '支付方式: alipay-支付宝,wechat-微信',\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+		"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB COMMENT='订单表';\n"
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: shoporder
+  description: 订单
+  table_name: shop_order
+`)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	model := readFile(t, filepath.Join(appDir, "model", "shop_order.go"))
+	for _, want := range []string{
+		`"fmt"`,
+		"Status  ShopOrderStatus",
+		"PayType ShopOrderPayType",
+		"type ShopOrderStatus int8",
+		"ShopOrderStatus1 ShopOrderStatus = 1 // 待支付",
+		"type ShopOrderPayType string",
+		`ShopOrderPayTypeAlipay ShopOrderPayType = "alipay" // 支付宝`,
+		"var ShopOrderStatusMap = map[ShopOrderStatus]string{",
+		`ShopOrderStatus2: "已支付",`,
+		"func (e ShopOrderStatus) String() string {",
+		"return fmt.Sprint(int8(e))",
+	} {
+		if !strings.Contains(model, want) {
+			t.Errorf("model file missing %q:\n%s", want, model)
+		}
+	}
+
+	object := readFile(t, filepath.Join(appDir, "object", "objshoporder", "shop_order.go"))
+	for _, want := range []string{
+		`binding:"omitempty,oneof=1 2 3" enums:"1,2,3"`,
+		`binding:"omitempty,oneof=alipay wechat" enums:"alipay,wechat"`,
+	} {
+		if !strings.Contains(object, want) {
+			t.Errorf("object file missing %q:\n%s", want, object)
+		}
+	}
+
+	service := readFile(t, filepath.Join(appDir, "internal", "service", "svcshoporder", "shop_order.go"))
+	for _, want := range []string{
+		"Status:  model.ShopOrderStatus(req.Status),",
+		"PayType: model.ShopOrderPayType(req.PayType),",
+		"Status:  int8(shopOrderEntity.Status),",
+		"PayType: string(v.PayType),",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service file missing %q:\n%s", want, service)
+		}
+	}
+}
+
+// TestSyncEnumDecls 字段改为枚举类型后同步补充枚举声明，已存在的声明不重复追加
+func TestSyncEnumDecls(t *testing.T) {
+	src := "package model\n\ntype OrderEntity struct {\n\tStatus OrderStatus `gorm:\"column:status\"`\n}\n\n// IsPaid 是否已支付\nfunc (e *OrderEntity) IsPaid() bool {\n\treturn e.Status == OrderStatusPaid\n}\n"
+	rendered := "package model\n\nimport \"fmt\"\n\ntype OrderEntity struct {\n\tStatus OrderStatus `gorm:\"column:status\"`\n}\n\n" +
+		"// OrderStatus 状态\ntype OrderStatus int8\n\nconst (\n\tOrderStatusPaid OrderStatus = 1 // 已支付\n)\n\n" +
+		"var OrderStatusMap = map[OrderStatus]string{\n\tOrderStatusPaid: \"已支付\",\n}\n\n" +
+		"func (e OrderStatus) String() string {\n\treturn fmt.Sprint(int8(e))\n}\n\nfunc (e *OrderEntity) TableName() string {\n\treturn \"order\"\n}\n"
+
+	result := &fieldSyncResult{}
+	got, err := syncEnumDecls([]byte(src), []byte(rendered), []string{"OrderStatus"}, result)
+	if err != nil {
+		t.Fatalf("syncEnumDecls error: %v", err)
+	}
+	got, err = syncFieldImports(got)
+	if err != nil {
+		t.Fatalf("syncFieldImports error: %v", err)
+	}
+	for _, want := range []string{
+		"\"fmt\"",
+		"// OrderStatus 状态\ntype OrderStatus int8",
+		"OrderStatusPaid OrderStatus = 1 // 已支付",
+		"var OrderStatusMap = map[OrderStatus]string{",
+		"func (e OrderStatus) String() string {",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("synced source missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(string(got), "TableName") || !reflect.DeepEqual(result.Added, []string{"type OrderStatus"}) {
+		t.Errorf("unexpected sync result %v:\n%s", result.Added, got)
+	}
+
+	again, err := syncEnumDecls(got, []byte(rendered), []string{"OrderStatus"}, &fieldSyncResult{})
+	if err != nil || string(again) != string(got) {
+		t.Errorf("second sync should not change source:\n%s", again)
+	}
+}
@@ -0,0 +1,157 @@
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 错误码分配默认值：业务错误码从 100100 开始，每个模块占用 100 个
+const (
+	defaultErrorCodeBase      = 100100
+	defaultErrorCodeBlockSize = 100
+)
+
+// codeConst pkg/code 中的整型错误码常量
+type codeConst struct {
+	Name  string
+	Value int64
+	File  string
+}
+
+// errorCodeRange 返回配置的错误码起始值与区间大小，未配置时使用默认值
+func errorCodeRange() (int64, int64) {
+	base, blockSize := int64(defaultErrorCodeBase), int64(defaultErrorCodeBlockSize)
+	if cfg.ErrorCode.Base > 0 {
+		base = int64(cfg.ErrorCode.Base)
+	}
+	if cfg.ErrorCode.BlockSize > 0 {
+		blockSize = int64(cfg.ErrorCode.BlockSize)
+	}
+	return base, blockSize
+}
+
+// scanCodeConsts 扫描目录下所有 Go 文件（不含测试文件）中的整型常量，目录不存在时返回空
+func scanCodeConsts(codeDir string) ([]codeConst, error) {
+	entries, readErr := os.ReadDir(codeDir)
+	if os.IsNotExist(readErr) {
+		return nil, nil
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("read code dir error: %v", readErr)
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, parseErr := parser.ParseFile(fset, filepath.Join(codeDir, name), nil, parser.SkipObjectResolution)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse code file %s error: %v", name, parseErr)
+		}
+		files = append(files, file)
+	}
+	return evalCodeConsts(fset, files), nil
+}
+
+// parseCodeConsts 解析单个 Go 源码中的整型常量
+func parseCodeConsts(filename string, src []byte) ([]codeConst, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse code file %s error: %v", filename, parseErr)
+	}
+	return evalCodeConsts(fset, []*ast.File{file}), nil
+}
+
+// evalCodeConsts 借助 go/types 计算包级常量的值，可正确处理 iota 与常量表达式；
+// 外部依赖（如 gerror）无需解析，类型检查错误直接忽略
+func evalCodeConsts(fset *token.FileSet, files []*ast.File) []codeConst {
+	if len(files) == 0 {
+		return nil
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{
+		Importer: emptyImporter{},
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+
+	var consts []codeConst
+	for ident, obj := range info.Defs {
+		constObj, ok := obj.(*types.Const)
+		if !ok || constObj.Parent() != constObj.Pkg().Scope() || constObj.Val().Kind() != constant.Int {
+			continue
+		}
+		value, exact := constant.Int64Val(constObj.Val())
+		if !exact {
+			continue
+		}
+		consts = append(consts, codeConst{
+			Name:  ident.Name,
+			Value: value,
+			File:  filepath.Base(fset.Position(ident.Pos()).Filename),
+		})
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		if consts[i].Value != consts[j].Value {
+			return consts[i].Value < consts[j].Value
+		}
+		return consts[i].Name < consts[j].Name
+	})
+	return consts
+}
+
+// emptyImporter 将所有导入解析为空包，仅用于常量求值
+type emptyImporter struct{}
+
+func (emptyImporter) Import(path string) (*types.Package, error) {
+	pkg := types.NewPackage(path, filepath.Base(path))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// allocateErrorCodeBlock 分配下一个空闲的错误码区间：取已使用的最大区间之后的区间，低于 base 的错误码不参与分配
+func allocateErrorCodeBlock(existing []codeConst, base, blockSize int64) int64 {
+	next := base
+	for _, item := range existing {
+		if item.Value < base {
+			continue
+		}
+		if blockStart := base + (item.Value-base)/blockSize*blockSize + blockSize; blockStart > next {
+			next = blockStart
+		}
+	}
+	return next
+}
+
+// checkErrorCodeCollision 校验新生成的错误码：常量名与数值均不能与已有错误码重复，且数值需落在分配的区间内
+func checkErrorCodeCollision(existing, generated []codeConst, blockStart, blockSize int64) error {
+	existingNames := make(map[string]codeConst, len(existing))
+	existingValues := make(map[int64]codeConst, len(existing))
+	for _, item := range existing {
+		existingNames[item.Name] = item
+		existingValues[item.Value] = item
+	}
+	for _, item := range generated {
+		if exist, ok := existingNames[item.Name]; ok {
+			return fmt.Errorf("error code collision: %s already defined in pkg/code/%s", item.Name, exist.File)
+		}
+		if exist, ok := existingValues[item.Value]; ok {
+			return fmt.Errorf("error code collision: %s = %d conflicts with %s in pkg/code/%s", item.Name, item.Value, exist.Name, exist.File)
+		}
+		if item.Value < blockStart || item.Value >= blockStart+blockSize {
+			return fmt.Errorf("error code %s = %d out of allocated range [%d, %d)", item.Name, item.Value, blockStart, blockStart+blockSize)
+		}
+	}
+	return nil
+}
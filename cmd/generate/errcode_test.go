@@ -0,0 +1,138 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCodeConsts(t *testing.T) {
+	src := `package code
+
+import "github.com/morehao/golib/gerror"
+
+const (
+	OrderCreateError = 100200 + iota
+	OrderDeleteError
+	OrderNotExistError
+)
+
+const OrderExpiredError gerror.Code = 100210
+
+const orderPrefix = "order"
+`
+	consts, err := parseCodeConsts("order.go", []byte(src))
+	if err != nil {
+		t.Fatalf("parseCodeConsts() error = %v", err)
+	}
+	want := map[string]int64{
+		"OrderCreateError":   100200,
+		"OrderDeleteError":   100201,
+		"OrderNotExistError": 100202,
+	}
+	if len(consts) != len(want) {
+		t.Fatalf("consts = %+v, want %d items", consts, len(want))
+	}
+	for _, item := range consts {
+		if want[item.Name] != item.Value || item.File != "order.go" {
+			t.Errorf("const %+v, want value %d", item, want[item.Name])
+		}
+	}
+}
+
+func TestAllocateErrorCodeBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []int64
+		want     int64
+	}{
+		{"empty", nil, 100100},
+		{"below base ignored", []int64{10001, 50000}, 100100},
+		{"first block used", []int64{100100, 100105}, 100200},
+		{"after highest block", []int64{100100, 100800, 100825}, 100900},
+		{"partially used block", []int64{101215}, 101300},
+	}
+	for _, tt := range tests {
+		var existing []codeConst
+		for _, value := range tt.existing {
+			existing = append(existing, codeConst{Value: value})
+		}
+		if got := allocateErrorCodeBlock(existing, 100100, 100); got != tt.want {
+			t.Errorf("%s: allocateErrorCodeBlock() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckErrorCodeCollision(t *testing.T) {
+	existing := []codeConst{{Name: "UserCreateError", Value: 100100, File: "user.go"}}
+	tests := []struct {
+		name      string
+		generated []codeConst
+		wantErr   string
+	}{
+		{"ok", []codeConst{{Name: "OrderCreateError", Value: 100200}}, ""},
+		{"name collision", []codeConst{{Name: "UserCreateError", Value: 100200}}, "already defined in pkg/code/user.go"},
+		{"value collision", []codeConst{{Name: "OrderCreateError", Value: 100100}}, "conflicts with UserCreateError"},
+		{"out of range", []codeConst{{Name: "OrderCreateError", Value: 100300}}, "out of allocated range"},
+	}
+	for _, tt := range tests {
+		err := checkErrorCodeCollision(existing, tt.generated, 100200, 100)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: error = %v, want contains %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestGenerateModuleErrorCode 新模块分配下一个空闲错误码区间，重复生成已有模块时拒绝
+func TestGenerateModuleErrorCode(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+error_code:
+  block_size: 1000
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	codeConsts, err := scanCodeConsts(filepath.Join("pkg", "code"))
+	if err != nil {
+		t.Fatalf("scanCodeConsts() error = %v", err)
+	}
+	values := make(map[string]int64)
+	for _, item := range codeConsts {
+		values[item.Name] = item.Value
+	}
+	if values["UserCreateError"] != 100100 || values["UserLoginLogCreateError"] != 101100 || values["UserLoginLogNotExistError"] != 101105 {
+		t.Errorf("unexpected error codes: %+v", codeConsts)
+	}
+
+	// 重复生成同一模块：错误码常量已存在，拒绝生成
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "error code collision: UserLoginLogCreateError already defined in pkg/code/loginlog.go") {
+		t.Errorf("expected collision error, got:\n%s", output)
+	}
+	if _, statErr := os.Stat(filepath.Join("pkg", "code", "loginlog.go")); statErr != nil {
+		t.Errorf("code file not found: %v", statErr)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/morehao/golib/codegen"
@@ -14,8 +16,8 @@ import (
 func genApi() error {
 	apiGenCfg := cfg.Api
 
-	// 使用工具函数复制嵌入的模板文件到临时目录
-	tplDir, getTplErr := CopyEmbeddedTemplatesToTempDir(TemplatesFS, "generate/api")
+	// 复制嵌入的模板文件到临时目录，并应用项目自定义模板覆盖
+	tplDir, getTplErr := prepareTemplateDir(tplModeApi)
 	if getTplErr != nil {
 		return getTplErr
 	}
@@ -30,28 +32,34 @@ func genApi() error {
 			LayerParentDirMap: defaultLayerParentDirMap,
 			LayerNameMap:      defaultLayerNameMap,
 			LayerPrefixMap:    defaultLayerPrefixMap,
-			TplFuncMap: template.FuncMap{
-				TplFuncToKebabCase: toKebabCase,
-				TplFuncPluralize:   pluralize,
-			},
+			TplFuncMap:        apiTplFuncMap(),
 		},
 		TargetFilename: apiGenCfg.TargetFilename,
 	}
+	structName := gutil.SnakeToPascal(gutil.TrimFileExtension(apiGenCfg.TargetFilename))
+	structNameLowerCamel := gutil.FirstLetterToLower(structName)
+	// 资源路径统一为 kebab-case 复数（与 module 模板的 restful 风格一致）
+	resourcePath := toKebabCase(pluralize(structNameLowerCamel))
+	endpoints, buildEndpointsErr := buildApiEndpoints(apiGenCfg, resourcePath)
+	if buildEndpointsErr != nil {
+		return buildEndpointsErr
+	}
+
 	gen := codegen.NewGenerator()
 	analysisRes, analysisErr := gen.AnalysisApiTpl(analysisCfg)
 	if analysisErr != nil {
 		return fmt.Errorf("analysis api tpl error: %v", analysisErr)
 	}
 
-	structName := gutil.SnakeToPascal(gutil.TrimFileExtension(apiGenCfg.TargetFilename))
-	structNameLowerCamel := gutil.FirstLetterToLower(structName)
-	functionName := gutil.FirstLetterToUpper(apiGenCfg.FunctionName)
-	functionNameLowerCamel := gutil.FirstLetterToLower(apiGenCfg.FunctionName)
-	// 资源路径统一为 kebab-case 复数（与 module 模板的 restful 风格一致）
-	resourcePath := toKebabCase(pluralize(structNameLowerCamel))
-
-	fmt.Printf("[API] Generating API - Method: %s, Path: /%s/%s, Description: %s\n",
-		apiGenCfg.HttpMethod, resourcePath, functionNameLowerCamel, apiGenCfg.Description)
+	// 路由函数注释使用的描述，批量配置时未填写则取首个接口的文档标签
+	description := apiGenCfg.Description
+	if description == "" {
+		description = endpoints[0].ApiDocTag
+	}
+	for _, endpoint := range endpoints {
+		fmt.Printf("[API] Generating API - Method: %s, Path: %s, Description: %s\n",
+			endpoint.HttpMethod, endpoint.RoutePath, endpoint.Description)
+	}
 	var genParamsList []codegen.GenParamsItem
 	var isNewRouter, isNewController bool
 	var controllerFilepath, serviceFilepath string
@@ -72,6 +80,18 @@ func genApi() error {
 		case codegen.LayerNameController:
 			controllerFilepath = filepath.Join(v.TargetDir, v.TargetFilename)
 			isNewController = !v.TargetFileExist
+			if v.TargetFileExist {
+				// 已存在的方法不重复生成，避免同名方法导致编译失败
+				methods, getMethodsErr := receiverMethods(controllerFilepath, structNameLowerCamel+"Ctr")
+				if getMethodsErr != nil {
+					return getMethodsErr
+				}
+				for _, endpoint := range endpoints {
+					if _, exists := methods[endpoint.FunctionName]; exists {
+						return fmt.Errorf("api %s already exists in %s", endpoint.FunctionName, controllerFilepath)
+					}
+				}
+			}
 		case codegen.LayerNameService:
 			serviceFilepath = filepath.Join(v.TargetDir, v.TargetFilename)
 		}
@@ -88,17 +108,16 @@ func genApi() error {
 					BaseModulePath:  cfg.appInfo.BaseModulePath,
 					AppModuleName:   cfg.appInfo.AppModuleName,
 				},
-				PackageName:            analysisRes.PackageName,
-				TargetFileExist:        v.TargetFileExist,
-				IsNewRouter:            isNewRouter,
-				Description:            apiGenCfg.Description,
-				StructName:             structName,
-				StructNameLowerCamel:   structNameLowerCamel,
-				FunctionName:           functionName,
-				FunctionNameLowerCamel: functionNameLowerCamel,
-				HttpMethod:             apiGenCfg.HttpMethod,
-				ApiDocTag:              apiGenCfg.ApiDocTag,
-				Template:               v.Template,
+				PackageName:          analysisRes.PackageName,
+				TargetFileExist:      v.TargetFileExist,
+				IsNewRouter:          isNewRouter,
+				Description:          description,
+				StructName:           structName,
+				StructNameLowerCamel: structNameLowerCamel,
+				Endpoints:            endpoints,
+				RequestImports:       mergeApiImports(endpoints, true),
+				ResponseImports:      mergeApiImports(endpoints, false),
+				Template:             v.Template,
 			},
 		})
 
@@ -106,18 +125,28 @@ func genApi() error {
 	genParams := &codegen.GenParams{
 		ParamsList: genParamsList,
 	}
+	if err := trackGenParams(genParams); err != nil {
+		return err
+	}
 	if err := gen.Gen(genParams); err != nil {
 		return err
 	}
 
+	if err := trackFiles(controllerFilepath, serviceFilepath); err != nil {
+		return err
+	}
+	functionNames := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		functionNames = append(functionNames, endpoint.FunctionName)
+	}
 	if !isNewController {
-		// 将方法添加到interface接口中
+		// 将全部方法一次性添加到interface接口中
 		controllerInterfaceName := fmt.Sprintf("%sCtr", structName)
-		if err := gast.AddMethodToInterface(controllerFilepath, structNameLowerCamel+"Ctr", functionName, controllerInterfaceName); err != nil {
+		if err := addMethodsToInterface(controllerFilepath, structNameLowerCamel+"Ctr", controllerInterfaceName, functionNames); err != nil {
 			return fmt.Errorf("add controller method to interface error: %w", err)
 		}
 		serviceInterfaceName := fmt.Sprintf("%sSvc", structName)
-		if err := gast.AddMethodToInterface(serviceFilepath, structNameLowerCamel+"Svc", functionName, serviceInterfaceName); err != nil {
+		if err := addMethodsToInterface(serviceFilepath, structNameLowerCamel+"Svc", serviceInterfaceName, functionNames); err != nil {
 			return fmt.Errorf("add service method to interface error: %w", err)
 		}
 	}
@@ -126,33 +155,123 @@ func genApi() error {
 	if isNewRouter {
 		routerCallContent := fmt.Sprintf("%sRouter(%s)", structNameLowerCamel, "groups")
 		routerEnterFilepath := filepath.Join(workDir, "/internal/router/router.go")
+		if err := trackFiles(routerEnterFilepath); err != nil {
+			return err
+		}
 		if err := gast.AddContentToFunc(routerEnterFilepath, "RegisterRouter", routerCallContent); err != nil {
 			return fmt.Errorf("new router appendContentToFunc error: %v", err)
 		}
 		fmt.Printf("[API] Registered new router: %sRouter\n", structNameLowerCamel)
 	} else {
-		routerCallContent := fmt.Sprintf(`v1RouterGroup.%s("/%s/%s", %sCtr.%s)`, apiGenCfg.HttpMethod, resourcePath, functionNameLowerCamel, structNameLowerCamel, functionName)
+		routerCallContents := make([]string, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			routerCallContents = append(routerCallContents, fmt.Sprintf(`v1RouterGroup.%s("%s", %sCtr.%s)`, endpoint.HttpMethod, endpoint.RoutePath, structNameLowerCamel, endpoint.FunctionName))
+		}
 		routerEnterFilepath := filepath.Join(workDir, fmt.Sprintf("/internal/router/%s.go", gutil.TrimFileExtension(apiGenCfg.PackageName)))
-		// 使用 AddContentToFunc 添加到函数末尾，避免注释丢失
-		if err := gast.AddContentToFuncWithLineNumber(routerEnterFilepath, fmt.Sprintf("%sRouter", structNameLowerCamel), routerCallContent, -1); err != nil {
+		if err := trackFiles(routerEnterFilepath); err != nil {
+			return err
+		}
+		// 全部路由一次性追加到函数末尾
+		if err := appendStatementsToFunc(routerEnterFilepath, fmt.Sprintf("%sRouter", structNameLowerCamel), routerCallContents); err != nil {
 			return fmt.Errorf("appendContentToFunc error: %v", err)
 		}
-		fmt.Printf("[API] Added route to existing router: %s %s\n", apiGenCfg.HttpMethod, fmt.Sprintf("/%s/%s", resourcePath, functionNameLowerCamel))
+		for _, endpoint := range endpoints {
+			fmt.Printf("[API] Added route to existing router: %s %s\n", endpoint.HttpMethod, endpoint.RoutePath)
+		}
 	}
 	return nil
 }
 
-type ApiExtraParams struct {
-	AppInfo
-	PackageName            string
-	Description            string
-	TargetFileExist        bool
-	IsNewRouter            bool
-	HttpMethod             string
-	StructName             string
-	StructNameLowerCamel   string
+// ApiEndpoint 单个接口的模板参数
+type ApiEndpoint struct {
+	ApiFields
 	FunctionName           string
 	FunctionNameLowerCamel string
+	HttpMethod             string
+	Description            string
 	ApiDocTag              string
-	Template               *template.Template
+	RoutePath              string // gin 路由路径，如 /orders/:orderID/refund
+}
+
+// buildApiEndpoints 解析 api 配置中的接口列表，配置了 endpoints 时忽略单接口配置，未配置 api_doc_tag 的接口使用上层配置
+func buildApiEndpoints(apiCfg ApiConfig, resourcePath string) ([]ApiEndpoint, error) {
+	endpointCfgs := apiCfg.Endpoints
+	if len(endpointCfgs) == 0 {
+		endpointCfgs = []ApiEndpointConfig{apiCfg.ApiEndpointConfig}
+	}
+	endpoints := make([]ApiEndpoint, 0, len(endpointCfgs))
+	functionNameSet := make(map[string]struct{}, len(endpointCfgs))
+	for _, endpointCfg := range endpointCfgs {
+		if endpointCfg.FunctionName == "" || endpointCfg.HttpMethod == "" {
+			return nil, fmt.Errorf("api endpoint requires function_name and http_method: %+v", endpointCfg)
+		}
+		if endpointCfg.ApiDocTag == "" {
+			endpointCfg.ApiDocTag = apiCfg.ApiDocTag
+		}
+		endpointCfg.HttpMethod = strings.ToUpper(endpointCfg.HttpMethod)
+		functionName := gutil.FirstLetterToUpper(endpointCfg.FunctionName)
+		if _, exists := functionNameSet[functionName]; exists {
+			return nil, fmt.Errorf("api endpoint %s is declared more than once", functionName)
+		}
+		functionNameSet[functionName] = struct{}{}
+
+		apiFields, buildFieldsErr := buildApiFields(endpointCfg)
+		if buildFieldsErr != nil {
+			return nil, buildFieldsErr
+		}
+		functionNameLowerCamel := gutil.FirstLetterToLower(endpointCfg.FunctionName)
+		endpoints = append(endpoints, ApiEndpoint{
+			ApiFields:              *apiFields,
+			FunctionName:           functionName,
+			FunctionNameLowerCamel: functionNameLowerCamel,
+			HttpMethod:             endpointCfg.HttpMethod,
+			Description:            endpointCfg.Description,
+			ApiDocTag:              endpointCfg.ApiDocTag,
+			RoutePath:              apiRoutePath(resourcePath, functionNameLowerCamel, apiFields.PathParams),
+		})
+	}
+	return endpoints, nil
+}
+
+// mergeApiImports 合并全部接口请求或响应结构体需要的导入
+func mergeApiImports(endpoints []ApiEndpoint, isRequest bool) []string {
+	importSet := make(map[string]struct{})
+	for _, endpoint := range endpoints {
+		imports := endpoint.ResponseImports
+		if isRequest {
+			imports = endpoint.RequestImports
+		}
+		for _, importPath := range imports {
+			importSet[importPath] = struct{}{}
+		}
+	}
+	imports := make([]string, 0, len(importSet))
+	for importPath := range importSet {
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// apiTplFuncMap api 模板使用的函数
+func apiTplFuncMap() template.FuncMap {
+	return template.FuncMap{
+		TplFuncToKebabCase: toKebabCase,
+		TplFuncPluralize:   pluralize,
+		TplFuncToLower:     strings.ToLower,
+	}
+}
+
+type ApiExtraParams struct {
+	AppInfo
+	PackageName          string
+	Description          string
+	TargetFileExist      bool
+	IsNewRouter          bool
+	StructName           string
+	StructNameLowerCamel string
+	Endpoints            []ApiEndpoint // 本次生成的接口，模板中按顺序渲染
+	RequestImports       []string      // 全部请求结构体需要的导入
+	ResponseImports      []string      // 全部响应结构体需要的导入
+	Template             *template.Template
 }
@@ -20,14 +20,149 @@ const (
 )
 
 func genModel() error {
-	modelGenCfg := cfg.Model
+	modelCfg := cfg.Model
+	single := TableConfig{
+		TableName:   modelCfg.TableName,
+		PackageName: modelCfg.PackageName,
+		Description: modelCfg.Description,
+	}
+	tables, batch, resolveErr := resolveTables(single, modelCfg.Tables, modelCfg.TablePrefix, tablePatterns)
+	if resolveErr != nil {
+		return resolveErr
+	}
+	setBatchTables(tables)
+
+	var results []*tableGenResult
+	for _, table := range tables {
+		result, genErr := genModelTable(ModelConfig{
+			PackageName: table.PackageName,
+			Description: table.Description,
+			TableName:   table.TableName,
+			TablePrefix: modelCfg.TablePrefix,
+			Relations:   resolveRelationConfig(table, modelCfg.Relations),
+			PageList:    resolvePageListConfig(table, modelCfg.PageList),
+		}, batch)
+		if genErr != nil {
+			return fmt.Errorf("generate table %s error: %v", table.TableName, genErr)
+		}
+		results = append(results, result)
+	}
+	if batch {
+		printTableGenSummary("Model", results)
+	}
+	return nil
+}
 
+// genModelTable 生成单张表的数据层代码，skipExisting 为 true 时若 model 文件已存在则跳过该表
+func genModelTable(modelGenCfg ModelConfig, skipExisting bool) (*tableGenResult, error) {
 	fmt.Printf("[Model] Generating model based on table: %s\n", modelGenCfg.TableName)
 
-	// 使用工具函数复制嵌入的模板文件到临时目录
-	tplDir, getTplErr := CopyEmbeddedTemplatesToTempDir(TemplatesFS, "generate/model")
+	plan, planErr := planModelTable(modelGenCfg)
+	if planErr != nil {
+		return nil, planErr
+	}
+	analysisRes := plan.analysisRes
+	modelLayerName, daoLayerName := plan.modelLayerName, plan.daoLayerName
+	tableLayerItem, modelTargetDir := plan.tableLayerItem, plan.modelTargetDir
+
+	result := &tableGenResult{
+		TableName:   analysisRes.TableName,
+		PackageName: modelGenCfg.PackageName,
+		Status:      tableGenStatusCreated,
+	}
+	if skipExisting && gutil.FileExists(plan.modelFilepath) {
+		result.Status = tableGenStatusSkipped
+		result.Reason = "model file already exists"
+		return result, nil
+	}
+
+	gen := codegen.NewGenerator()
+	genParams := &codegen.GenParams{
+		ParamsList: plan.genParamsList,
+	}
+	if err := trackGenParams(genParams); err != nil {
+		return nil, err
+	}
+	if err := gen.Gen(genParams); err != nil {
+		return nil, err
+	}
+	for _, item := range plan.genParamsList {
+		result.Files = append(result.Files, filepath.Join(item.TargetDir, item.TargetFileName))
+	}
+	// like 筛选与关键字搜索通过 dao 包内共享的 like.go 转义通配符
+	if plan.daoTargetDir != "" && plan.pageList.HasLike() {
+		helperFiles, helperErr := genLikeHelper(plan.daoTargetDir, string(daoLayerName))
+		if helperErr != nil {
+			return nil, helperErr
+		}
+		result.Files = append(result.Files, helperFiles...)
+	}
+
+	if tableLayerItem != nil {
+		constName := fmt.Sprintf("TableName%s", analysisRes.StructName)
+		tableFilepath := filepath.Join(modelTargetDir, "table.go")
+		if err := trackFiles(tableFilepath); err != nil {
+			return nil, err
+		}
+		if gutil.FileExists(tableFilepath) {
+			if err := gast.AddConstToFile(tableFilepath, constName, analysisRes.TableName, token.STRING); err != nil {
+				return nil, fmt.Errorf("failed to append table const: %v", err)
+			}
+		} else {
+			tableExtraParams := ModelExtraParams{
+				AppInfo: AppInfo{
+					ProjectName:     cfg.appInfo.ProjectName,
+					AppName:         cfg.appInfo.AppName,
+					ProjectRootPath: cfg.appInfo.ProjectRootPath,
+					BaseModulePath:  cfg.appInfo.BaseModulePath,
+					AppModuleName:   cfg.appInfo.AppModuleName,
+				},
+				PackageName:    analysisRes.PackageName,
+				TableName:      analysisRes.TableName,
+				ModelLayerName: string(modelLayerName),
+				StructName:     analysisRes.StructName,
+			}
+			tableGenParams := &codegen.GenParams{
+				ParamsList: []codegen.GenParamsItem{
+					{
+						TargetDir:      modelTargetDir,
+						TargetFileName: "table.go",
+						Template:       tableLayerItem.Template,
+						ExtraParams:    tableExtraParams,
+					},
+				},
+			}
+			if err := gen.Gen(tableGenParams); err != nil {
+				return nil, fmt.Errorf("failed to generate table.go: %v", err)
+			}
+		}
+		result.Files = append(result.Files, tableFilepath)
+	}
+
+	fmt.Printf("[Model] Generated layers: model(%s), dao(%s)\n", modelLayerName, daoLayerName)
+	return result, nil
+}
+
+// modelTablePlan 单表数据层代码的生成计划：模板解析结果及 model/dao/object 各层的渲染参数
+type modelTablePlan struct {
+	analysisRes    *moduleAnalysis
+	modelLayerName codegen.LayerName
+	daoLayerName   codegen.LayerName
+	modelTargetDir string
+	modelFilepath  string
+	daoTargetDir   string
+	pageList       *PageListParams
+	tableLayerItem *tplAnalysisItem
+	layerNames     []codegen.LayerName // 与 genParamsList 一一对应的原始层名
+	genParamsList  []codegen.GenParamsItem
+}
+
+// planModelTable 解析 model 模式模板与表结构，计算各层的目标文件与渲染参数
+func planModelTable(modelGenCfg ModelConfig) (*modelTablePlan, error) {
+	// 复制嵌入的模板文件到临时目录，并应用项目自定义模板覆盖
+	tplDir, getTplErr := prepareTemplateDir(tplModeModel)
 	if getTplErr != nil {
-		return getTplErr
+		return nil, getTplErr
 	}
 	// 清理临时目录
 	defer os.RemoveAll(tplDir)
@@ -52,15 +187,15 @@ func genModel() error {
 				TplFuncIsBasicType:         IsBasicType,
 				TplFuncIsNumID:             IsNumID,
 				TplFuncHasTimeFieldAny:     HasTimeFieldAny,
+				TplFuncHasEnumField:        HasEnumField,
 			},
 		},
 		TableName: modelGenCfg.TableName,
 	}
 
-	gen := codegen.NewGenerator()
-	analysisRes, analysisErr := gen.AnalysisModuleTpl(DBClient, analysisCfg)
+	analysisRes, analysisErr := analysisModuleTpl(analysisCfg)
 	if analysisErr != nil {
-		return fmt.Errorf("analysis model tpl error: %v", analysisErr)
+		return nil, fmt.Errorf("analysis model tpl error: %v", analysisErr)
 	}
 
 	// 如果配置了表名前缀，则从结构体名中去除前缀
@@ -79,61 +214,37 @@ func genModel() error {
 		pkFieldType = "uint"
 	}
 
-	var modelLayerName, daoLayerName codegen.LayerName
+	plan := &modelTablePlan{analysisRes: analysisRes}
+	var modelDir string
 	for _, v := range analysisRes.TplAnalysisList {
 		if v.OriginLayerName == codegen.LayerNameModel {
-			modelLayerName = v.LayerName
+			plan.modelLayerName = v.LayerName
+			modelDir = v.TargetDir
 		}
 		if v.OriginLayerName == codegen.LayerNameDao {
-			daoLayerName = v.LayerName
+			plan.daoLayerName = v.LayerName
 		}
 	}
 
-	var genParamsList []codegen.GenParamsItem
-	var tableLayerItem *codegen.ModuleTplAnalysisItem
-	var modelTargetDir string
+	modelFields := buildModelFields(analysisRes.Columns, analysisRes.StructName)
+	relations, relationErr := buildRelations(analysisRes.TableName, analysisRes.StructName, analysisRes.Columns,
+		modelGenCfg.Relations, modelGenCfg.TablePrefix, modelDir)
+	if relationErr != nil {
+		return nil, relationErr
+	}
+	pageList, pageListErr := buildPageListParams(analysisRes.TableName, modelFields, modelGenCfg.PageList)
+	if pageListErr != nil {
+		return nil, pageListErr
+	}
+	plan.pageList = pageList
+	sysFieldParams := buildSysFieldParams(pkFieldType, modelFields)
 	for _, v := range analysisRes.TplAnalysisList {
-		if v.OriginLayerName == codegen.LayerName("table") {
+		if v.OriginLayerName == layerNameTable {
 			tmpV := v
-			tableLayerItem = &tmpV
+			plan.tableLayerItem = &tmpV
 			continue
 		}
 
-		var modelFields []ModelField
-		for _, field := range v.ModelFields {
-			nullableDesc := nullableDefaultDesc
-			if field.IsNullable {
-				nullableDesc = ""
-			}
-			defaultValue := fmt.Sprintf("%s %s", fieldDefaultKeyword, field.DefaultValue)
-			if field.DefaultValue == "" {
-				defaultValue = fmt.Sprintf("%s ''", fieldDefaultKeyword)
-			}
-			// GormComment 用于 model 层的 gorm tag，格式为 "comment: xxx"
-			gormComment := fmt.Sprintf("%s: %s", fieldCommentKeyword, field.Comment)
-			if field.Comment == "" {
-				gormComment = ""
-			}
-			// Comment 用于 obj 层等其他地方的普通注释，直接使用原始注释
-			comment := field.Comment
-			modelFields = append(modelFields, ModelField{
-				IsPrimaryKey:         field.IsPrimaryKey,
-				FieldName:            gutil.ReplaceIdToID(field.FieldName),
-				FieldLowerCaseName:   gutil.SnakeToLowerCamel(field.FieldName),
-				JsonTagName:          SnakeToLowerCamelWithID(field.ColumnName),
-				FieldType:            field.FieldType,
-				ColumnName:           field.ColumnName,
-				ColumnType:           field.ColumnType,
-				NullableDesc:         nullableDesc,
-				DefaultValue:         defaultValue,
-				GormComment:          gormComment,
-				Comment:              comment,
-				StructNameLowerCamel: gutil.FirstLetterToLower(analysisRes.StructName),
-				IndexName:            field.IndexName,
-				IsUniqueIndex:        field.IsUniqueIndex,
-			})
-		}
-
 		// 如果配置了表名前缀，则从文件名中去除前缀
 		targetFilename := v.TargetFilename
 		if modelGenCfg.TablePrefix != "" {
@@ -147,16 +258,28 @@ func genModel() error {
 		targetDir := v.TargetDir
 		if v.OriginLayerName == codegen.LayerNameDao {
 			targetDir = filepath.Dir(v.TargetDir)
+			plan.daoTargetDir = targetDir
 		}
 		if v.OriginLayerName == codegen.LayerNameModel {
-			modelTargetDir = targetDir
+			plan.modelTargetDir = targetDir
+			plan.modelFilepath = filepath.Join(targetDir, targetFilename)
 		}
 
 		fieldImports := calcFieldImports(modelFields)
+		if v.OriginLayerName == codegen.LayerNameModel {
+			fieldImports = calcModelImports(modelFields, sysFieldParams.EmbedGormModel)
+		}
 		if v.OriginLayerName == codegen.LayerNameObject {
-			fieldImports = calcFieldImports(modelFields, "time")
+			fieldImports = calcFieldImports(modelFields, "time", "database/sql")
+		}
+		if v.OriginLayerName == codegen.LayerNameDao {
+			fieldImports = calcFieldImports(modelFields, "database/sql")
 		}
-		genParamsList = append(genParamsList, codegen.GenParamsItem{
+		if v.OriginLayerName == codegen.LayerNameDao && (pageList.NeedTimeImport() || pageList.NeedCursorTimeImport()) {
+			fieldImports = appendImport(fieldImports, "time")
+		}
+		plan.layerNames = append(plan.layerNames, v.OriginLayerName)
+		plan.genParamsList = append(plan.genParamsList, codegen.GenParamsItem{
 			TargetDir:      targetDir,
 			TargetFileName: targetFilename,
 			Template:       v.Template,
@@ -168,91 +291,66 @@ func genModel() error {
 					BaseModulePath:  cfg.appInfo.BaseModulePath,
 					AppModuleName:   cfg.appInfo.AppModuleName,
 				},
+				SysFieldParams: sysFieldParams,
 				PackageName:    analysisRes.PackageName,
 				TableName:      analysisRes.TableName,
 				PKFieldType:    pkFieldType,
-				ModelLayerName: string(modelLayerName),
-				DaoLayerName:   string(daoLayerName),
-				DaoPackageName: string(daoLayerName),
+				KeyFields:      buildKeyFields(modelFields),
+				ModelLayerName: string(plan.modelLayerName),
+				DaoLayerName:   string(plan.daoLayerName),
+				DaoPackageName: string(plan.daoLayerName),
 				DBName:         fmt.Sprintf("%sDB", gutil.FirstLetterToUpper(cfg.ServiceName)),
 				Description:    modelGenCfg.Description,
 				StructName:     analysisRes.StructName,
 				Template:       v.Template,
 				ModelFields:    modelFields,
 				FieldImports:   fieldImports,
+				Relations:      relations,
+				UniqueIndexes:  buildUniqueIndexes(modelFields),
+				PageList:       pageList,
 			},
 		})
-
-	}
-	genParams := &codegen.GenParams{
-		ParamsList: genParamsList,
-	}
-	if err := gen.Gen(genParams); err != nil {
-		return err
-	}
-
-	if tableLayerItem != nil {
-		constName := fmt.Sprintf("TableName%s", analysisRes.StructName)
-		tableFilepath := filepath.Join(modelTargetDir, "table.go")
-		if gutil.FileExists(tableFilepath) {
-			if err := gast.AddConstToFile(tableFilepath, constName, analysisRes.TableName, token.STRING); err != nil {
-				return fmt.Errorf("failed to append table const: %v", err)
-			}
-		} else {
-			tableExtraParams := ModelExtraParams{
-				AppInfo: AppInfo{
-					ProjectName:     cfg.appInfo.ProjectName,
-					AppName:         cfg.appInfo.AppName,
-					ProjectRootPath: cfg.appInfo.ProjectRootPath,
-					BaseModulePath:  cfg.appInfo.BaseModulePath,
-					AppModuleName:   cfg.appInfo.AppModuleName,
-				},
-				PackageName:    analysisRes.PackageName,
-				TableName:      analysisRes.TableName,
-				ModelLayerName: string(modelLayerName),
-				StructName:     analysisRes.StructName,
-			}
-			tableGenParams := &codegen.GenParams{
-				ParamsList: []codegen.GenParamsItem{
-					{
-						TargetDir:      modelTargetDir,
-						TargetFileName: "table.go",
-						Template:       tableLayerItem.Template,
-						ExtraParams:    tableExtraParams,
-					},
-				},
-			}
-			if err := gen.Gen(tableGenParams); err != nil {
-				return fmt.Errorf("failed to generate table.go: %v", err)
-			}
-		}
 	}
-
-	fmt.Printf("[Model] Generated layers: model(%s), dao(%s)\n", modelLayerName, daoLayerName)
-	return nil
+	return plan, nil
 }
 
 type ModelField struct {
-	IsPrimaryKey         bool   // 是否是主键
-	FieldName            string // 字段名称
-	FieldLowerCaseName   string // 字段名称小驼峰
-	JsonTagName          string // JSON 标签名称，特殊处理 _id 后缀为 ID
-	FieldType            string // 字段数据类型，如int、string
-	ColumnName           string // 列名
-	ColumnType           string // 列数据类型，如varchar(255)
-	NullableDesc         string // 是否允许为空描述，如 NOT NULL
-	DefaultValue         string // 默认值,如 DEFAULT 0
-	GormComment          string // gorm tag中的注释，格式为 "comment: xxx"，用于 model 层
-	Comment              string // 普通注释，用于 obj 层等其他地方
-	StructNameLowerCamel string // 结构体名称小驼峰，用于模板引用
-	IndexName            string // 索引名称
-	IsUniqueIndex        bool   // 是否唯一索引
+	IsPrimaryKey         bool          // 是否是主键
+	IsKeyField           bool          // 是否是复合主键的组成列，与 ID 一样不进入 BaseInfo，由请求路径与 <Struct>Key 传递
+	FieldName            string        // 字段名称
+	FieldLowerCaseName   string        // 字段名称小驼峰
+	JsonTagName          string        // JSON 标签名称，特殊处理 _id 后缀为 ID
+	FieldType            string        // 字段数据类型，如int、string
+	ColumnName           string        // 列名
+	ColumnType           string        // 列数据类型，如varchar(255)
+	NullableDesc         string        // 是否允许为空描述，如 NOT NULL
+	DefaultValue         string        // 默认值,如 DEFAULT 0
+	GormComment          string        // gorm tag中的注释，格式为 "comment: xxx"，用于 model 层
+	Comment              string        // 普通注释，用于 obj 层等其他地方
+	StructNameLowerCamel string        // 结构体名称小驼峰，用于模板引用
+	IndexName            string        // 索引名称
+	IsUniqueIndex        bool          // 是否唯一索引
+	Indexes              []ColumnIndex // 所属的全部索引（主键除外）
+	EnumTypeName         string        // 枚举类型名称，如 UserStatus，非枚举字段为空
+	EnumDesc             string        // 枚举类型描述，取注释中冒号之前的部分
+	EnumItems            []EnumItem    // 枚举取值
+	EnumOneOf            string        // binding oneof 校验的取值，空格分隔，如 "1 2"
+	EnumList             string        // swag enums 标签的取值，逗号分隔，如 "1,2"
+	Binding              string        // obj 层 binding 校验规则，如 required,max=32，无规则时为空
+	NullableStyle        string        // 可空列的映射方式（pointer、sqlnull），非空列或 value 方式时为空
+	ModelFieldType       string        // model 层字段类型，如 string、UserStatus、*string、sql.NullString
+	NullValueField       string        // sqlnull 方式下 sql.Null 类型中值的字段名，如 String、V
+	Serializer           string        // gorm 序列化器，如 json，来自 type_overrides
+	SoftDelete           string        // 软删除列的删除方式（gorm、flag），来自 sys_fields，非软删除列为空
+	GormTagExtra         string        // 额外的 gorm 标签，如 autoCreateTime、softDelete:flag
 }
 
 type ModelExtraParams struct {
 	AppInfo
+	SysFieldParams
 	PackageName    string
 	PKFieldType    string
+	KeyFields      []ModelField // 复合主键的各列，单列主键时为空
 	ModelLayerName string
 	DaoLayerName   string
 	DaoPackageName string
@@ -263,6 +361,9 @@ type ModelExtraParams struct {
 	Template       *template.Template
 	ModelFields    []ModelField
 	FieldImports   []string
+	Relations      []Relation
+	UniqueIndexes  []UniqueIndex   // 唯一索引，用于生成 DAO 按唯一键查询的方法
+	PageList       *PageListParams // 分页列表的筛选、排序与关键字搜索，未配置时为 nil
 }
 
 func calcFieldImports(fields []ModelField, excludeImports ...string) []string {
@@ -271,8 +372,10 @@ func calcFieldImports(fields []ModelField, excludeImports ...string) []string {
 		if IsBuiltInField(field.FieldName) {
 			continue
 		}
-		if importInfo, ok := fieldTypeImportMap[field.FieldType]; ok {
-			importMap[importInfo.ImportPath] = struct{}{}
+		for _, fieldType := range []string{field.FieldType, field.ModelFieldType} {
+			if importInfo, ok := lookupFieldTypeImport(fieldType); ok {
+				importMap[importInfo.ImportPath] = struct{}{}
+			}
 		}
 	}
 	for _, exclude := range excludeImports {
@@ -285,3 +388,15 @@ func calcFieldImports(fields []ModelField, excludeImports ...string) []string {
 	sort.Strings(imports)
 	return imports
 }
+
+// appendImport 追加导入路径，已存在时不重复追加，结果保持有序
+func appendImport(imports []string, importPath string) []string {
+	for _, v := range imports {
+		if v == importPath {
+			return imports
+		}
+	}
+	imports = append(imports, importPath)
+	sort.Strings(imports)
+	return imports
+}
@@ -1,10 +1,12 @@
 package generate
 
 import (
+	"bytes"
 	"fmt"
 	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/morehao/golib/codegen"
@@ -13,14 +15,53 @@ import (
 )
 
 func genModule() error {
-	moduleGenCfg := cfg.Module
+	moduleCfg := cfg.Module
+	single := TableConfig{
+		TableName:   moduleCfg.TableName,
+		PackageName: moduleCfg.PackageName,
+		Description: moduleCfg.Description,
+	}
+	tables, batch, resolveErr := resolveTables(single, moduleCfg.Tables, moduleCfg.TablePrefix, tablePatterns)
+	if resolveErr != nil {
+		return resolveErr
+	}
+	setBatchTables(tables)
+
+	var results []*tableGenResult
+	for _, table := range tables {
+		result, genErr := genModuleTable(ModuleConfig{
+			PackageName: table.PackageName,
+			Description: table.Description,
+			TableName:   table.TableName,
+			TablePrefix: moduleCfg.TablePrefix,
+			Relations:   resolveRelationConfig(table, moduleCfg.Relations),
+			PageList:    resolvePageListConfig(table, moduleCfg.PageList),
+			WithGrpc:    moduleCfg.WithGrpc,
+		}, batch)
+		if genErr != nil {
+			return fmt.Errorf("generate table %s error: %v", table.TableName, genErr)
+		}
+		results = append(results, result)
+	}
+
+	// 所有表生成完成后统一注册路由
+	if err := registerRouters(results); err != nil {
+		return err
+	}
+	if batch {
+		printTableGenSummary("Module", results)
+	}
+	return nil
+}
 
+// genModuleTable 生成单张表的完整模块代码，skipExisting 为 true 时若 model 文件已存在则跳过该表
+func genModuleTable(moduleGenCfg ModuleConfig, skipExisting bool) (*tableGenResult, error) {
 	fmt.Printf("[Module] Generating module based on table: %s\n", moduleGenCfg.TableName)
 
-	// 使用工具函数复制嵌入的模板文件到临时目录
-	tplDir, getTplErr := CopyEmbeddedTemplatesToTempDir(TemplatesFS, "generate/module")
+	// 复制嵌入的模板文件到临时目录，并应用项目自定义模板覆盖
+	tplDir, getTplErr := prepareTemplateDir(tplModeModule)
 	if getTplErr != nil {
-		return getTplErr
+		return nil, getTplErr
 	}
 	// 清理临时目录
 	defer os.RemoveAll(tplDir)
@@ -43,21 +84,30 @@ func genModule() error {
 				TplFuncHasTimeField:        HasTimeField,
 				TplFuncGetFieldImports:     GetFieldImports,
 				TplFuncIsBasicType:         IsBasicType,
-				TplFuncToKebabCase:        toKebabCase,
-				TplFuncPluralize:          pluralize,
-				TplFuncIsNumID:            IsNumID,
-				TplFuncIsStringID:         IsStringID,
-				TplFuncIsIntID:            IsIntID,
-				TplFuncHasTimeFieldAny:    HasTimeFieldAny,
+				TplFuncToKebabCase:         toKebabCase,
+				TplFuncPluralize:           pluralize,
+				TplFuncIsNumID:             IsNumID,
+				TplFuncIsStringID:          IsStringID,
+				TplFuncIsIntID:             IsIntID,
+				TplFuncHasTimeFieldAny:     HasTimeFieldAny,
+				TplFuncHasEnumField:        HasEnumField,
+				TplFuncSampleValue:         SampleValue,
+				TplFuncSampleImports:       SampleImports,
+				TplFuncFirstLetterToUpper:  gutil.FirstLetterToUpper,
+				TplFuncNullableToModel:     NullableToModel,
+				TplFuncNullableToObject:    NullableToObject,
+				TplFuncNullableModelPtr:    NullableModelPtr,
+				TplFuncOperatorID:          OperatorID,
+				TplFuncUnixTime:            UnixTime,
 			},
 		},
 		TableName: moduleGenCfg.TableName,
 	}
-	gen := codegen.NewGenerator()
-	analysisRes, analysisErr := gen.AnalysisModuleTpl(DBClient, analysisCfg)
+	analysisRes, analysisErr := analysisModuleTpl(analysisCfg)
 	if analysisErr != nil {
-		return fmt.Errorf("analysis module tpl error: %v", analysisErr)
+		return nil, fmt.Errorf("analysis module tpl error: %v", analysisErr)
 	}
+	gen := codegen.NewGenerator()
 
 	// 如果配置了表名前缀，则从结构体名中去除前缀
 	if moduleGenCfg.TablePrefix != "" {
@@ -76,73 +126,79 @@ func genModule() error {
 	}
 
 	var modelLayerName, daoLayerName codegen.LayerName
+	var modelDir string
 	for _, v := range analysisRes.TplAnalysisList {
 		if v.OriginLayerName == codegen.LayerNameModel {
 			modelLayerName = v.LayerName
+			modelDir = v.TargetDir
 		}
 		if v.OriginLayerName == codegen.LayerNameDao {
 			daoLayerName = v.LayerName
 		}
 	}
+	relations, relationErr := buildRelations(analysisRes.TableName, analysisRes.StructName, analysisRes.Columns,
+		moduleGenCfg.Relations, moduleGenCfg.TablePrefix, modelDir)
+	if relationErr != nil {
+		return nil, relationErr
+	}
+	pageList, pageListErr := buildPageListParams(analysisRes.TableName, buildModelFields(analysisRes.Columns, analysisRes.StructName), moduleGenCfg.PageList)
+	if pageListErr != nil {
+		return nil, pageListErr
+	}
+	if moduleGenCfg.WithGrpc {
+		if err := validateGrpcCompositeKey(analysisRes.TableName, analysisRes.Columns); err != nil {
+			return nil, err
+		}
+		if pageList.IsCursor() {
+			return nil, fmt.Errorf("--with-grpc does not support cursor pagination of table %s", analysisRes.TableName)
+		}
+		if err := validateGrpcFieldTypes(analysisRes.TableName, analysisRes.Columns); err != nil {
+			return nil, err
+		}
+	}
 	appInfo := cfg.appInfo
+	result := &tableGenResult{
+		TableName:   analysisRes.TableName,
+		PackageName: moduleGenCfg.PackageName,
+		Status:      tableGenStatusCreated,
+	}
+
+	// 生成的单元测试依赖项目的 pkg/testsetup 初始化应用与构造 gin.Context，不存在时跳过测试文件
+	testSetupDir := filepath.Join(appInfo.ProjectRootPath, "pkg", "testsetup")
+	withTests := true
+	if info, statErr := os.Stat(testSetupDir); statErr != nil || !info.IsDir() {
+		withTests = false
+		fmt.Printf("[Module] Skip unit tests: %s not found\n", testSetupDir)
+	}
 
 	var genParamsList []codegen.GenParamsItem
-	var codeLayerItem *codegen.ModuleTplAnalysisItem
-	var tableLayerItem *codegen.ModuleTplAnalysisItem
+	var codeLayerItem *tplAnalysisItem
+	var tableLayerItem *tplAnalysisItem
 	var modelTargetDir string
+	var serviceTargetDir, daoTargetDir string
 	for _, v := range analysisRes.TplAnalysisList {
+		if !withTests && (v.OriginLayerName == layerNameServiceTest || v.OriginLayerName == layerNameControllerTest) {
+			continue
+		}
 		if v.OriginLayerName == codegen.LayerNameCode {
 			tmpV := v
 			codeLayerItem = &tmpV
 			continue
 		}
-		if v.OriginLayerName == codegen.LayerName("table") {
+		if v.OriginLayerName == layerNameTable {
 			tmpV := v
 			tableLayerItem = &tmpV
 			continue
 		}
 
-		var modelFields []ModelField
-		for _, field := range v.ModelFields {
-			nullableDesc := nullableDefaultDesc
-			if field.IsNullable {
-				nullableDesc = ""
-			}
-			defaultValue := fmt.Sprintf("%s %s", fieldDefaultKeyword, field.DefaultValue)
-			if field.DefaultValue == "" {
-				defaultValue = fmt.Sprintf("%s ''", fieldDefaultKeyword)
-			}
-			// GormComment 用于 model 层的 gorm tag，格式为 "comment: xxx"
-			gormComment := fmt.Sprintf("%s: %s", fieldCommentKeyword, field.Comment)
-			if field.Comment == "" {
-				gormComment = ""
-			}
-			// Comment 用于 obj 层等其他地方的普通注释，直接使用原始注释
-			comment := field.Comment
-			modelFields = append(modelFields, ModelField{
-				IsPrimaryKey:         field.IsPrimaryKey,
-				FieldName:            gutil.ReplaceIdToID(field.FieldName),
-				FieldLowerCaseName:   gutil.SnakeToLowerCamel(field.FieldName),
-				JsonTagName:          SnakeToLowerCamelWithID(field.ColumnName),
-				FieldType:            field.FieldType,
-				ColumnName:           field.ColumnName,
-				ColumnType:           field.ColumnType,
-				NullableDesc:         nullableDesc,
-				DefaultValue:         defaultValue,
-				GormComment:          gormComment,
-				Comment:              comment,
-				StructNameLowerCamel: gutil.FirstLetterToLower(analysisRes.StructName),
-				IndexName:            field.IndexName,
-				IsUniqueIndex:        field.IsUniqueIndex,
-			})
-		}
+		modelFields := buildModelFields(analysisRes.Columns, analysisRes.StructName)
 
 		// 如果配置了表名前缀，则从文件名中去除前缀
 		targetFilename := v.TargetFilename
 		// router 文件统一使用带下划线的 snake_case 文件名（如 core_task.go），
 		// 与 api 模式生成的 router 文件名保持一致，避免同名 router 函数落入不同文件导致重复声明。
 		if v.OriginLayerName == codegen.LayerNameRouter {
-			targetFilename = fmt.Sprintf("%s%s", cfg.Module.PackageName, ".go")
+			targetFilename = fmt.Sprintf("%s%s", moduleGenCfg.PackageName, ".go")
 		} else if moduleGenCfg.TablePrefix != "" {
 			targetFilename = RemoveTablePrefixFromFilename(
 				v.TargetFilename,
@@ -157,54 +213,114 @@ func genModule() error {
 		}
 		if v.OriginLayerName == codegen.LayerNameModel {
 			modelTargetDir = targetDir
+			if skipExisting && gutil.FileExists(filepath.Join(targetDir, targetFilename)) {
+				result.Status = tableGenStatusSkipped
+				result.Reason = "model file already exists"
+				return result, nil
+			}
 		}
 
+		sysFieldParams := buildSysFieldParams(pkFieldType, modelFields)
 		fieldImports := calcFieldImports(modelFields)
-			if v.OriginLayerName == codegen.LayerNameObject {
-				fieldImports = calcFieldImports(modelFields, "time")
+		switch v.OriginLayerName {
+		case codegen.LayerNameModel:
+			fieldImports = calcModelImports(modelFields, sysFieldParams.EmbedGormModel)
+		case codegen.LayerNameObject:
+			fieldImports = calcFieldImports(modelFields, "time", "database/sql")
+		case codegen.LayerNameDao:
+			fieldImports = calcFieldImports(modelFields, "database/sql")
+			daoTargetDir = targetDir
+			if pageList.NeedTimeImport() || pageList.NeedCursorTimeImport() {
+				fieldImports = appendImport(fieldImports, "time")
 			}
-			genParamsList = append(genParamsList, codegen.GenParamsItem{
-				TargetDir:      targetDir,
-				TargetFileName: targetFilename,
-				Template:       v.Template,
-				ExtraParams: ModuleExtraParams{
-					AppInfo: AppInfo{
-						ProjectName:     appInfo.ProjectName,
-						AppName:         appInfo.AppName,
-						ProjectRootPath: appInfo.ProjectRootPath,
-						BaseModulePath:  appInfo.BaseModulePath,
-						AppModuleName:   appInfo.AppModuleName,
-					},
-					PackageName:          analysisRes.PackageName,
-					TableName:            analysisRes.TableName,
-					PKFieldType:          pkFieldType,
-					ModelLayerName:       string(modelLayerName),
-					DaoLayerName:         string(daoLayerName),
-					DaoPackageName:       string(daoLayerName),
-					DBName:               fmt.Sprintf("%sDB", gutil.FirstLetterToUpper(cfg.ServiceName)),
-					Description:          moduleGenCfg.Description,
-					StructName:           analysisRes.StructName,
-					StructNameLowerCamel: gutil.FirstLetterToLower(analysisRes.StructName),
-					Template:             v.Template,
-					ModelFields:          modelFields,
-					FieldImports:         fieldImports,
+		case codegen.LayerNameService:
+			fieldImports = calcServiceImports(modelFields, pageList)
+			serviceTargetDir = targetDir
+		}
+		genParamsList = append(genParamsList, codegen.GenParamsItem{
+			TargetDir:      targetDir,
+			TargetFileName: targetFilename,
+			Template:       v.Template,
+			ExtraParams: ModuleExtraParams{
+				AppInfo: AppInfo{
+					ProjectName:     appInfo.ProjectName,
+					AppName:         appInfo.AppName,
+					ProjectRootPath: appInfo.ProjectRootPath,
+					BaseModulePath:  appInfo.BaseModulePath,
+					AppModuleName:   appInfo.AppModuleName,
 				},
-			})
+				SysFieldParams:       sysFieldParams,
+				PackageName:          analysisRes.PackageName,
+				TableName:            analysisRes.TableName,
+				PKFieldType:          pkFieldType,
+				KeyFields:            buildKeyFields(modelFields),
+				ModelLayerName:       string(modelLayerName),
+				DaoLayerName:         string(daoLayerName),
+				DaoPackageName:       string(daoLayerName),
+				DBName:               fmt.Sprintf("%sDB", gutil.FirstLetterToUpper(cfg.ServiceName)),
+				Description:          moduleGenCfg.Description,
+				StructName:           analysisRes.StructName,
+				StructNameLowerCamel: gutil.FirstLetterToLower(analysisRes.StructName),
+				Template:             v.Template,
+				ModelFields:          modelFields,
+				FieldImports:         fieldImports,
+				Relations:            relations,
+				UniqueIndexes:        buildUniqueIndexes(modelFields),
+				PageList:             pageList,
+			},
+		})
 
 	}
 	genParams := &codegen.GenParams{
 		ParamsList: genParamsList,
 	}
+	if err := trackGenParams(genParams); err != nil {
+		return nil, err
+	}
 	if err := gen.Gen(genParams); err != nil {
-		return err
+		return nil, err
+	}
+	for _, item := range genParamsList {
+		result.Files = append(result.Files, filepath.Join(item.TargetDir, item.TargetFileName))
+	}
+
+	// object 层可空字段为指针时，service 通过包内共享的 nullable.go 转换
+	if serviceTargetDir != "" && hasNullableBaseField(buildModelFields(analysisRes.Columns, analysisRes.StructName)) {
+		helperFiles, helperErr := genNullableHelper(serviceTargetDir, analysisRes.PackageName)
+		if helperErr != nil {
+			return nil, helperErr
+		}
+		result.Files = append(result.Files, helperFiles...)
+	}
+	// like 筛选与关键字搜索通过 dao 包内共享的 like.go 转义通配符
+	if daoTargetDir != "" && pageList.HasLike() {
+		helperFiles, helperErr := genLikeHelper(daoTargetDir, string(daoLayerName))
+		if helperErr != nil {
+			return nil, helperErr
+		}
+		result.Files = append(result.Files, helperFiles...)
+	}
+
+	if moduleGenCfg.WithGrpc {
+		grpcParams := buildGrpcParams(appInfo, analysisRes.PackageName, moduleGenCfg.Description, analysisRes.StructName, pkFieldType,
+			buildModelFields(analysisRes.Columns, analysisRes.StructName), pageList)
+		tableFilename := RemoveTablePrefixFromFilename(analysisRes.TableName+".go", analysisRes.TableName, moduleGenCfg.TablePrefix)
+		grpcFiles, grpcErr := genGrpcLayers(grpcParams, tableFilename)
+		if grpcErr != nil {
+			return nil, grpcErr
+		}
+		result.Files = append(result.Files, grpcFiles...)
 	}
 
 	if tableLayerItem != nil {
 		constName := fmt.Sprintf("TableName%s", analysisRes.StructName)
 		tableFilepath := filepath.Join(modelTargetDir, "table.go")
+		if err := trackFiles(tableFilepath); err != nil {
+			return nil, err
+		}
 		if gutil.FileExists(tableFilepath) {
 			if err := gast.AddConstToFile(tableFilepath, constName, analysisRes.TableName, token.STRING); err != nil {
-				return fmt.Errorf("failed to append table const: %v", err)
+				return nil, fmt.Errorf("failed to append table const: %v", err)
 			}
 		} else {
 			tableExtraParams := ModuleExtraParams{
@@ -231,56 +347,19 @@ func genModule() error {
 				},
 			}
 			if err := gen.Gen(tableGenParams); err != nil {
-				return fmt.Errorf("failed to generate table.go: %v", err)
+				return nil, fmt.Errorf("failed to generate table.go: %v", err)
 			}
 		}
+		result.Files = append(result.Files, tableFilepath)
 	}
 
-	// 注册路由
-	routerContent := fmt.Sprintf("%sRouter(groups)", gutil.FirstLetterToLower(analysisRes.StructName))
-	routerEnterFilepath := filepath.Join(workDir, "/internal/router/router.go")
-	if err := gast.AddContentToFunc(routerEnterFilepath, "RegisterRouter", routerContent); err != nil {
-		return fmt.Errorf("router appendContentToFunc error: %v", err)
-	}
-	fmt.Printf("[Module] Registered router: %sRouter\n", gutil.FirstLetterToLower(analysisRes.StructName))
+	// 路由在所有表生成完成后统一注册
+	result.RouterFunc = fmt.Sprintf("%sRouter", gutil.FirstLetterToLower(analysisRes.StructName))
 
 	// 处理code层：生成错误码文件到项目根目录的pkg/code目录
 	if codeLayerItem != nil {
 		// 构造code层的ExtraParams
-		var modelFields []ModelField
-		for _, field := range codeLayerItem.ModelFields {
-			nullableDesc := nullableDefaultDesc
-			if field.IsNullable {
-				nullableDesc = ""
-			}
-			defaultValue := fmt.Sprintf("%s %s", fieldDefaultKeyword, field.DefaultValue)
-			if field.DefaultValue == "" {
-				defaultValue = fmt.Sprintf("%s ''", fieldDefaultKeyword)
-			}
-			// GormComment 用于 model 层的 gorm tag，格式为 "comment: xxx"
-			gormComment := fmt.Sprintf("%s: %s", fieldCommentKeyword, field.Comment)
-			if field.Comment == "" {
-				gormComment = ""
-			}
-			// Comment 用于 obj 层等其他地方的普通注释，直接使用原始注释
-			comment := field.Comment
-			modelFields = append(modelFields, ModelField{
-				IsPrimaryKey:         field.IsPrimaryKey,
-				FieldName:            gutil.ReplaceIdToID(field.FieldName),
-				FieldLowerCaseName:   gutil.SnakeToLowerCamel(field.FieldName),
-				JsonTagName:          SnakeToLowerCamelWithID(field.ColumnName),
-				FieldType:            field.FieldType,
-				ColumnName:           field.ColumnName,
-				ColumnType:           field.ColumnType,
-				NullableDesc:         nullableDesc,
-				DefaultValue:         defaultValue,
-				GormComment:          gormComment,
-				Comment:              comment,
-				StructNameLowerCamel: gutil.FirstLetterToLower(analysisRes.StructName),
-				IndexName:            field.IndexName,
-				IsUniqueIndex:        field.IsUniqueIndex,
-			})
-		}
+		modelFields := buildModelFields(analysisRes.Columns, analysisRes.StructName)
 
 		codeExtraParams := ModuleExtraParams{
 			AppInfo: AppInfo{
@@ -303,15 +382,38 @@ func genModule() error {
 			Template:             codeLayerItem.Template,
 			ModelFields:          modelFields,
 			FieldImports:         calcFieldImports(modelFields),
+			UniqueIndexes:        buildUniqueIndexes(modelFields),
 		}
 
 		// 生成错误码文件到项目根目录的pkg/code目录
 		codeTargetDir := filepath.Join(cfg.appInfo.ProjectRootPath, "pkg/code")
 		codeTargetFileName := fmt.Sprintf("%s.go", moduleGenCfg.PackageName)
 
+		// 分配错误码区间，并预渲染错误码文件校验与已有错误码不冲突
+		existingCodes, scanErr := scanCodeConsts(codeTargetDir)
+		if scanErr != nil {
+			return nil, fmt.Errorf("scan error codes error: %v", scanErr)
+		}
+		codeBase, codeBlockSize := errorCodeRange()
+		codeExtraParams.ErrorCodeBase = allocateErrorCodeBlock(existingCodes, codeBase, codeBlockSize)
+		var codeBuf bytes.Buffer
+		if err := codeLayerItem.Template.Execute(&codeBuf, codeExtraParams); err != nil {
+			return nil, fmt.Errorf("render code file error: %v", err)
+		}
+		generatedCodes, parseCodeErr := parseCodeConsts(codeTargetFileName, codeBuf.Bytes())
+		if parseCodeErr != nil {
+			return nil, parseCodeErr
+		}
+		if err := checkErrorCodeCollision(existingCodes, generatedCodes, codeExtraParams.ErrorCodeBase, codeBlockSize); err != nil {
+			return nil, err
+		}
+
+		if err := trackFiles(filepath.Join(codeTargetDir, codeTargetFileName)); err != nil {
+			return nil, err
+		}
 		// 确保目录存在
 		if err := os.MkdirAll(codeTargetDir, 0755); err != nil {
-			return fmt.Errorf("failed to create code directory: %v", err)
+			return nil, fmt.Errorf("failed to create code directory: %v", err)
 		}
 
 		// 使用codegen的createFile函数生成文件（支持追加）
@@ -326,26 +428,60 @@ func genModule() error {
 			},
 		}
 		if err := gen.Gen(codeGenParams); err != nil {
-			return fmt.Errorf("failed to generate code file: %v", err)
+			return nil, fmt.Errorf("failed to generate code file: %v", err)
 		}
 
 		// 注册错误码到项目根目录的pkg/code/code.go
 		codeContent := fmt.Sprintf("registerError(%sErrorMsgMap)", gutil.FirstLetterToLower(analysisRes.StructName))
 		codeEnterFilepath := filepath.Join(cfg.appInfo.ProjectRootPath, "pkg/code/code.go")
+		if err := trackFiles(codeEnterFilepath); err != nil {
+			return nil, err
+		}
 		if err := gast.AddContentToFunc(codeEnterFilepath, "init", codeContent); err != nil {
-			return fmt.Errorf("code appendContentToFunc error: %v", err)
+			return nil, fmt.Errorf("code appendContentToFunc error: %v", err)
 		}
-		fmt.Printf("[Module] Registered error code: %sErrorMsgMap\n", gutil.FirstLetterToLower(analysisRes.StructName))
+		result.Files = append(result.Files, filepath.Join(codeTargetDir, codeTargetFileName), codeEnterFilepath)
+		fmt.Printf("[Module] Registered error code: %sErrorMsgMap (%d-%d)\n", gutil.FirstLetterToLower(analysisRes.StructName),
+			codeExtraParams.ErrorCodeBase, codeExtraParams.ErrorCodeBase+codeBlockSize-1)
 	}
 
 	fmt.Printf("[Module] Generated layers: model(%s), dao(%s)\n", modelLayerName, daoLayerName)
+	return result, nil
+}
+
+// registerRouters 将生成的路由函数统一注册到 RegisterRouter，已注册的路由函数不重复注册
+func registerRouters(results []*tableGenResult) error {
+	routerEnterFilepath := filepath.Join(workDir, "/internal/router/router.go")
+	routerFileContent, readErr := os.ReadFile(routerEnterFilepath)
+	if readErr != nil {
+		return fmt.Errorf("read router file error: %v", readErr)
+	}
+	for _, result := range results {
+		if result.RouterFunc == "" || result.Status != tableGenStatusCreated {
+			continue
+		}
+		routerContent := fmt.Sprintf("%s(groups)", result.RouterFunc)
+		if strings.Contains(string(routerFileContent), routerContent) {
+			fmt.Printf("[Module] Router already registered: %s\n", result.RouterFunc)
+			continue
+		}
+		if err := trackFiles(routerEnterFilepath); err != nil {
+			return err
+		}
+		if err := gast.AddContentToFunc(routerEnterFilepath, "RegisterRouter", routerContent); err != nil {
+			return fmt.Errorf("router appendContentToFunc error: %v", err)
+		}
+		fmt.Printf("[Module] Registered router: %s\n", result.RouterFunc)
+	}
 	return nil
 }
 
 type ModuleExtraParams struct {
 	AppInfo
+	SysFieldParams
 	PackageName          string
 	PKFieldType          string
+	KeyFields            []ModelField // 复合主键的各列，单列主键时为空
 	ModelLayerName       string
 	DaoLayerName         string
 	DaoPackageName       string
@@ -357,4 +493,8 @@ type ModuleExtraParams struct {
 	Template             *template.Template
 	ModelFields          []ModelField
 	FieldImports         []string
+	Relations            []Relation
+	UniqueIndexes        []UniqueIndex   // 唯一索引，用于生成 DAO 查询方法、重复校验与 AlreadyExist 错误码
+	PageList             *PageListParams // 分页列表的筛选、排序与关键字搜索，未配置时为 nil
+	ErrorCodeBase        int64           // 模块错误码区间起始值，仅 code 层使用
 }
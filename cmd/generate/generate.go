@@ -28,6 +28,9 @@ var workDir string
 var cfg *Config
 var DBClient *gorm.DB
 
+// tablePatterns --tables 参数指定的表名匹配规则
+var tablePatterns []string
+
 // Cmd represents the generate command
 var Cmd = &cobra.Command{
 	Use:   "generate",
@@ -54,7 +57,7 @@ var apiCmd = &cobra.Command{
 }
 
 func init() {
-	Cmd.AddCommand(moduleCmd, modelCmd, apiCmd)
+	Cmd.AddCommand(moduleCmd, modelCmd, apiCmd, syncCmd)
 }
 
 func runGenerate(genFn func() error) func(cmd *cobra.Command, args []string) {
@@ -67,12 +70,12 @@ func runGenerate(genFn func() error) func(cmd *cobra.Command, args []string) {
 			return
 		}
 
-		workDir = filepath.Join(projectRootDir, "apps", appName)
-
-		if _, err := os.Stat(workDir); os.IsNotExist(err) {
-			fmt.Printf("App directory does not exist: %s\n", workDir)
+		appDir, resolveErr := resolveAppDir(projectRootDir, appName)
+		if resolveErr != nil {
+			fmt.Printf("Resolve app directory error: %v\n", resolveErr)
 			return
 		}
+		workDir = appDir
 
 		if cfg == nil {
 			configFilepath := filepath.Join(workDir, "config", "code_gen.yaml")
@@ -90,7 +93,46 @@ func runGenerate(genFn func() error) func(cmd *cobra.Command, args []string) {
 			cfg.appInfo = *appInfo
 		}
 
-		if DBClient == nil {
+		tablePatterns, _ = cmd.Flags().GetStringSlice("tables")
+		if withGrpc, _ := cmd.Flags().GetBool("with-grpc"); withGrpc {
+			cfg.Module.WithGrpc = true
+		}
+
+		if err := validateNullableStyle(cfg.NullableStyle); err != nil {
+			fmt.Printf("Check config error: %v\n", err)
+			return
+		}
+		if err := validateTypeOverrides(cfg.TypeOverrides); err != nil {
+			fmt.Printf("Check config error: %v\n", err)
+			return
+		}
+		if err := validateSysFields(cfg.SysFields); err != nil {
+			fmt.Printf("Check config error: %v\n", err)
+			return
+		}
+
+		ddlFiles, _ := cmd.Flags().GetStringSlice("ddl")
+		if len(ddlFiles) > 0 {
+			cfg.SchemaSource = SchemaSourceDDL
+		}
+
+		if cfg.SchemaSource == SchemaSourceDDL {
+			if ddlTables == nil {
+				// --ddl 参数的相对路径基于当前目录，配置文件中的相对路径基于项目根目录
+				ddlBaseDir := cfg.appInfo.ProjectRootPath
+				if len(ddlFiles) == 0 {
+					ddlFiles = cfg.DDLFiles
+				} else {
+					ddlBaseDir = projectRootDir
+				}
+				tables, loadErr := loadDDLTables(ddlFiles, ddlBaseDir, ddlDialect(cfg.DatabaseDSN))
+				if loadErr != nil {
+					fmt.Printf("Load ddl files error: %v\n", loadErr)
+					return
+				}
+				ddlTables = tables
+			}
+		} else if DBClient == nil {
 			dbCfg, parseErr := ParseDatabaseDSN(cfg.DatabaseDSN)
 			if parseErr != nil {
 				fmt.Printf("Parse database dsn error: %v\n", parseErr)
@@ -104,6 +146,14 @@ func runGenerate(genFn func() error) func(cmd *cobra.Command, args []string) {
 				dbClient, openErr = gorm.Open(mysql.Open(dbCfg.ConnStr), &gorm.Config{})
 			case DBTypePostgres:
 				dbClient, openErr = gorm.Open(postgres.Open(dbCfg.ConnStr), &gorm.Config{})
+			case DBTypeSQLite:
+				// 数据库文件的相对路径基于项目根目录，与 ddl_files 保持一致
+				dialector, dialectorErr := sqliteDialector(dbCfg.ConnStr, cfg.appInfo.ProjectRootPath)
+				if dialectorErr != nil {
+					fmt.Printf("Open database connection error: %v\n", dialectorErr)
+					return
+				}
+				dbClient, openErr = gorm.Open(dialector, &gorm.Config{})
 			default:
 				fmt.Printf("Unsupported database type: %s\n", dbCfg.Type)
 				return
@@ -115,16 +165,35 @@ func runGenerate(genFn func() error) func(cmd *cobra.Command, args []string) {
 			DBClient = dbClient
 		}
 
-		if err := genFn(); err != nil {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			if err := runDryRun(genFn); err != nil {
+				fmt.Printf("Error generating: %v\n", err)
+				return
+			}
+			fmt.Println("Dry run finished, no files were written")
+			return
+		}
+
+		if err := runInTransaction(genFn); err != nil {
 			fmt.Printf("Error generating: %v\n", err)
 			return
 		}
 		fmt.Println("Generated successfully")
+		if hint := softDeleteDependencyHint(workDir); hint != "" {
+			fmt.Println(hint)
+		}
 	}
 }
 
 func init() {
-	for _, subCmd := range []*cobra.Command{moduleCmd, modelCmd, apiCmd} {
+	for _, subCmd := range []*cobra.Command{moduleCmd, modelCmd, apiCmd, syncCmd} {
 		subCmd.Flags().StringP("app", "a", "", "App name to generate code for (e.g., demoapp)")
+		subCmd.Flags().Bool("dry-run", false, "Preview created/modified files and diffs without writing to disk")
+	}
+	for _, subCmd := range []*cobra.Command{moduleCmd, modelCmd, syncCmd} {
+		subCmd.Flags().StringSlice("ddl", nil, "Read table schema from SQL DDL files instead of database (supports glob, e.g., scripts/sql/*.sql)")
+		subCmd.Flags().StringSlice("tables", nil, "Generate tables matching the patterns in batch (glob, e.g., 'iam_*', or regex wrapped in slashes, e.g., '/^iam_(user|role)$/')")
 	}
+	moduleCmd.Flags().Bool("with-grpc", false, "Also generate a .proto file and a gRPC server delegating to the generated service")
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/morehao/gocli/internal/scaffold"
 	"github.com/morehao/golib/gutil"
+	"github.com/spf13/cobra"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -104,6 +105,45 @@ func resetGenerateState() {
 	cfg = nil
 	workDir = ""
 	DBClient = nil
+	ddlTables = nil
+	tablePatterns = nil
+	batchTableNames = nil
+	for _, subCmd := range []*cobra.Command{moduleCmd, modelCmd, apiCmd, syncCmd} {
+		for _, name := range []string{"ddl", "tables", "dry-run"} {
+			resetFlag(subCmd, name)
+		}
+	}
+	for _, subCmd := range []*cobra.Command{openapiCmd, clientCmd} {
+		for _, name := range []string{"output", "lang"} {
+			resetFlag(subCmd, name)
+		}
+	}
+	resetFlag(removeModuleCmd, "yes")
+	resetFlag(moduleCmd, "with-grpc")
+}
+
+// writeCodeGenConfig 覆盖当前目录（示例副本）下 demoapp 的 code_gen.yaml
+func writeCodeGenConfig(t *testing.T, content string) {
+	t.Helper()
+	configFilepath := filepath.Join("apps", "demoapp", "config", "code_gen.yaml")
+	if err := os.WriteFile(configFilepath, []byte(strings.TrimPrefix(content, "\n")), 0644); err != nil {
+		t.Fatalf("write code_gen.yaml: %v", err)
+	}
+}
+
+// resetFlag 将参数恢复为默认值，避免 cobra 命令在用例间复用参数值
+func resetFlag(cmd *cobra.Command, name string) {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		return
+	}
+	// 切片类型参数的 Set 为追加语义，需整体替换
+	if sliceValue, ok := flag.Value.(interface{ Replace([]string) error }); ok {
+		_ = sliceValue.Replace(nil)
+	} else {
+		_ = flag.Value.Set(flag.DefValue)
+	}
+	flag.Changed = false
 }
 
 // skipIfDBUnavailable 数据库不可达时跳过测试
@@ -245,25 +285,27 @@ func TestGenerateModuleCode(t *testing.T) {
 
 // TestApiTemplateRestfulPath 验证 api 模板生成的路径为 kebab-case 复数资源 + 动作子路径（与 module 模板的 restful 风格一致）
 func TestApiTemplateRestfulPath(t *testing.T) {
-	tplFuncs := template.FuncMap{
-		TplFuncToKebabCase: toKebabCase,
-		TplFuncPluralize:   pluralize,
-	}
+	tplFuncs := apiTplFuncMap()
 	params := map[string]interface{}{
-		"AppName":                "demoapp",
-		"PackageName":            "user",
-		"BaseModulePath":         "github.com/example",
-		"AppModuleName":          "demoapp",
-		"StructName":             "UserLoginLog",
-		"StructNameLowerCamel":   "userLoginLog",
-		"FunctionName":           "Delete",
-		"FunctionNameLowerCamel": "delete",
-		"HttpMethod":             "POST",
-		"Description":            "删除登录记录",
-		"ApiDocTag":              "用户登录记录",
-		"TargetFileExist":        false,
-		"IsNewRouter":            true,
-	}
+		"AppName":              "demoapp",
+		"PackageName":          "user",
+		"BaseModulePath":       "github.com/example",
+		"AppModuleName":        "demoapp",
+		"StructName":           "UserLoginLog",
+		"StructNameLowerCamel": "userLoginLog",
+		"Description":          "删除登录记录",
+		"TargetFileExist":      false,
+		"IsNewRouter":          true,
+	}
+	endpoint := ApiEndpoint{
+		ApiFields:              ApiFields{BindBody: true},
+		FunctionName:           "Delete",
+		FunctionNameLowerCamel: "delete",
+		HttpMethod:             "POST",
+		Description:            "删除登录记录",
+		ApiDocTag:              "用户登录记录",
+	}
+	params["Endpoints"] = []ApiEndpoint{endpoint}
 
 	controllerTpl, err := template.New("controller.go.tpl").Funcs(tplFuncs).ParseFS(TemplatesFS, "generate/api/controller.go.tpl")
 	if err != nil {
@@ -279,7 +321,9 @@ func TestApiTemplateRestfulPath(t *testing.T) {
 	}
 
 	// GET 分支：路由 restful 化，且函数定义与方法名之间应有空格
-	params["HttpMethod"] = "GET"
+	endpoint.HttpMethod = "GET"
+	endpoint.ApiFields = ApiFields{BindQuery: true}
+	params["Endpoints"] = []ApiEndpoint{endpoint}
 	buf.Reset()
 	if err := controllerTpl.ExecuteTemplate(&buf, "controller.go.tpl", params); err != nil {
 		t.Fatalf("render api controller template (GET): %v", err)
@@ -355,6 +399,8 @@ func TestParseDatabaseDSN(t *testing.T) {
 			&DatabaseConfig{Type: DBTypeMySQL, ConnStr: "root:pwd@tcp(127.0.0.1:3306)/demo?charset=utf8mb4"}},
 		{"postgres", "postgresql://host=127.0.0.1 user=root dbname=demo", false,
 			&DatabaseConfig{Type: DBTypePostgres, ConnStr: "postgresql://host=127.0.0.1 user=root dbname=demo"}},
+		{"sqlite", "sqlite://scripts/demo.db", false,
+			&DatabaseConfig{Type: DBTypeSQLite, ConnStr: "scripts/demo.db"}},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
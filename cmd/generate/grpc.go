@@ -0,0 +1,283 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/morehao/golib/gutil"
+)
+
+// grpc 模板文件名
+const (
+	grpcTplProto   = "proto.tpl"
+	grpcTplServer  = "server.go.tpl"
+	grpcTplContext = "context.go.tpl"
+
+	// grpcContextFilename gRPC 服务端包内共享的 *gin.Context 构造函数所在文件，同一包只生成一次
+	grpcContextFilename = "context.go"
+)
+
+// GrpcField proto 消息字段，用于生成 .proto 与 gRPC 服务端中 dto 与 pb 消息的转换
+type GrpcField struct {
+	FieldName string // dto/object 结构体字段名，如 CategoryID
+	FieldType string // dto/object 字段的 Go 类型，切片字段为元素类型
+	ProtoName string // proto 字段名，snake_case，如 category_id
+	ProtoType string // proto 标量类型，如 uint64
+	GoName    string // protoc-gen-go 生成的字段名，如 CategoryId
+	PbGoType  string // pb 字段的 Go 类型，切片字段为元素类型
+	Cast      bool   // dto 与 pb 字段的 Go 类型不同，赋值时需要类型转换
+	Repeated  bool   // 是否为 repeated 字段
+	Optional  bool   // 是否为 proto3 optional 字段，对应 dto 中的指针字段
+	Number    int    // 字段编号
+	Comment   string // 字段说明
+}
+
+// GrpcExtraParams grpc 模板参数
+type GrpcExtraParams struct {
+	AppInfo
+	PackageName          string
+	Description          string
+	StructName           string
+	StructNameLowerCamel string
+	ProtoPackage         string      // proto 包名，如 demoapp.article
+	PK                   GrpcField   // 主键，proto 中统一命名为 id
+	BaseFields           []GrpcField // <Struct>BaseInfo 消息字段，与 object 层 BaseInfo 一致
+	PageListFields       []GrpcField // <Struct>PageListReq 中分页参数之后的筛选、关键字与排序字段，与 dto 一致
+	HasUniqueIndex       bool        // 存在唯一索引时 service 会返回 <Struct>AlreadyExistError，映射为 AlreadyExists
+}
+
+// grpcScalarTypeMap Go 类型对应的 proto 标量类型与 pb 字段的 Go 类型
+var grpcScalarTypeMap = map[string][2]string{
+	"int8":            {"int32", "int32"},
+	"int16":           {"int32", "int32"},
+	"int32":           {"int32", "int32"},
+	"int":             {"int64", "int64"},
+	"int64":           {"int64", "int64"},
+	"uint8":           {"uint32", "uint32"},
+	"uint16":          {"uint32", "uint32"},
+	"uint32":          {"uint32", "uint32"},
+	"uint":            {"uint64", "uint64"},
+	"uint64":          {"uint64", "uint64"},
+	"float32":         {"float", "float32"},
+	"float64":         {"double", "float64"},
+	"bool":            {"bool", "bool"},
+	"string":          {"string", "string"},
+	"[]byte":          {"bytes", "[]byte"},
+	"json.RawMessage": {"bytes", "[]byte"},
+}
+
+// newGrpcField 根据 dto 字段的 Go 类型生成 proto 字段，时间字段在 dto 中为 Unix 时间戳（int64），未识别的类型按 string 处理
+func newGrpcField(fieldName, fieldType, protoName, comment string, number int) GrpcField {
+	if fieldType == "time.Time" {
+		fieldType = "int64"
+	}
+	scalar, ok := grpcScalarTypeMap[fieldType]
+	if !ok {
+		scalar = [2]string{"string", "string"}
+	}
+	return GrpcField{
+		FieldName: fieldName,
+		FieldType: fieldType,
+		ProtoName: protoName,
+		ProtoType: scalar[0],
+		GoName:    protoGoName(protoName),
+		PbGoType:  scalar[1],
+		// []byte 与 json.RawMessage 底层类型相同，可直接赋值
+		Cast:    scalar[1] != fieldType && scalar[0] != "bytes",
+		Number:  number,
+		Comment: comment,
+	}
+}
+
+// buildGrpcParams 由表字段与分页列表配置生成 grpc 模板参数，字段与 object 层 BaseInfo、dto 的 PageListReq 保持一致
+func buildGrpcParams(appInfo AppInfo, packageName, description, structName, pkFieldType string,
+	modelFields []ModelField, pageList *PageListParams) GrpcExtraParams {
+	params := GrpcExtraParams{
+		AppInfo:              appInfo,
+		PackageName:          packageName,
+		Description:          description,
+		StructName:           structName,
+		StructNameLowerCamel: gutil.FirstLetterToLower(structName),
+		ProtoPackage:         strings.ReplaceAll(appInfo.AppName, "-", "_") + "." + packageName,
+		PK:                   newGrpcField(structName+"ID", pkFieldType, "id", "主键 ID", 1),
+		HasUniqueIndex:       len(buildUniqueIndexes(modelFields)) > 0,
+	}
+	for _, field := range modelFields {
+		if IsSysField(field.FieldName) {
+			continue
+		}
+		baseField := newGrpcField(field.FieldName, field.FieldType, protoFieldName(field.ColumnName), field.Comment, len(params.BaseFields)+1)
+		baseField.Optional = field.NullableStyle != ""
+		params.BaseFields = append(params.BaseFields, baseField)
+	}
+
+	// page、page_size 占用编号 1、2
+	addPageListField := func(fieldName, fieldType, protoName, comment string, repeated bool) *GrpcField {
+		field := newGrpcField(fieldName, fieldType, protoName, comment, len(params.PageListFields)+3)
+		field.Repeated = repeated
+		params.PageListFields = append(params.PageListFields, field)
+		return &params.PageListFields[len(params.PageListFields)-1]
+	}
+	if pageList != nil {
+		for _, filter := range pageList.Filters {
+			protoName := protoFieldName(filter.ColumnName)
+			switch filter.Op {
+			case PageListOpIn:
+				addPageListField(filter.FieldName+"List", filter.FieldType, protoName+"_list", filter.Comment+"（多值匹配）", true)
+			case PageListOpRange:
+				// 数值区间的边界在 dto 中为指针，0 是合法边界；时间区间仍为 Unix 时间戳
+				optional := filter.FieldType != "time.Time"
+				addPageListField(filter.FieldName+"Start", filter.FieldType, protoName+"_start", filter.Comment+"起始值（含）", false).Optional = optional
+				addPageListField(filter.FieldName+"End", filter.FieldType, protoName+"_end", filter.Comment+"结束值（含）", false).Optional = optional
+			case PageListOpLike:
+				addPageListField(filter.FieldName, filter.FieldType, protoName, filter.Comment+"（模糊匹配）", false)
+			default:
+				field := addPageListField(filter.FieldName, filter.FieldType, protoName, filter.Comment, false)
+				// 可空列的等值筛选在 dto 中为指针，时间筛选仍为 Unix 时间戳
+				field.Optional = filter.NullableStyle != "" && filter.FieldType != "time.Time"
+			}
+		}
+		if pageList.HasKeyword() {
+			addPageListField("Keyword", "string", "keyword", "关键字", false)
+		}
+		if pageList.HasSort() {
+			addPageListField("OrderBy", "string", "order_by", "排序字段，- 前缀表示降序", false)
+		}
+	}
+	return params
+}
+
+// validateGrpcFieldTypes 校验 type_overrides 替换后的列类型能否映射为 proto 标量：
+// 自定义类型（如 decimal.Decimal）与 pb 字段之间没有通用的转换方式，生成前报错
+func validateGrpcFieldTypes(tableName string, columns []ColumnSchema) error {
+	for _, column := range columns {
+		if _, overridden := matchTypeOverride(tableName, column); !overridden {
+			continue
+		}
+		if _, ok := grpcScalarTypeMap[column.FieldType]; !ok && column.FieldType != "time.Time" {
+			return fmt.Errorf("--with-grpc does not support column %s.%s of type %s from type_overrides, only Go types of proto scalars are supported",
+				tableName, column.ColumnName, column.FieldType)
+		}
+	}
+	return nil
+}
+
+// protoFieldName 列名对应的 proto 字段名，驼峰列名转为 snake_case
+func protoFieldName(columnName string) string {
+	if strings.ToLower(columnName) == columnName {
+		return columnName
+	}
+	return strings.ToLower(gutil.CamelToSnakeCase(columnName))
+}
+
+// protoGoName protoc-gen-go 生成的 Go 字段名，与 protogen.GoCamelCase 规则一致：
+// 去掉下划线并将其后的小写字母大写，如 category_id -> CategoryId、address_2 -> Address_2
+func protoGoName(protoName string) string {
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	var b []byte
+	for i := 0; i < len(protoName); i++ {
+		c := protoName[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(protoName) && isLower(protoName[i+1]):
+			// 跳过下划线，下一个字母在 default 分支中大写
+		case isDigit(c):
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(protoName) && isLower(protoName[i+1]); i++ {
+				b = append(b, protoName[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// genGrpcLayers 生成单张表的 .proto 与委托给 <Struct>Svc 的 gRPC 服务端实现，
+// 同一包的 context.go 不存在时一并生成。返回创建或覆盖的文件（绝对路径）
+func genGrpcLayers(params GrpcExtraParams, tableFilename string) ([]string, error) {
+	tplDir, getTplErr := prepareTemplateDir(tplModeGrpc)
+	if getTplErr != nil {
+		return nil, getTplErr
+	}
+	defer os.RemoveAll(tplDir)
+
+	parseTpl := func(name string) (*template.Template, error) {
+		tpl, parseErr := template.New(name).ParseFiles(filepath.Join(tplDir, name))
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse grpc template %s error: %v", name, parseErr)
+		}
+		return tpl, nil
+	}
+
+	// .proto 不是 Go 文件，不经过 codegen 的 gofmt，直接渲染写入
+	protoDir, serverDir := grpcTargetDirs(workDir, params.PackageName)
+	protoFilepath := filepath.Join(protoDir, gutil.TrimFileExtension(tableFilename)+".proto")
+	protoTpl, parseErr := parseTpl(grpcTplProto)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	var protoBuf bytes.Buffer
+	if err := protoTpl.Execute(&protoBuf, params); err != nil {
+		return nil, fmt.Errorf("render proto file error: %v", err)
+	}
+	if err := trackFiles(protoFilepath); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(protoDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create proto directory: %v", err)
+	}
+	if err := os.WriteFile(protoFilepath, protoBuf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("write proto file error: %v", err)
+	}
+	files := []string{protoFilepath}
+
+	genFiles := map[string]string{grpcTplServer: tableFilename}
+	if !gutil.FileExists(filepath.Join(serverDir, grpcContextFilename)) {
+		genFiles[grpcTplContext] = grpcContextFilename
+	}
+	var genParamsList []codegen.GenParamsItem
+	for _, tplName := range []string{grpcTplServer, grpcTplContext} {
+		targetFilename, ok := genFiles[tplName]
+		if !ok {
+			continue
+		}
+		tpl, tplErr := parseTpl(tplName)
+		if tplErr != nil {
+			return nil, tplErr
+		}
+		genParamsList = append(genParamsList, codegen.GenParamsItem{
+			TargetDir:      serverDir,
+			TargetFileName: targetFilename,
+			Template:       tpl,
+			ExtraParams:    params,
+		})
+		files = append(files, filepath.Join(serverDir, targetFilename))
+	}
+	genParams := &codegen.GenParams{ParamsList: genParamsList}
+	if err := trackGenParams(genParams); err != nil {
+		return nil, err
+	}
+	if err := codegen.NewGenerator().Gen(genParams); err != nil {
+		return nil, fmt.Errorf("generate grpc server error: %v", err)
+	}
+	relProtoPath, _ := filepath.Rel(workDir, protoFilepath)
+	fmt.Printf("[Module] Generated gRPC service: %s, compile it in %s with:\n", relProtoPath, workDir)
+	fmt.Printf("  protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative %s\n", filepath.ToSlash(relProtoPath))
+	return files, nil
+}
+
+// grpcTargetDirs .proto 与 gRPC 服务端的目录：proto/pb<package>、internal/grpc/grpc<package>
+func grpcTargetDirs(appDir, packageName string) (string, string) {
+	return filepath.Join(appDir, "proto", "pb"+packageName), filepath.Join(appDir, "internal", "grpc", "grpc"+packageName)
+}
@@ -0,0 +1,208 @@
+package generate
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProtoGoName(t *testing.T) {
+	cases := map[string]string{
+		"id":          "Id",
+		"category_id": "CategoryId",
+		"page_size":   "PageSize",
+		"address_2":   "Address_2",
+		"ipv4_addr":   "Ipv4Addr",
+		"_hidden":     "XHidden",
+	}
+	for protoName, want := range cases {
+		if got := protoGoName(protoName); got != want {
+			t.Errorf("protoGoName(%s) = %s, want %s", protoName, got, want)
+		}
+	}
+}
+
+func TestBuildGrpcParams(t *testing.T) {
+	modelFields := []ModelField{
+		{FieldName: "ID", FieldType: "uint", ColumnName: "id"},
+		{FieldName: "Title", FieldType: "string", ColumnName: "title", Comment: "标题"},
+		{FieldName: "Status", FieldType: "int8", ColumnName: "status"},
+		{FieldName: "PublishedAt", FieldType: "time.Time", ColumnName: "publishedAt"},
+		{FieldName: "Extra", FieldType: "json.RawMessage", ColumnName: "extra"},
+		{FieldName: "CreatedAt", FieldType: "time.Time", ColumnName: "created_at"},
+	}
+	pageList := &PageListParams{
+		Filters: []PageListFilter{
+			{ModelField: modelFields[2], Op: PageListOpIn},
+			{ModelField: modelFields[3], Op: PageListOpRange},
+			{ModelField: modelFields[2], Op: PageListOpRange},
+		},
+		KeywordColumns: []string{"title"},
+	}
+	params := buildGrpcParams(AppInfo{AppName: "demo-app"}, "article", "文章", "Article", "uint", modelFields, pageList)
+	if params.ProtoPackage != "demo_app.article" {
+		t.Errorf("proto package = %s", params.ProtoPackage)
+	}
+	if pk := params.PK; pk.ProtoType != "uint64" || !pk.Cast || pk.FieldName != "ArticleID" || pk.GoName != "Id" {
+		t.Errorf("pk = %+v", pk)
+	}
+
+	var baseFields []string
+	for _, field := range params.BaseFields {
+		baseFields = append(baseFields, field.ProtoType+" "+field.ProtoName+" "+field.GoName)
+	}
+	want := "string title Title,int32 status Status,int64 published_at PublishedAt,bytes extra Extra"
+	if got := strings.Join(baseFields, ","); got != want {
+		t.Errorf("base fields = %s, want %s", got, want)
+	}
+	status, publishedAt, extra := params.BaseFields[1], params.BaseFields[2], params.BaseFields[3]
+	if !status.Cast || status.FieldType != "int8" || status.PbGoType != "int32" {
+		t.Errorf("status = %+v", status)
+	}
+	if publishedAt.Cast || publishedAt.FieldType != "int64" {
+		t.Errorf("time field should be a unix timestamp without cast: %+v", publishedAt)
+	}
+	if extra.Cast {
+		t.Errorf("json.RawMessage should be assigned from []byte directly: %+v", extra)
+	}
+
+	var pageListFields []string
+	for _, field := range params.PageListFields {
+		pageListFields = append(pageListFields, fmt.Sprintf("%s=%d", field.ProtoName, field.Number))
+	}
+	if got := strings.Join(pageListFields, ","); got != "status_list=3,published_at_start=4,published_at_end=5,status_start=6,status_end=7,keyword=8" {
+		t.Errorf("page list fields = %s", got)
+	}
+	// 数值区间的边界为 optional，对应 dto 中的指针；时间区间仍为 Unix 时间戳
+	if publishedAtStart, statusStart := params.PageListFields[1], params.PageListFields[3]; publishedAtStart.Optional || !statusStart.Optional || !statusStart.Cast {
+		t.Errorf("range fields = %+v, %+v", publishedAtStart, statusStart)
+	}
+	if statusList := params.PageListFields[0]; !statusList.Repeated || statusList.FieldName != "StatusList" {
+		t.Errorf("status list = %+v", statusList)
+	}
+}
+
+// TestGenerateModuleWithGrpc --with-grpc 生成 .proto、委托给 service 的 gRPC 服务端与包内共享的 context.go，remove module 一并删除
+func TestGenerateModuleWithGrpc(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "article.sql")
+	ddl := "CREATE TABLE `article` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `title` varchar(128) NOT NULL COMMENT '标题',\n" +
+		"  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态',\n" +
+		"  `score` int NOT NULL DEFAULT 0 COMMENT '分数',\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+		"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_title` (`title`)\n" +
+		") ENGINE=InnoDB COMMENT='文章表';\n"
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: article
+  description: 文章
+  table_name: article
+  page_list:
+    filters:
+      - column: status
+        op: in
+      - column: score
+        op: range
+`)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--with-grpc"); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if !strings.Contains(output, "protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/pbarticle/article.proto") {
+		t.Errorf("protoc hint missing:\n%s", output)
+	}
+
+	appDir := filepath.Join("apps", "demoapp")
+	protoFile := filepath.Join(appDir, "proto", "pbarticle", "article.proto")
+	serverFile := filepath.Join(appDir, "internal", "grpc", "grpcarticle", "article.go")
+	contextFile := filepath.Join(appDir, "internal", "grpc", "grpcarticle", "context.go")
+
+	proto := readFile(t, protoFile)
+	for _, want := range []string{
+		"package demoapp.article;",
+		`option go_package = "github.com/example/demoapp/proto/pbarticle;pbarticle";`,
+		"rpc Delete(ArticleDeleteReq) returns (google.protobuf.Empty);",
+		"string title = 1; // 标题",
+		"int32 status = 2; // 状态",
+		"uint64 id = 1; // 主键 ID",
+		"repeated int32 status_list = 3; // 状态（多值匹配）",
+		"optional int64 score_start = 4; // 分数起始值（含）",
+		"repeated ArticlePageListItem list = 1; // 数据列表",
+	} {
+		if !strings.Contains(proto, want) {
+			t.Errorf("proto file missing %q:\n%s", want, proto)
+		}
+	}
+
+	server := readFile(t, serverFile)
+	for _, want := range []string{
+		"pbarticle.UnimplementedArticleServiceServer",
+		"svc svcarticle.ArticleSvc",
+		"func RegisterArticleServer(registrar grpc.ServiceRegistrar) {",
+		"err := withGinContext(ctx, func(ginCtx *gin.Context) (err error) {\n\t\tresp, err = s.svc.Create(ginCtx, &dtoarticle.ArticleCreateReq{",
+		"return nil, articleStatusError(err)",
+		"case code.ArticleNotExistError:\n\t\treturn status.Error(codes.NotFound, codeErr.Msg)",
+		"case code.ArticleAlreadyExistError:\n\t\treturn status.Error(codes.AlreadyExists, codeErr.Msg)",
+		"return status.Error(codes.Internal, codeErr.Msg)",
+		"ArticleID: uint(req.GetId()),",
+		"Id:        uint64(resp.ArticleID),",
+		"pageListReq.StatusList = append(pageListReq.StatusList, int8(v))",
+		"if req.ScoreStart != nil {\n\t\tv := int(*req.ScoreStart)\n\t\tpageListReq.ScoreStart = &v\n\t}",
+		"Status: int8(info.GetStatus()),",
+		"Status: int32(info.Status),",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q:\n%s", want, server)
+		}
+	}
+	context := readFile(t, contextFile)
+	for _, want := range []string{
+		"ginEngine.ServeHTTP(&discardResponseWriter{header: make(http.Header)}",
+		"func withGinContext(ctx context.Context, fn func(ginCtx *gin.Context) error) error {",
+	} {
+		if !strings.Contains(context, want) {
+			t.Errorf("context file missing %q:\n%s", want, context)
+		}
+	}
+	if strings.Contains(context, "CreateTestContext") {
+		t.Errorf("context file should not use gin test helpers:\n%s", context)
+	}
+	for _, file := range []string{serverFile, contextFile} {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated file %s is not valid Go: %v", file, err)
+		}
+	}
+
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "remove", "module", "--app", "demoapp", "--yes"); err != nil {
+			t.Errorf("Failed to execute remove command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Removed successfully") {
+		t.Fatalf("remove did not complete successfully:\n%s", output)
+	}
+	for _, dir := range []string{filepath.Dir(protoFile), filepath.Dir(serverFile)} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", dir)
+		}
+	}
+}
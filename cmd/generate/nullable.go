@@ -0,0 +1,197 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/morehao/golib/gutil"
+)
+
+// 可空列的 Go 类型映射方式
+const (
+	NullableStyleValue   = "value"   // 值类型（默认），NULL 与零值无法区分
+	NullableStylePointer = "pointer" // 指针类型，如 *string
+	NullableStyleSQLNull = "sqlnull" // database/sql 的 Null 类型，如 sql.NullString
+)
+
+// nullable 模板文件名与生成的文件名，service 包内共享，同一包只生成一次
+const (
+	nullableTplHelper      = "nullable.go.tpl"
+	nullableHelperFilename = "nullable.go"
+)
+
+// sqlNullTypeMap 字段类型对应的 sql.Null 类型及其值字段，未列出的类型与枚举使用泛型 sql.Null[T]
+var sqlNullTypeMap = map[string][2]string{
+	"string":    {"sql.NullString", "String"},
+	"int64":     {"sql.NullInt64", "Int64"},
+	"int32":     {"sql.NullInt32", "Int32"},
+	"int16":     {"sql.NullInt16", "Int16"},
+	"uint8":     {"sql.NullByte", "Byte"},
+	"float64":   {"sql.NullFloat64", "Float64"},
+	"bool":      {"sql.NullBool", "Bool"},
+	"time.Time": {"sql.NullTime", "Time"},
+}
+
+// currentNullableStyle 配置的可空列映射方式，未配置时为 value
+func currentNullableStyle() string {
+	if cfg == nil || cfg.NullableStyle == "" {
+		return NullableStyleValue
+	}
+	return cfg.NullableStyle
+}
+
+// validateNullableStyle 校验 nullable_style 配置
+func validateNullableStyle(style string) error {
+	switch style {
+	case "", NullableStyleValue, NullableStylePointer, NullableStyleSQLNull:
+		return nil
+	}
+	return fmt.Errorf("invalid nullable_style %s, expected pointer, sqlnull or value", style)
+}
+
+// fieldNullableStyle 列在 model 中的可空映射方式。非空列、主键、系统字段（由 gorm 或 service 使用其值类型）、
+// 本身可为 nil 的切片类型（如 json.RawMessage）与使用 gorm 序列化器的列保持值类型，返回空
+func fieldNullableStyle(column ColumnSchema, fieldName string) string {
+	style := currentNullableStyle()
+	if style == NullableStyleValue || !column.IsNullable || column.IsPrimaryKey || IsSysField(fieldName) || column.Serializer != "" {
+		return ""
+	}
+	if strings.HasPrefix(column.FieldType, "[]") || column.FieldType == "json.RawMessage" {
+		return ""
+	}
+	return style
+}
+
+// modelFieldType model 字段的 Go 类型与 sqlnull 方式下值字段名，baseType 为字段类型或枚举类型名
+func modelFieldType(style, fieldType, baseType string) (string, string) {
+	switch style {
+	case NullableStylePointer:
+		return "*" + baseType, ""
+	case NullableStyleSQLNull:
+		if nullType, ok := sqlNullTypeMap[fieldType]; ok && baseType == fieldType {
+			return nullType[0], nullType[1]
+		}
+		return "sql.Null[" + baseType + "]", "V"
+	}
+	return baseType, ""
+}
+
+// NullableToModel object 层可空字段指针（如 req.Title）转换为 model 字段值的表达式，
+// modelPkg 为 model 层包名，用于引用枚举类型
+func NullableToModel(field ModelField, modelPkg, src string) string {
+	enumType := ""
+	if field.EnumTypeName != "" {
+		enumType = modelPkg + "." + field.EnumTypeName
+	}
+	switch field.NullableStyle {
+	case NullableStylePointer:
+		switch {
+		case field.FieldType == "time.Time":
+			return fmt.Sprintf("timePtr(%s)", src)
+		case enumType != "":
+			return fmt.Sprintf("(*%s)(%s)", enumType, src)
+		}
+	case NullableStyleSQLNull:
+		nullType := field.ModelFieldType
+		value := fmt.Sprintf("valueOf(%s)", src)
+		switch {
+		case field.FieldType == "time.Time":
+			value = fmt.Sprintf("time.Unix(%s, 0)", value)
+		case enumType != "":
+			nullType = "sql.Null[" + enumType + "]"
+			value = fmt.Sprintf("%s(%s)", enumType, value)
+		}
+		return fmt.Sprintf("%s{%s: %s, Valid: %s != nil}", nullType, field.NullValueField, value, src)
+	}
+	return src
+}
+
+// NullableModelPtr model 可空字段值（如 entity.Title）对应的指针表达式，枚举字段为枚举类型指针，
+// 用于按唯一索引查询（nil 对应 NULL）与转换为 object 层字段
+func NullableModelPtr(field ModelField, src string) string {
+	if field.NullableStyle == NullableStyleSQLNull {
+		return fmt.Sprintf("nullPtr(%s.%s, %s.Valid)", src, field.NullValueField, src)
+	}
+	return src
+}
+
+// NullableToObject model 可空字段值转换为 object 层字段指针的表达式，时间字段转为 Unix 时间戳
+func NullableToObject(field ModelField, src string) string {
+	ptr := NullableModelPtr(field, src)
+	switch {
+	case field.FieldType == "time.Time":
+		return fmt.Sprintf("unixPtr(%s)", ptr)
+	case field.EnumTypeName != "":
+		return fmt.Sprintf("(*%s)(%s)", field.FieldType, ptr)
+	}
+	return ptr
+}
+
+// hasNullableBaseField 是否存在 object 层使用指针的可空字段，service 需要 nullable.go 中的转换函数
+func hasNullableBaseField(fields []ModelField) bool {
+	for _, field := range fields {
+		if field.NullableStyle != "" && !IsSysField(field.FieldName) {
+			return true
+		}
+	}
+	return false
+}
+
+// calcServiceImports service 层引用的字段类型导入：值类型与 sqlnull 方式的时间字段使用 time.Unix，
+// sqlnull 方式的字段构造 sql.Null 类型，时间筛选条件使用 time.Unix；系统字段不由 service 赋值
+func calcServiceImports(fields []ModelField, pageList *PageListParams) []string {
+	var imports []string
+	for _, field := range fields {
+		if IsSysField(field.FieldName) {
+			continue
+		}
+		if field.FieldType == "time.Time" && field.NullableStyle != NullableStylePointer {
+			imports = appendImport(imports, "time")
+		}
+		if field.NullableStyle == NullableStyleSQLNull {
+			imports = appendImport(imports, "database/sql")
+		}
+	}
+	if pageList.NeedTimeImport() {
+		imports = appendImport(imports, "time")
+	}
+	return imports
+}
+
+// genNullableHelper service 包内不存在 nullable.go 时生成可空字段的转换函数，返回生成的文件（绝对路径），已存在时返回空
+func genNullableHelper(serviceDir, packageName string) ([]string, error) {
+	if gutil.FileExists(filepath.Join(serviceDir, nullableHelperFilename)) {
+		return nil, nil
+	}
+	tplDir, getTplErr := prepareTemplateDir(tplModeNullable)
+	if getTplErr != nil {
+		return nil, getTplErr
+	}
+	defer os.RemoveAll(tplDir)
+
+	tpl, parseErr := template.New(nullableTplHelper).ParseFiles(filepath.Join(tplDir, nullableTplHelper))
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse nullable template error: %v", parseErr)
+	}
+	genParams := &codegen.GenParams{
+		ParamsList: []codegen.GenParamsItem{
+			{
+				TargetDir:      serviceDir,
+				TargetFileName: nullableHelperFilename,
+				Template:       tpl,
+				ExtraParams:    ModuleExtraParams{PackageName: packageName},
+			},
+		},
+	}
+	if err := trackGenParams(genParams); err != nil {
+		return nil, err
+	}
+	if err := codegen.NewGenerator().Gen(genParams); err != nil {
+		return nil, fmt.Errorf("generate nullable helper error: %v", err)
+	}
+	return []string{filepath.Join(serviceDir, nullableHelperFilename)}, nil
+}
@@ -0,0 +1,222 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestNullableExprs service 中 object 与 model 可空字段之间的转换表达式
+func TestNullableExprs(t *testing.T) {
+	titlePtr := ModelField{FieldName: "Title", FieldType: "string", NullableStyle: NullableStylePointer, ModelFieldType: "*string"}
+	titleNull := ModelField{FieldName: "Title", FieldType: "string", NullableStyle: NullableStyleSQLNull, ModelFieldType: "sql.NullString", NullValueField: "String"}
+	publishedPtr := ModelField{FieldName: "PublishedAt", FieldType: "time.Time", NullableStyle: NullableStylePointer, ModelFieldType: "*time.Time"}
+	publishedNull := ModelField{FieldName: "PublishedAt", FieldType: "time.Time", NullableStyle: NullableStyleSQLNull, ModelFieldType: "sql.NullTime", NullValueField: "Time"}
+	statusPtr := ModelField{FieldName: "Status", FieldType: "int8", EnumTypeName: "ArticleStatus", NullableStyle: NullableStylePointer, ModelFieldType: "*ArticleStatus"}
+	statusNull := ModelField{FieldName: "Status", FieldType: "int8", EnumTypeName: "ArticleStatus", NullableStyle: NullableStyleSQLNull, ModelFieldType: "sql.Null[ArticleStatus]", NullValueField: "V"}
+
+	tests := []struct {
+		name     string
+		field    ModelField
+		toModel  string
+		toObject string
+	}{
+		{"pointer", titlePtr, "req.Title", "v.Title"},
+		{"sqlnull", titleNull, "sql.NullString{String: valueOf(req.Title), Valid: req.Title != nil}", "nullPtr(v.Title.String, v.Title.Valid)"},
+		{"pointer time", publishedPtr, "timePtr(req.PublishedAt)", "unixPtr(v.PublishedAt)"},
+		{"sqlnull time", publishedNull, "sql.NullTime{Time: time.Unix(valueOf(req.PublishedAt), 0), Valid: req.PublishedAt != nil}", "unixPtr(nullPtr(v.PublishedAt.Time, v.PublishedAt.Valid))"},
+		{"pointer enum", statusPtr, "(*model.ArticleStatus)(req.Status)", "(*int8)(v.Status)"},
+		{"sqlnull enum", statusNull, "sql.Null[model.ArticleStatus]{V: model.ArticleStatus(valueOf(req.Status)), Valid: req.Status != nil}", "(*int8)(nullPtr(v.Status.V, v.Status.Valid))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NullableToModel(tt.field, "model", "req."+tt.field.FieldName); got != tt.toModel {
+				t.Errorf("NullableToModel() = %s, want %s", got, tt.toModel)
+			}
+			if got := NullableToObject(tt.field, "v."+tt.field.FieldName); got != tt.toObject {
+				t.Errorf("NullableToObject() = %s, want %s", got, tt.toObject)
+			}
+		})
+	}
+}
+
+// TestGenerateModuleNullableStyle nullable_style 为 pointer、sqlnull 时可空列在各层的类型与转换，remove module 一并删除 nullable.go
+func TestGenerateModuleNullableStyle(t *testing.T) {
+	ddl := "CREATE TABLE `article` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `title` varchar(128) NOT NULL COMMENT '标题',\n" +
+		"  `summary` varchar(255) DEFAULT NULL COMMENT '摘要',\n" +
+		"  `slug` varchar(64) DEFAULT NULL COMMENT '别名',\n" +
+		"  `category_id` bigint unsigned DEFAULT NULL COMMENT '分类ID',\n" +
+		"  `status` tinyint DEFAULT NULL COMMENT '状态: 1-草稿,2-已发布',\n" +
+		"  `published_at` datetime DEFAULT NULL COMMENT '发布时间',\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+		"  `deleted_by` bigint unsigned DEFAULT NULL COMMENT '删除人',\n" +
+		"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_slug` (`slug`)\n" +
+		") ENGINE=InnoDB COMMENT='文章表';\n"
+
+	tests := []struct {
+		style       string
+		modelWants  []string
+		svcWants    []string
+		protoWants  []string
+		serverWants []string
+	}{
+		{
+			style: NullableStylePointer,
+			modelWants: []string{
+				"Summary *string `gorm:\"column:summary;type:varchar(255);default '';comment:摘要\"`",
+				"CategoryID *uint",
+				"Status *ArticleStatus",
+				"PublishedAt *time.Time",
+				"DeletedBy uint",
+			},
+			svcWants: []string{
+				"Summary: req.Summary,",
+				"Status: (*model.ArticleStatus)(req.Status),",
+				"PublishedAt: timePtr(req.PublishedAt),",
+				"GetBySlug(ctx, insertEntity.Slug)",
+				"GetBySlug(ctx, updateEntity.Slug)",
+				`"summary": updateEntity.Summary,`,
+				"PublishedAt: unixPtr(articleEntity.PublishedAt),",
+				"Status: (*int8)(v.Status),",
+			},
+			protoWants: []string{
+				"optional string summary = 2; // 摘要",
+				"optional int32 status = 5; // 状态: 1-草稿,2-已发布",
+				"optional int64 published_at = 6; // 发布时间",
+				"optional uint64 category_id = 3; // 分类ID",
+			},
+			serverWants: []string{
+				"baseInfo.Summary = info.Summary",
+				"v := int8(*info.Status)",
+				"v := int32(*info.Status)",
+				"v := uint(*req.CategoryId)",
+				"baseInfo.PublishedAt = info.PublishedAt",
+			},
+		},
+		{
+			style: NullableStyleSQLNull,
+			modelWants: []string{
+				"Summary sql.NullString `gorm:\"column:summary;type:varchar(255);default '';comment:摘要\"`",
+				"CategoryID sql.Null[uint]",
+				"Status sql.Null[ArticleStatus]",
+				"PublishedAt sql.NullTime",
+				"DeletedBy uint",
+			},
+			svcWants: []string{
+				"Summary: sql.NullString{String: valueOf(req.Summary), Valid: req.Summary != nil},",
+				"PublishedAt: sql.NullTime{Time: time.Unix(valueOf(req.PublishedAt), 0), Valid: req.PublishedAt != nil},",
+				"GetBySlug(ctx, nullPtr(insertEntity.Slug.String, insertEntity.Slug.Valid))",
+				"GetBySlug(ctx, nullPtr(updateEntity.Slug.String, updateEntity.Slug.Valid))",
+				"Summary: nullPtr(v.Summary.String, v.Summary.Valid),",
+				`"database/sql"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			resetGenerateState()
+			ddlFile := filepath.Join(t.TempDir(), "article.sql")
+			if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+				t.Fatal(err)
+			}
+			restore := chdirToExample(t)
+			defer restore()
+			writeCodeGenConfig(t, `
+service_name: mysql
+nullable_style: `+tt.style+`
+module:
+  package_name: article
+  description: 文章
+  table_name: article
+  page_list:
+    filters:
+      - column: category_id
+      - column: published_at
+`)
+			args := []string{"module", "--app", "demoapp", "--ddl", ddlFile}
+			if tt.protoWants != nil {
+				args = append(args, "--with-grpc")
+			}
+			output := captureStdout(t, func() {
+				if _, err := ExecuteCommand(Cmd, args...); err != nil {
+					t.Errorf("Failed to execute module command: %v", err)
+				}
+			})
+			assertGenerateSuccess(t, output)
+
+			appDir := filepath.Join("apps", "demoapp")
+			files := map[string][]string{
+				filepath.Join(appDir, "model", "article.go"):                             tt.modelWants,
+				filepath.Join(appDir, "internal", "service", "svcarticle", "article.go"): tt.svcWants,
+				filepath.Join(appDir, "object", "objarticle", "article.go"): {
+					"Summary *string `json:\"summary\" form:\"summary\" binding:\"omitempty,max=255\"`",
+					"PublishedAt *int64",
+					"Title string",
+				},
+				filepath.Join(appDir, "dao", "article.go"): {
+					"CategoryID *uint",
+					"if c.CategoryID != nil {",
+					`db.Where(tableName+".category_id = ?", *c.CategoryID)`,
+					"func (d *ArticleDao) GetBySlug(ctx context.Context, slug *string)",
+				},
+				filepath.Join(appDir, "internal", "dto", "dtoarticle", "request.go"): {
+					"CategoryID *uint `json:\"categoryID\" form:\"categoryID\"`",
+					"PublishedAt int64",
+				},
+				filepath.Join(appDir, "internal", "service", "svcarticle", "nullable.go"): {
+					"func nullPtr[T any](value T, valid bool) *T {",
+					"func timePtr(ts *int64) *time.Time {",
+				},
+			}
+			if tt.protoWants != nil {
+				files[filepath.Join(appDir, "proto", "pbarticle", "article.proto")] = tt.protoWants
+				files[filepath.Join(appDir, "internal", "grpc", "grpcarticle", "article.go")] = tt.serverWants
+			}
+			for file, wants := range files {
+				content := compactSpaces(readFile(t, file))
+				for _, want := range wants {
+					if !strings.Contains(content, want) {
+						t.Errorf("%s missing %q:\n%s", file, want, content)
+					}
+				}
+				if strings.HasSuffix(file, ".go") {
+					if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+						t.Errorf("generated file %s is not valid Go: %v", file, err)
+					}
+				}
+			}
+
+			resetGenerateState()
+			output = captureStdout(t, func() {
+				if _, err := ExecuteCommand(Cmd, "remove", "module", "--app", "demoapp", "--yes"); err != nil {
+					t.Errorf("Failed to execute remove command: %v", err)
+				}
+			})
+			if !strings.Contains(output, "delete  "+filepath.Join(appDir, "internal", "service", "svcarticle", "nullable.go")) {
+				t.Errorf("remove summary missing nullable.go:\n%s", output)
+			}
+			if _, err := os.Stat(filepath.Join(appDir, "internal", "service", "svcarticle")); !os.IsNotExist(err) {
+				t.Error("service package dir should be removed")
+			}
+		})
+	}
+}
+
+func TestValidateNullableStyle(t *testing.T) {
+	for _, style := range []string{"", NullableStyleValue, NullableStylePointer, NullableStyleSQLNull} {
+		if err := validateNullableStyle(style); err != nil {
+			t.Errorf("validateNullableStyle(%q) error: %v", style, err)
+		}
+	}
+	if err := validateNullableStyle("nullable"); err == nil {
+		t.Error("validateNullableStyle should reject unknown style")
+	}
+}
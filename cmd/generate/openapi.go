@@ -0,0 +1,532 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	openAPIVersion         = "3.1.0"
+	dtoRenderPkgPath       = "github.com/morehao/golib/biz/gcontext/gincontext"
+	dtoRenderTypeName      = "DtoRender"
+	openAPISchemaRefPrefix = "#/components/schemas/"
+)
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate an OpenAPI 3.1 document from router registrations and dto structs",
+	Long: `Walk internal/router/*.go registrations, resolve the controller handlers and their dto request/response
+structs (json/uri/form/binding tags) with go/types, and write an OpenAPI 3.1 document. Responses are wrapped
+in the gincontext.DtoRender envelope. The format follows the output file extension (.json or .yaml).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, _ := cmd.Flags().GetString("app")
+		outputPath, _ := cmd.Flags().GetString("output")
+		if appName == "" {
+			fmt.Println("Please provide an app name using --app flag")
+			return
+		}
+		currentDir, _ := os.Getwd()
+		appDir, resolveErr := resolveAppDir(currentDir, appName)
+		if resolveErr != nil {
+			fmt.Printf("Resolve app directory error: %v\n", resolveErr)
+			return
+		}
+		if outputPath == "" {
+			outputPath = filepath.Join(appDir, "docs", "openapi.yaml")
+		}
+		if err := runInTransaction(func() error { return genOpenAPI(appDir, outputPath) }); err != nil {
+			fmt.Printf("Error generating: %v\n", err)
+			return
+		}
+		fmt.Printf("OpenAPI document written to %s\n", outputPath)
+	},
+}
+
+func init() {
+	openapiCmd.Flags().StringP("app", "a", "", "App name to generate the document for (e.g., demoapp)")
+	openapiCmd.Flags().StringP("output", "o", "", "Output file, .json for JSON and others for YAML (default apps/<app>/docs/openapi.yaml)")
+	Cmd.AddCommand(openapiCmd)
+}
+
+// openAPIDocument OpenAPI 3.1 文档
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi" yaml:"openapi"`
+	Info       openAPIInfo                             `json:"info" yaml:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths" yaml:"paths"`
+	Components openAPIComponents                       `json:"components" yaml:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas" yaml:"schemas"`
+}
+
+type openAPIOperation struct {
+	Tags        []string                    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string                      `json:"operationId" yaml:"operationId"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses" yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name" yaml:"name"`
+	In          string         `json:"in" yaml:"in"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]*openAPIMediaType `json:"content" yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description" yaml:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// openAPISchema JSON Schema 子集，空值表示任意类型
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string                    `json:"format,omitempty" yaml:"format,omitempty"`
+	Description          string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+	AllOf                []*openAPISchema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+}
+
+// genOpenAPI 扫描应用路由并写出 OpenAPI 文档
+func genOpenAPI(appDir, outputPath string) error {
+	appInfo, appInfoErr := GetAppInfo(appDir)
+	if appInfoErr != nil {
+		return fmt.Errorf("get app info error: %v", appInfoErr)
+	}
+	scanRes, scanErr := scanApiRoutes(appDir, *appInfo)
+	if scanErr != nil {
+		return scanErr
+	}
+	doc := buildOpenAPIDocument(appInfo.AppName, scanRes)
+
+	var content []byte
+	var marshalErr error
+	if strings.EqualFold(filepath.Ext(outputPath), ".json") {
+		content, marshalErr = json.MarshalIndent(doc, "", "  ")
+		content = append(content, '\n')
+	} else {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		marshalErr = encoder.Encode(doc)
+		content = buf.Bytes()
+	}
+	if marshalErr != nil {
+		return fmt.Errorf("marshal openapi document error: %v", marshalErr)
+	}
+	if err := trackFiles(outputPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("create output dir error: %v", err)
+	}
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("write openapi document error: %v", err)
+	}
+	fmt.Printf("[OpenAPI] %d operations, %d schemas\n", len(scanRes.Routes), len(doc.Components.Schemas))
+	return nil
+}
+
+// buildOpenAPIDocument 将扫描到的接口转换为 OpenAPI 文档，响应统一包装为 gincontext.DtoRender{data=...}
+func buildOpenAPIDocument(appName string, scanRes *apiScanResult) *openAPIDocument {
+	builder := newOpenAPISchemaBuilder(scanRes.loader)
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:   appName + " API",
+			Version: "1.0.0",
+		},
+		Paths: make(map[string]map[string]*openAPIOperation),
+	}
+
+	var envelope *openAPISchema
+	if dtoRender := scanRes.loader.wellKnownNamed(dtoRenderPkgPath, dtoRenderTypeName); dtoRender != nil {
+		envelope = builder.schemaOf(dtoRender)
+	}
+
+	for _, route := range scanRes.Routes {
+		path, pathParams := openAPIPath(route.Path)
+		operation := &openAPIOperation{
+			Tags:        route.Tags,
+			Summary:     route.Summary,
+			Description: route.Description,
+			OperationID: route.OperationID,
+			Responses:   make(map[string]*openAPIResponse),
+		}
+
+		requestFields := builder.requestFields(route.Request)
+		declaredPathParams := make(map[string]struct{})
+		for _, field := range requestFields {
+			if field.URIName == "" {
+				continue
+			}
+			declaredPathParams[field.URIName] = struct{}{}
+			operation.Parameters = append(operation.Parameters, &openAPIParameter{
+				Name:        field.URIName,
+				In:          "path",
+				Description: field.Schema.Description,
+				Required:    true,
+				Schema:      field.Schema.withoutDescription(),
+			})
+		}
+		for _, name := range pathParams {
+			if _, ok := declaredPathParams[name]; !ok {
+				operation.Parameters = append(operation.Parameters, &openAPIParameter{
+					Name:     name,
+					In:       "path",
+					Required: true,
+					Schema:   &openAPISchema{Type: "string"},
+				})
+			}
+		}
+		if route.BindQuery {
+			for _, field := range requestFields {
+				if field.URIName != "" || field.QueryName == "" {
+					continue
+				}
+				operation.Parameters = append(operation.Parameters, &openAPIParameter{
+					Name:        field.QueryName,
+					In:          "query",
+					Description: field.Schema.Description,
+					Required:    field.Required,
+					Schema:      field.Schema.withoutDescription(),
+				})
+			}
+		}
+		if route.BindBody && route.Request != nil {
+			operation.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content: map[string]*openAPIMediaType{
+					"application/json": {Schema: builder.schemaOf(route.Request)},
+				},
+			}
+		}
+
+		dataSchema := &openAPISchema{Type: "string"}
+		if route.Response != nil {
+			dataSchema = builder.schemaOf(route.Response)
+		}
+		responseSchema := dataSchema
+		if envelope != nil {
+			responseSchema = &openAPISchema{
+				AllOf: []*openAPISchema{
+					envelope,
+					{Type: "object", Properties: map[string]*openAPISchema{"data": dataSchema}},
+				},
+			}
+		}
+		operation.Responses["200"] = &openAPIResponse{
+			Description: "success",
+			Content: map[string]*openAPIMediaType{
+				"application/json": {Schema: responseSchema},
+			},
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+	doc.Components.Schemas = builder.schemas
+	return doc
+}
+
+var ginPathParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// openAPIPath gin 路由路径转换为 OpenAPI 路径，如 /users/:userID -> /users/{userID}，同时返回路径参数名
+func openAPIPath(ginPath string) (string, []string) {
+	var params []string
+	path := ginPathParamPattern.ReplaceAllStringFunc(ginPath, func(match string) string {
+		params = append(params, match[1:])
+		return "{" + match[1:] + "}"
+	})
+	return path, params
+}
+
+// openAPISchemaBuilder 由 go/types 类型构造 schema，具名结构体输出到 components.schemas 并以 $ref 引用
+type openAPISchemaBuilder struct {
+	loader  *goSourceLoader
+	schemas map[string]*openAPISchema
+	names   map[*types.TypeName]string
+}
+
+func newOpenAPISchemaBuilder(loader *goSourceLoader) *openAPISchemaBuilder {
+	return &openAPISchemaBuilder{
+		loader:  loader,
+		schemas: make(map[string]*openAPISchema),
+		names:   make(map[*types.TypeName]string),
+	}
+}
+
+func (s *openAPISchema) withoutDescription() *openAPISchema {
+	copied := *s
+	copied.Description = ""
+	return &copied
+}
+
+// schemaOf 返回类型对应的 schema
+func (b *openAPISchemaBuilder) schemaOf(t types.Type) *openAPISchema {
+	switch typ := t.(type) {
+	case *types.Pointer:
+		return b.schemaOf(typ.Elem())
+	case *types.Alias:
+		return b.schemaOf(types.Unalias(typ))
+	case *types.Named:
+		obj := typ.Obj()
+		if obj.Pkg() != nil {
+			switch obj.Pkg().Path() + "." + obj.Name() {
+			case "time.Time":
+				return &openAPISchema{Type: "string", Format: "date-time"}
+			case "encoding/json.RawMessage":
+				return &openAPISchema{}
+			}
+		}
+		st, isStruct := typ.Underlying().(*types.Struct)
+		if !isStruct {
+			return b.schemaOf(typ.Underlying())
+		}
+		if name, ok := b.names[obj]; ok {
+			return &openAPISchema{Ref: openAPISchemaRefPrefix + name}
+		}
+		name := b.componentName(obj)
+		b.names[obj] = name
+		b.schemas[name] = &openAPISchema{}
+		*b.schemas[name] = *b.structSchema(st)
+		return &openAPISchema{Ref: openAPISchemaRefPrefix + name}
+	case *types.Basic:
+		return basicSchema(typ)
+	case *types.Slice:
+		if basic, ok := typ.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: b.schemaOf(typ.Elem())}
+	case *types.Array:
+		return &openAPISchema{Type: "array", Items: b.schemaOf(typ.Elem())}
+	case *types.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: b.schemaOf(typ.Elem())}
+	case *types.Struct:
+		return b.structSchema(typ)
+	}
+	return &openAPISchema{}
+}
+
+// componentName 组件名取 <包名>.<类型名>（与 swag 一致），重名时追加序号
+func (b *openAPISchemaBuilder) componentName(obj *types.TypeName) string {
+	name := obj.Name()
+	if obj.Pkg() != nil {
+		name = obj.Pkg().Name() + "." + name
+	}
+	candidate := name
+	for i := 2; ; i++ {
+		if _, taken := b.schemas[candidate]; !taken {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+func basicSchema(basic *types.Basic) *openAPISchema {
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return &openAPISchema{Type: "boolean"}
+	case info&types.IsInteger != 0:
+		schema := &openAPISchema{Type: "integer"}
+		switch basic.Kind() {
+		case types.Int64, types.Uint64:
+			schema.Format = "int64"
+		case types.Int32, types.Uint32:
+			schema.Format = "int32"
+		}
+		return schema
+	case info&types.IsFloat != 0:
+		schema := &openAPISchema{Type: "number"}
+		if basic.Kind() == types.Float32 {
+			schema.Format = "float"
+		} else {
+			schema.Format = "double"
+		}
+		return schema
+	case info&types.IsString != 0:
+		return &openAPISchema{Type: "string"}
+	}
+	return &openAPISchema{}
+}
+
+// structSchema 结构体 schema：按 json 标签命名属性，匿名嵌入的结构体字段展开，binding/validate 标签转换为约束
+func (b *openAPISchemaBuilder) structSchema(st *types.Struct) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for _, field := range b.flattenFields(st) {
+		if field.JSONName == "" {
+			continue
+		}
+		schema.Properties[field.JSONName] = field.Schema
+		if field.Required {
+			schema.Required = append(schema.Required, field.JSONName)
+		}
+	}
+	return schema
+}
+
+// dtoField 展开后的结构体字段
+type dtoField struct {
+	Var       *types.Var
+	JSONName  string // json 属性名，json:"-" 时为空
+	URIName   string // uri 标签，路径参数名
+	QueryName string // form 标签（缺省取 json 属性名），查询参数名
+	Required  bool
+	Schema    *openAPISchema
+}
+
+// requestFields 请求结构体展开后的字段，用于生成 path/query 参数
+func (b *openAPISchemaBuilder) requestFields(t types.Type) []dtoField {
+	if t == nil {
+		return nil
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	return b.flattenFields(st)
+}
+
+func (b *openAPISchemaBuilder) flattenFields(st *types.Struct) []dtoField {
+	var fields []dtoField
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+		jsonName, jsonOpts := tagName(tag.Get("json"))
+		if v.Embedded() && jsonName == "" && jsonOpts != "-" {
+			fieldType := v.Type()
+			if ptr, isPtr := fieldType.(*types.Pointer); isPtr {
+				fieldType = ptr.Elem()
+			}
+			if embedded, isStruct := fieldType.Underlying().(*types.Struct); isStruct {
+				fields = append(fields, b.flattenFields(embedded)...)
+				continue
+			}
+		}
+		if !v.Exported() {
+			continue
+		}
+		field := dtoField{Var: v}
+		if jsonOpts != "-" {
+			field.JSONName = jsonName
+			if field.JSONName == "" {
+				field.JSONName = v.Name()
+			}
+		}
+		field.URIName, _ = tagName(tag.Get("uri"))
+		field.QueryName, _ = tagName(tag.Get("form"))
+		if field.QueryName == "" && tag.Get("form") != "-" {
+			field.QueryName = field.JSONName
+		}
+
+		// OpenAPI 3.1 允许 $ref 与 description 并列
+		field.Schema = b.schemaOf(v.Type())
+		field.Schema.Description = b.loader.fieldDocs[v]
+		validateRules := tag.Get("binding")
+		if validateRules == "" {
+			validateRules = tag.Get("validate")
+		}
+		field.Required = applyValidateRules(field.Schema, validateRules)
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// tagName 拆分结构体标签值为名称与选项，json:"-" 时选项为 "-"
+func tagName(value string) (string, string) {
+	if value == "-" {
+		return "", "-"
+	}
+	name, opts, _ := strings.Cut(value, ",")
+	return name, opts
+}
+
+// applyValidateRules 将 gin binding / validator 规则转换为 schema 约束，返回字段是否必填
+func applyValidateRules(schema *openAPISchema, rules string) bool {
+	var required bool
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "required":
+			required = true
+		case "max", "lte", "min", "gte", "len":
+			value, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			isString := schema.Type == "string"
+			if name == "max" || name == "lte" || name == "len" {
+				if isString {
+					length := int(value)
+					schema.MaxLength = &length
+				} else if schema.Type == "integer" || schema.Type == "number" {
+					schema.Maximum = &value
+				}
+			}
+			if name == "min" || name == "gte" || name == "len" {
+				if isString {
+					length := int(value)
+					schema.MinLength = &length
+				} else if schema.Type == "integer" || schema.Type == "number" {
+					schema.Minimum = &value
+				}
+			}
+		case "oneof":
+			for _, item := range strings.Fields(param) {
+				if schema.Type == "integer" || schema.Type == "number" {
+					if number, err := strconv.ParseFloat(item, 64); err == nil {
+						schema.Enum = append(schema.Enum, number)
+						continue
+					}
+				}
+				schema.Enum = append(schema.Enum, item)
+			}
+		}
+	}
+	return required
+}
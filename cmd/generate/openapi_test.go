@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestGenerateOpenAPI 从示例应用的路由与 dto 生成 OpenAPI 文档
+func TestGenerateOpenAPI(t *testing.T) {
+	resetGenerateState()
+	restore := chdirToExample(t)
+	defer restore()
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "openapi", "--app", "demoapp"); err != nil {
+			t.Errorf("Failed to execute openapi command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "OpenAPI document written to") {
+		t.Fatalf("openapi generation failed:\n%s", output)
+	}
+	content, err := os.ReadFile(filepath.Join("apps", "demoapp", "docs", "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("read openapi.yaml: %v", err)
+	}
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("unmarshal openapi.yaml: %v", err)
+	}
+	if doc.OpenAPI != openAPIVersion {
+		t.Errorf("openapi version = %q", doc.OpenAPI)
+	}
+
+	detail := doc.Paths["/v1/demoapp/users/{userID}"]["get"]
+	if detail == nil {
+		t.Fatalf("detail operation missing, paths: %v", doc.Paths)
+	}
+	if detail.OperationID != "userDetail" || detail.Summary != "用户登录记录详情" {
+		t.Errorf("detail operation = %+v", detail)
+	}
+	if len(detail.Parameters) != 1 || detail.Parameters[0].In != "path" || detail.Parameters[0].Name != "userID" || !detail.Parameters[0].Required {
+		t.Errorf("detail parameters = %+v", detail.Parameters)
+	}
+	respSchema := detail.Responses["200"].Content["application/json"].Schema
+	if len(respSchema.AllOf) != 2 || respSchema.AllOf[0].Ref != "#/components/schemas/gincontext.DtoRender" ||
+		respSchema.AllOf[1].Properties["data"].Ref != "#/components/schemas/dtouser.UserDetailResp" {
+		t.Errorf("detail response schema = %+v", respSchema)
+	}
+
+	create := doc.Paths["/v1/demoapp/users"]["post"]
+	if create == nil || create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/dtouser.UserCreateReq" {
+		t.Fatalf("create request body = %+v", create)
+	}
+	pageList := doc.Paths["/v1/demoapp/users"]["get"]
+	if pageList == nil || len(pageList.Parameters) != 2 || pageList.Parameters[1].Name != "pageSize" || pageList.Parameters[1].Schema.Maximum == nil {
+		t.Fatalf("page list query parameters = %+v", pageList)
+	}
+
+	detailResp := doc.Components.Schemas["dtouser.UserDetailResp"]
+	if detailResp == nil {
+		t.Fatalf("dtouser.UserDetailResp schema missing")
+	}
+	// 嵌入的 objuser.UserBaseInfo 与 gobject.OperatorBaseInfo 字段展开
+	for _, name := range []string{"userID", "name", "companyID", "createdAt", "updatedBy"} {
+		if detailResp.Properties[name] == nil {
+			t.Errorf("UserDetailResp missing property %q", name)
+		}
+	}
+	if len(detailResp.Required) != 1 || detailResp.Required[0] != "userID" {
+		t.Errorf("UserDetailResp required = %v", detailResp.Required)
+	}
+	if updateReq := doc.Components.Schemas["dtouser.UserUpdateReq"]; updateReq == nil || updateReq.Properties["userID"] != nil {
+		t.Errorf("uri-only field should not be in body schema: %+v", updateReq)
+	}
+	if doc.Components.Schemas["gincontext.DtoRender"] == nil {
+		t.Errorf("gincontext.DtoRender schema missing")
+	}
+
+	// .json 后缀输出 JSON
+	jsonPath := filepath.Join(t.TempDir(), "openapi.json")
+	captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "openapi", "--app", "demoapp", "-o", jsonPath); err != nil {
+			t.Errorf("Failed to execute openapi command: %v", err)
+		}
+	})
+	jsonContent, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("read openapi.json: %v", err)
+	}
+	var jsonDoc map[string]any
+	if err := json.Unmarshal(jsonContent, &jsonDoc); err != nil {
+		t.Fatalf("unmarshal openapi.json: %v", err)
+	}
+	if jsonDoc["openapi"] != openAPIVersion {
+		t.Errorf("json openapi version = %v", jsonDoc["openapi"])
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	path, params := openAPIPath("/v1/demoapp/orders/:orderID/items/*itemPath")
+	if path != "/v1/demoapp/orders/{orderID}/items/{itemPath}" {
+		t.Errorf("path = %q", path)
+	}
+	if len(params) != 2 || params[0] != "orderID" || params[1] != "itemPath" {
+		t.Errorf("params = %v", params)
+	}
+}
@@ -0,0 +1,323 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/morehao/golib/gutil"
+)
+
+// 分页列表筛选操作符
+const (
+	PageListOpEq    = "eq"    // 等值匹配
+	PageListOpIn    = "in"    // 多值匹配，请求字段为切片
+	PageListOpLike  = "like"  // 模糊匹配，仅字符串字段
+	PageListOpRange = "range" // 区间匹配，仅时间与数值字段，请求字段为 <Field>Start/<Field>End
+)
+
+// 分页方式
+const (
+	PaginationOffset = "offset" // 按页码分页（默认），PageListReq 内嵌 gobject.PageQuery，响应返回总条数
+	PaginationCursor = "cursor" // 游标分页，按排序列与主键的 keyset 条件翻页，响应返回下一页游标
+)
+
+// like 模板文件名与生成的文件名，dao 包内共享，同一包只生成一次
+const (
+	likeTplHelper      = "like.go.tpl"
+	likeHelperFilename = "like.go"
+)
+
+// PageListParams 分页列表的筛选、排序与关键字搜索，用于生成 PageListReq 字段、Cond 字段与 BuildCondition 条件
+type PageListParams struct {
+	Filters        []PageListFilter // 筛选字段
+	SortFields     []PageListSort   // 允许排序的字段
+	SortOneOf      string           // 排序字段的 binding oneof 取值，如 "createdAt -createdAt"
+	DefaultSort    string           // 默认排序表达式，如 created_at DESC，为空时不指定
+	KeywordColumns []string         // 关键字模糊匹配的列名
+	Cursor         *PageListCursor  // 游标分页参数，按页码分页时为 nil
+}
+
+// PageListCursor 游标分页：按排序列与主键倒序返回，游标为上一页最后一条记录的排序列与主键取值
+type PageListCursor struct {
+	SortField ModelField // 排序列，默认为主键
+	PKField   ModelField // 主键，排序列取值相同时决定先后
+}
+
+// SortByPK 排序列是否即为主键，是则游标只包含主键
+func (c *PageListCursor) SortByPK() bool {
+	return c.SortField.ColumnName == c.PKField.ColumnName
+}
+
+// PageListFilter 筛选字段
+type PageListFilter struct {
+	ModelField
+	Op string // 操作符：eq、in、like、range
+}
+
+// PageListSort 允许排序的字段
+type PageListSort struct {
+	Name       string // 请求中的排序字段名，与 json tag 一致，如 createdAt
+	ColumnName string // 列名
+}
+
+// HasKeyword 是否配置了关键字搜索
+func (p *PageListParams) HasKeyword() bool {
+	return p != nil && len(p.KeywordColumns) > 0
+}
+
+// HasLike 是否配置了 like 筛选或关键字搜索，dao 需要 like.go 中的通配符转义函数
+func (p *PageListParams) HasLike() bool {
+	if p == nil {
+		return false
+	}
+	for _, filter := range p.Filters {
+		if filter.Op == PageListOpLike {
+			return true
+		}
+	}
+	return p.HasKeyword()
+}
+
+// HasSort 是否配置了排序
+func (p *PageListParams) HasSort() bool {
+	return p != nil && (len(p.SortFields) > 0 || p.DefaultSort != "")
+}
+
+// IsCursor 是否使用游标分页
+func (p *PageListParams) IsCursor() bool {
+	return p != nil && p.Cursor != nil
+}
+
+// NeedCursorTimeImport 游标的排序列为时间字段时 dao 中的游标结构体需要引入 "time" 包
+func (p *PageListParams) NeedCursorTimeImport() bool {
+	return p.IsCursor() && p.Cursor.SortField.FieldType == "time.Time"
+}
+
+// NeedTimeImport 区间筛选包含时间字段时 dao 与 service 需要引入 "time" 包
+func (p *PageListParams) NeedTimeImport() bool {
+	if p == nil {
+		return false
+	}
+	for _, filter := range p.Filters {
+		if filter.FieldType == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePageListConfig 单表配置优先，未配置时使用 module/model 下的配置
+func resolvePageListConfig(table TableConfig, defaultCfg PageListConfig) PageListConfig {
+	if table.PageList != nil {
+		return *table.PageList
+	}
+	return defaultCfg
+}
+
+// buildPageListParams 校验分页列表配置并转换为模板参数，未配置任何筛选、排序与关键字时返回 nil
+func buildPageListParams(tableName string, fields []ModelField, pageListCfg PageListConfig) (*PageListParams, error) {
+	if len(pageListCfg.Filters) == 0 && len(pageListCfg.Sort) == 0 && pageListCfg.DefaultSort == "" && len(pageListCfg.Keyword) == 0 &&
+		pageListCfg.Pagination == "" && pageListCfg.CursorColumn == "" {
+		return nil, nil
+	}
+	fieldMap := make(map[string]ModelField, len(fields))
+	for _, field := range fields {
+		fieldMap[field.ColumnName] = field
+	}
+	getField := func(column, usage string) (ModelField, error) {
+		field, ok := fieldMap[column]
+		if !ok {
+			return ModelField{}, fmt.Errorf("page_list %s column %s not found in table %s", usage, column, tableName)
+		}
+		return field, nil
+	}
+
+	params := &PageListParams{}
+	filterSet := make(map[string]struct{}, len(pageListCfg.Filters))
+	for _, filterCfg := range pageListCfg.Filters {
+		field, err := getField(filterCfg.Column, "filter")
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := filterSet[filterCfg.Column]; exists {
+			return nil, fmt.Errorf("page_list filter column %s is declared more than once", filterCfg.Column)
+		}
+		filterSet[filterCfg.Column] = struct{}{}
+		op := filterCfg.Op
+		if op == "" {
+			op = PageListOpEq
+		}
+		isTime := field.FieldType == "time.Time"
+		isNumber := IsNumID(field.FieldType) || IsIntID(field.FieldType) || field.FieldType == "float32" || field.FieldType == "float64"
+		switch op {
+		case PageListOpEq:
+		case PageListOpIn:
+			if isTime {
+				return nil, fmt.Errorf("page_list filter %s: op in does not support time column", filterCfg.Column)
+			}
+		case PageListOpLike:
+			if field.FieldType != "string" {
+				return nil, fmt.Errorf("page_list filter %s: op like only supports string column", filterCfg.Column)
+			}
+		case PageListOpRange:
+			if !isTime && !isNumber {
+				return nil, fmt.Errorf("page_list filter %s: op range only supports time and numeric column", filterCfg.Column)
+			}
+		default:
+			return nil, fmt.Errorf("page_list filter %s: invalid op %q, supported: eq, in, like, range", filterCfg.Column, op)
+		}
+		if field.FieldType != "string" && !isTime && !isNumber {
+			return nil, fmt.Errorf("page_list filter %s: unsupported field type %s", filterCfg.Column, field.FieldType)
+		}
+		if field.SoftDelete != "" {
+			return nil, fmt.Errorf("page_list filter %s: soft delete column cannot be filtered", filterCfg.Column)
+		}
+		params.Filters = append(params.Filters, PageListFilter{ModelField: field, Op: op})
+	}
+
+	var sortValues []string
+	for _, column := range pageListCfg.Sort {
+		field, err := getField(column, "sort")
+		if err != nil {
+			return nil, err
+		}
+		params.SortFields = append(params.SortFields, PageListSort{Name: field.JsonTagName, ColumnName: field.ColumnName})
+		sortValues = append(sortValues, field.JsonTagName, "-"+field.JsonTagName)
+	}
+	params.SortOneOf = strings.Join(sortValues, " ")
+	if pageListCfg.DefaultSort != "" {
+		column := strings.TrimPrefix(pageListCfg.DefaultSort, "-")
+		if _, err := getField(column, "default_sort"); err != nil {
+			return nil, err
+		}
+		params.DefaultSort = column
+		if strings.HasPrefix(pageListCfg.DefaultSort, "-") {
+			params.DefaultSort += " DESC"
+		}
+	}
+
+	for _, column := range pageListCfg.Keyword {
+		field, err := getField(column, "keyword")
+		if err != nil {
+			return nil, err
+		}
+		if field.FieldType != "string" {
+			return nil, fmt.Errorf("page_list keyword column %s must be a string column", column)
+		}
+		params.KeywordColumns = append(params.KeywordColumns, column)
+	}
+
+	cursor, err := buildPageListCursor(tableName, fields, pageListCfg)
+	if err != nil {
+		return nil, err
+	}
+	params.Cursor = cursor
+
+	// 生成的 Keyword、OrderBy、Cursor、PageSize 字段不能与表字段同名
+	for _, field := range fields {
+		if field.FieldName == "Keyword" && len(params.KeywordColumns) > 0 {
+			return nil, fmt.Errorf("page_list keyword conflicts with column %s of table %s", field.ColumnName, tableName)
+		}
+		if field.FieldName == "OrderBy" && len(params.SortFields) > 0 {
+			return nil, fmt.Errorf("page_list sort conflicts with column %s of table %s", field.ColumnName, tableName)
+		}
+		if (field.FieldName == "Cursor" || field.FieldName == "PageSize") && params.Cursor != nil {
+			return nil, fmt.Errorf("page_list cursor pagination conflicts with column %s of table %s", field.ColumnName, tableName)
+		}
+	}
+	return params, nil
+}
+
+// buildPageListCursor 校验游标分页配置：排序固定为排序列与主键倒序，不能再配置 sort、default_sort；
+// 排序列须有索引且非空，表须有单列主键。按页码分页时返回 nil
+func buildPageListCursor(tableName string, fields []ModelField, pageListCfg PageListConfig) (*PageListCursor, error) {
+	switch pageListCfg.Pagination {
+	case "", PaginationOffset:
+		if pageListCfg.CursorColumn != "" {
+			return nil, fmt.Errorf("page_list cursor_column requires pagination cursor")
+		}
+		return nil, nil
+	case PaginationCursor:
+	default:
+		return nil, fmt.Errorf("invalid page_list pagination %q, supported: offset, cursor", pageListCfg.Pagination)
+	}
+	if len(pageListCfg.Sort) > 0 || pageListCfg.DefaultSort != "" {
+		return nil, fmt.Errorf("page_list sort and default_sort cannot be used with cursor pagination, rows are ordered by the cursor column")
+	}
+
+	var pkFields []ModelField
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			pkFields = append(pkFields, field)
+		}
+	}
+	if len(pkFields) != 1 {
+		return nil, fmt.Errorf("page_list cursor pagination requires a single-column primary key in table %s", tableName)
+	}
+	cursor := &PageListCursor{SortField: pkFields[0], PKField: pkFields[0]}
+	if pageListCfg.CursorColumn == "" {
+		return cursor, nil
+	}
+
+	var found bool
+	for _, field := range fields {
+		if field.ColumnName == pageListCfg.CursorColumn {
+			cursor.SortField, found = field, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("page_list cursor_column %s not found in table %s", pageListCfg.CursorColumn, tableName)
+	}
+	field := cursor.SortField
+	if !field.IsPrimaryKey && field.IndexName == "" {
+		return nil, fmt.Errorf("page_list cursor_column %s must be indexed", field.ColumnName)
+	}
+	// 可空列的 NULL 无法参与 keyset 比较
+	if field.NullableDesc == "" || field.SoftDelete != "" {
+		return nil, fmt.Errorf("page_list cursor_column %s must be a NOT NULL column", field.ColumnName)
+	}
+	isNumber := IsNumID(field.FieldType) || IsIntID(field.FieldType) || field.FieldType == "float32" || field.FieldType == "float64"
+	if field.FieldType != "string" && field.FieldType != "time.Time" && !isNumber {
+		return nil, fmt.Errorf("page_list cursor_column %s: unsupported field type %s", field.ColumnName, field.FieldType)
+	}
+	return cursor, nil
+}
+
+// genLikeHelper dao 包内不存在 like.go 时生成 LIKE 通配符转义函数，返回生成的文件（绝对路径），已存在时返回空
+func genLikeHelper(daoDir, daoPackageName string) ([]string, error) {
+	if gutil.FileExists(filepath.Join(daoDir, likeHelperFilename)) {
+		return nil, nil
+	}
+	tplDir, getTplErr := prepareTemplateDir(tplModeLike)
+	if getTplErr != nil {
+		return nil, getTplErr
+	}
+	defer os.RemoveAll(tplDir)
+
+	tpl, parseErr := template.New(likeTplHelper).ParseFiles(filepath.Join(tplDir, likeTplHelper))
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse like template error: %v", parseErr)
+	}
+	genParams := &codegen.GenParams{
+		ParamsList: []codegen.GenParamsItem{
+			{
+				TargetDir:      daoDir,
+				TargetFileName: likeHelperFilename,
+				Template:       tpl,
+				ExtraParams:    ModuleExtraParams{DaoPackageName: daoPackageName},
+			},
+		},
+	}
+	if err := trackGenParams(genParams); err != nil {
+		return nil, err
+	}
+	if err := codegen.NewGenerator().Gen(genParams); err != nil {
+		return nil, fmt.Errorf("generate like helper error: %v", err)
+	}
+	return []string{filepath.Join(daoDir, likeHelperFilename)}, nil
+}
@@ -0,0 +1,364 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildPageListParams(t *testing.T) {
+	fields := []ModelField{
+		{FieldName: "ID", FieldType: "uint64", ColumnName: "id", JsonTagName: "id", IsPrimaryKey: true},
+		{FieldName: "Title", FieldType: "string", ColumnName: "title", JsonTagName: "title"},
+		{FieldName: "Status", FieldType: "int8", ColumnName: "status", JsonTagName: "status"},
+		{FieldName: "Enabled", FieldType: "bool", ColumnName: "enabled", JsonTagName: "enabled"},
+		{FieldName: "CreatedAt", FieldType: "time.Time", ColumnName: "created_at", JsonTagName: "createdAt"},
+		{FieldName: "DeletedAt", FieldType: "gorm.DeletedAt", ColumnName: "deleted_at", JsonTagName: "deletedAt"},
+	}
+
+	params, err := buildPageListParams("article", fields, PageListConfig{})
+	if err != nil || params != nil {
+		t.Fatalf("empty config = %+v, %v, want nil", params, err)
+	}
+
+	params, err = buildPageListParams("article", fields, PageListConfig{
+		Filters: []PageListFilterConfig{
+			{Column: "status", Op: "in"},
+			{Column: "title", Op: "like"},
+			{Column: "created_at", Op: "range"},
+			{Column: "id"},
+		},
+		Sort:        []string{"id", "created_at"},
+		DefaultSort: "-created_at",
+		Keyword:     []string{"title"},
+	})
+	if err != nil {
+		t.Fatalf("buildPageListParams error: %v", err)
+	}
+	var ops []string
+	for _, filter := range params.Filters {
+		ops = append(ops, filter.FieldName+":"+filter.Op)
+	}
+	if got := strings.Join(ops, ","); got != "Status:in,Title:like,CreatedAt:range,ID:eq" {
+		t.Errorf("filters = %s", got)
+	}
+	if params.SortOneOf != "id -id createdAt -createdAt" {
+		t.Errorf("SortOneOf = %q", params.SortOneOf)
+	}
+	if params.DefaultSort != "created_at DESC" {
+		t.Errorf("DefaultSort = %q", params.DefaultSort)
+	}
+	if !params.HasKeyword() || !params.HasSort() || !params.NeedTimeImport() {
+		t.Errorf("params flags = %+v", params)
+	}
+
+	invalidConfigs := map[string]PageListConfig{
+		"column not found":  {Filters: []PageListFilterConfig{{Column: "missing"}}},
+		"duplicated filter": {Filters: []PageListFilterConfig{{Column: "status"}, {Column: "status", Op: "in"}}},
+		"like on number":    {Filters: []PageListFilterConfig{{Column: "status", Op: "like"}}},
+		"range on string":   {Filters: []PageListFilterConfig{{Column: "title", Op: "range"}}},
+		"in on time":        {Filters: []PageListFilterConfig{{Column: "created_at", Op: "in"}}},
+		"bool filter":       {Filters: []PageListFilterConfig{{Column: "enabled"}}},
+		"soft delete":       {Filters: []PageListFilterConfig{{Column: "deleted_at"}}},
+		"invalid op":        {Filters: []PageListFilterConfig{{Column: "status", Op: "gt"}}},
+		"keyword number":    {Keyword: []string{"status"}},
+		"sort not found":    {Sort: []string{"missing"}},
+		"default sort":      {DefaultSort: "-missing"},
+	}
+	for name, cfg := range invalidConfigs {
+		if _, err := buildPageListParams("article", fields, cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// TestGenerateModulePageList page_list 配置生成分页列表的筛选字段、排序白名单、关键字搜索与对应的查询条件，
+// 数值区间的边界为指针，LIKE 条件通过 dao 包内的 like.go 转义通配符
+func TestGenerateModulePageList(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "article.sql")
+	ddl := "CREATE TABLE `article` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `title` varchar(128) NOT NULL COMMENT '标题',\n" +
+		"  `summary` varchar(255) NOT NULL DEFAULT '' COMMENT '摘要',\n" +
+		"  `category_id` bigint unsigned NOT NULL COMMENT '分类ID',\n" +
+		"  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态',\n" +
+		"  `score` int NOT NULL DEFAULT 0 COMMENT '评分',\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+		"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB COMMENT='文章表';\n"
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: article
+  description: 文章
+  table_name: article
+  page_list:
+    filters:
+      - column: category_id
+      - column: status
+        op: in
+      - column: title
+        op: like
+      - column: created_at
+        op: range
+      - column: score
+        op: range
+    sort: [id, created_at]
+    default_sort: -created_at
+    keyword: [title, summary]
+`)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	dtoFile := filepath.Join(appDir, "internal", "dto", "dtoarticle", "request.go")
+	daoFile := filepath.Join(appDir, "dao", "article.go")
+	serviceFile := filepath.Join(appDir, "internal", "service", "svcarticle", "article.go")
+
+	request := readFile(t, dtoFile)
+	for _, want := range []string{
+		"CategoryID uint `json:\"categoryID\" form:\"categoryID\"`",
+		"StatusList []int8 `json:\"statusList\" form:\"statusList\"`",
+		"Title string `json:\"title\" form:\"title\"`",
+		"CreatedAtStart int64 `json:\"createdAtStart\" form:\"createdAtStart\"`",
+		"CreatedAtEnd int64 `json:\"createdAtEnd\" form:\"createdAtEnd\"`",
+		"ScoreStart *int `json:\"scoreStart\" form:\"scoreStart\"`",
+		"ScoreEnd *int `json:\"scoreEnd\" form:\"scoreEnd\"`",
+		"Keyword string `json:\"keyword\" form:\"keyword\"`",
+		"OrderBy string `json:\"orderBy\" form:\"orderBy\" binding:\"omitempty,oneof=id -id createdAt -createdAt\"`",
+	} {
+		// gofmt 会对齐结构体字段，比较前压缩连续空白
+		if !strings.Contains(compactSpaces(request), want) {
+			t.Errorf("request file missing %q:\n%s", want, request)
+		}
+	}
+
+	dao := readFile(t, daoFile)
+	for _, want := range []string{
+		`"strings"`,
+		`"time"`,
+		"StatusList     []int8",
+		"TitleLike      string",
+		"CreatedAtStart time.Time",
+		"ScoreStart     *int",
+		"OrderBy        string",
+		`var ArticleSortColumns = map[string]string{`,
+		`"createdAt": "created_at",`,
+		`db.Where(tableName+".status IN ?", c.StatusList)`,
+		`db.Where(tableName+".title LIKE ? ESCAPE '!'", likeContains(c.TitleLike))`,
+		`db.Where(tableName+".created_at >= ?", c.CreatedAtStart)`,
+		"if c.ScoreStart != nil {\n\t\tdb.Where(tableName+\".score >= ?\", *c.ScoreStart)\n\t}",
+		`keyword := likeContains(c.Keyword)`,
+		`db.Where("("+tableName+".title LIKE ? ESCAPE '!' OR "+tableName+".summary LIKE ? ESCAPE '!')", keyword, keyword)`,
+		`db.Order(tableName + ".created_at DESC")`,
+	} {
+		if !strings.Contains(dao, want) {
+			t.Errorf("dao file missing %q:\n%s", want, dao)
+		}
+	}
+
+	service := readFile(t, serviceFile)
+	for _, want := range []string{
+		`"time"`,
+		"CategoryID: req.CategoryID,",
+		"StatusList: req.StatusList,",
+		"TitleLike:  req.Title,",
+		"Keyword:    req.Keyword,",
+		"OrderBy:    req.OrderBy,",
+		"ScoreStart: req.ScoreStart,",
+		"cond.CreatedAtStart = time.Unix(req.CreatedAtStart, 0)",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service file missing %q:\n%s", want, service)
+		}
+	}
+
+	likeFile := filepath.Join(appDir, "dao", "like.go")
+	like := readFile(t, likeFile)
+	for _, want := range []string{
+		`var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")`,
+		"func likeContains(value string) string {",
+	} {
+		if !strings.Contains(like, want) {
+			t.Errorf("like file missing %q:\n%s", want, like)
+		}
+	}
+
+	for _, file := range []string{dtoFile, daoFile, serviceFile, likeFile} {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated file %s is not valid Go: %v", file, err)
+		}
+	}
+}
+
+// TestBuildPageListCursor 游标分页配置校验：排序列须有索引且非空，不能与 sort、default_sort 同时配置
+func TestBuildPageListCursor(t *testing.T) {
+	fields := []ModelField{
+		{FieldName: "ID", FieldType: "uint", ColumnName: "id", JsonTagName: "id", IsPrimaryKey: true, NullableDesc: "not null"},
+		{FieldName: "Title", FieldType: "string", ColumnName: "title", JsonTagName: "title", NullableDesc: "not null"},
+		{FieldName: "LoginTime", FieldType: "time.Time", ColumnName: "login_time", JsonTagName: "loginTime", IndexName: "idx_login_time", NullableDesc: "not null"},
+		{FieldName: "LoginIp", FieldType: "string", ColumnName: "login_ip", JsonTagName: "loginIp", IndexName: "idx_login_ip"},
+		{FieldName: "DeletedAt", FieldType: "time.Time", ColumnName: "deleted_at", JsonTagName: "deletedAt", IndexName: "idx_deleted_at", SoftDelete: SoftDeleteGorm},
+	}
+
+	params, err := buildPageListParams("user_login_log", fields, PageListConfig{Pagination: PaginationCursor})
+	if err != nil {
+		t.Fatalf("buildPageListParams error: %v", err)
+	}
+	if !params.IsCursor() || !params.Cursor.SortByPK() || params.NeedCursorTimeImport() {
+		t.Errorf("default cursor = %+v, want sorted by primary key", params.Cursor)
+	}
+
+	params, err = buildPageListParams("user_login_log", fields, PageListConfig{Pagination: PaginationCursor, CursorColumn: "login_time"})
+	if err != nil {
+		t.Fatalf("buildPageListParams error: %v", err)
+	}
+	if params.Cursor.SortByPK() || params.Cursor.SortField.FieldName != "LoginTime" || !params.NeedCursorTimeImport() {
+		t.Errorf("cursor = %+v, want sorted by login_time", params.Cursor)
+	}
+
+	params, err = buildPageListParams("user_login_log", fields, PageListConfig{Pagination: PaginationOffset})
+	if err != nil || params.IsCursor() {
+		t.Errorf("offset pagination = %+v, %v, want no cursor", params, err)
+	}
+
+	invalidConfigs := map[string]PageListConfig{
+		"invalid pagination":       {Pagination: "keyset"},
+		"cursor column for offset": {CursorColumn: "login_time"},
+		"with sort":                {Pagination: PaginationCursor, Sort: []string{"title"}},
+		"with default sort":        {Pagination: PaginationCursor, DefaultSort: "-id"},
+		"column not found":         {Pagination: PaginationCursor, CursorColumn: "missing"},
+		"column not indexed":       {Pagination: PaginationCursor, CursorColumn: "title"},
+		"nullable column":          {Pagination: PaginationCursor, CursorColumn: "login_ip"},
+		"soft delete column":       {Pagination: PaginationCursor, CursorColumn: "deleted_at"},
+	}
+	for name, cfg := range invalidConfigs {
+		if _, err := buildPageListParams("user_login_log", fields, cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	compositeFields := []ModelField{
+		{FieldName: "UserID", FieldType: "uint", ColumnName: "user_id", IsPrimaryKey: true, NullableDesc: "not null"},
+		{FieldName: "RoleID", FieldType: "uint", ColumnName: "role_id", IsPrimaryKey: true, NullableDesc: "not null"},
+	}
+	if _, err := buildPageListParams("user_role", compositeFields, PageListConfig{Pagination: PaginationCursor}); err == nil ||
+		!strings.Contains(err.Error(), "single-column primary key") {
+		t.Errorf("composite primary key error = %v", err)
+	}
+}
+
+// TestGenerateModuleCursorPagination pagination: cursor 生成游标请求与响应、按 keyset 条件查询的 DAO 方法与返回 NextCursor 的 service
+func TestGenerateModuleCursorPagination(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+  page_list:
+    filters:
+      - column: user_id
+    pagination: cursor
+    cursor_column: login_time
+`)
+	if err := os.MkdirAll(filepath.Join("pkg", "testsetup"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	files := map[string][]string{
+		filepath.Join(appDir, "internal", "dto", "dtologinlog", "request.go"): {
+			"type UserLoginLogPageListReq struct { Cursor string `json:\"cursor\" form:\"cursor\"`",
+			"PageSize int `json:\"pageSize\" form:\"pageSize\" binding:\"required,min=1,max=100\"` // 每页条数，最多 100 条",
+		},
+		filepath.Join(appDir, "internal", "dto", "dtologinlog", "response.go"): {
+			"NextCursor string `json:\"nextCursor\"` // 下一页游标，为空时没有更多数据",
+		},
+		filepath.Join(appDir, "dao", "user_login_log.go"): {
+			`"encoding/base64"`,
+			`"time"`,
+			"Cursor *UserLoginLogCursor",
+			"type UserLoginLogCursor struct { LoginTime time.Time `json:\"loginTime\"` ID uint `json:\"id\"` }",
+			"func ParseUserLoginLogCursor(token string) (*UserLoginLogCursor, error) {",
+			`db.Where("("+tableName+".login_time < ? OR ("+tableName+".login_time = ? AND "+tableName+".id < ?))", c.Cursor.LoginTime, c.Cursor.LoginTime, c.Cursor.ID)`,
+			`db.Order(tableName + ".login_time DESC").Order(tableName + ".id DESC")`,
+			"func (d *UserLoginLogDao) GetCursorListByCond(ctx context.Context, cond *UserLoginLogCond, limit int) (model.UserLoginLogEntityList, error) {",
+		},
+		filepath.Join(appDir, "internal", "service", "svcloginlog", "user_login_log.go"): {
+			"cursor, err := dao.ParseUserLoginLogCursor(req.Cursor)",
+			"dao.NewUserLoginLogDao().GetCursorListByCond(ctx, cond, req.PageSize+1)",
+			"nextCursor = dao.UserLoginLogCursor{ LoginTime: last.LoginTime, ID: last.ID, }.Encode()",
+			"NextCursor: nextCursor,",
+		},
+		filepath.Join(appDir, "internal", "service", "svcloginlog", "user_login_log_test.go"): {
+			"PageSize: 10,",
+		},
+		filepath.Join(appDir, "internal", "controller", "ctrloginlog", "user_login_log_test.go"): {
+			`userLoginLogTestPath+"?pageSize=10"`,
+		},
+	}
+	for file, wants := range files {
+		content := compactSpaces(readFile(t, file))
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated file %s is not valid Go: %v", file, err)
+		}
+	}
+	for _, file := range []string{
+		filepath.Join(appDir, "internal", "dto", "dtologinlog", "request.go"),
+		filepath.Join(appDir, "internal", "dto", "dtologinlog", "response.go"),
+		filepath.Join(appDir, "internal", "service", "svcloginlog", "user_login_log.go"),
+		filepath.Join(appDir, "internal", "service", "svcloginlog", "user_login_log_test.go"),
+	} {
+		content := readFile(t, file)
+		for _, offsetOnly := range []string{"PageQuery", "Total", "GetPageListByCond", "gormdao"} {
+			if strings.Contains(content, offsetOnly) {
+				t.Errorf("%s should not contain offset pagination %s:\n%s", file, offsetOnly, content)
+			}
+		}
+	}
+
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--with-grpc")
+	})
+	if want := "--with-grpc does not support cursor pagination of table user_login_log"; !strings.Contains(output, want) {
+		t.Errorf("output missing %q:\n%s", want, output)
+	}
+}
+
+// compactSpaces 将连续空白压缩为单个空格
+func compactSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
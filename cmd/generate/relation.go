@@ -0,0 +1,342 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+)
+
+// 关联关系识别方式
+const (
+	RelationDetectFK     = "fk"     // 读取外键约束（默认）
+	RelationDetectNaming = "naming" // 外键约束及 <表名>_id 命名约定
+	RelationDetectNone   = "none"   // 不生成关联
+)
+
+// 关联类型，与 gorm 的关联名称一致
+const (
+	RelationKindBelongsTo = "belongsTo"
+	RelationKindHasMany   = "hasMany"
+)
+
+// batchTableNames 本次生成的全部表名，关联表的 model 尚未生成但在本次生成范围内时仍生成关联字段
+var batchTableNames map[string]struct{}
+
+// Relation 表之间的关联关系，用于生成 gorm 关联字段、DAO 预加载方法与 Cond 预加载选项
+type Relation struct {
+	Kind       string // 关联类型：belongsTo、hasMany
+	FieldName  string // 关联字段名，如 User、UserLoginLogList
+	StructName string // 关联表的结构体名，如 User
+	TableName  string // 关联表名
+	ForeignKey string // 外键字段名，如 UserID
+	References string // 被引用的字段名，如 ID
+}
+
+// foreignKey 单列外键：Table.Column 引用 RefTable.RefColumn
+type foreignKey struct {
+	Table      string `gorm:"column:table_name"`
+	Column     string `gorm:"column:column_name"`
+	RefTable   string `gorm:"column:ref_table_name"`
+	RefColumn  string `gorm:"column:ref_column_name"`
+	Constraint string `gorm:"column:constraint_name"` // 约束名，用于排除多列外键
+}
+
+// resolveRelationConfig 单表配置了 relations 时使用单表配置，否则使用 module/model 下的配置
+func resolveRelationConfig(table TableConfig, defaultCfg RelationConfig) RelationConfig {
+	if table.Relations != nil {
+		return *table.Relations
+	}
+	return defaultCfg
+}
+
+// setBatchTables 记录本次生成的表
+func setBatchTables(tables []TableConfig) {
+	batchTableNames = make(map[string]struct{}, len(tables))
+	for _, table := range tables {
+		batchTableNames[table.TableName] = struct{}{}
+	}
+}
+
+// buildRelations 识别表的关联关系：本表外键列生成 belongsTo，其他表引用本表的外键生成 hasMany。
+// 关联表的 model 不存在（且不在本次生成范围内）时跳过该关联，避免生成无法编译的代码。
+func buildRelations(tableName, structName string, columns []ColumnSchema, relCfg RelationConfig, tablePrefix, modelDir string) ([]Relation, error) {
+	switch relCfg.Detect {
+	case "", RelationDetectFK, RelationDetectNaming:
+	case RelationDetectNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid relations.detect %q, supported: fk, naming, none", relCfg.Detect)
+	}
+
+	foreignKeys, loadErr := loadForeignKeys(tableName)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if relCfg.Detect == RelationDetectNaming {
+		namingKeys, namingErr := namingForeignKeys(tableName, columns, tablePrefix, foreignKeys)
+		if namingErr != nil {
+			return nil, namingErr
+		}
+		foreignKeys = append(foreignKeys, namingKeys...)
+	}
+
+	excludeSet := make(map[string]struct{}, len(relCfg.Exclude))
+	for _, name := range relCfg.Exclude {
+		excludeSet[name] = struct{}{}
+	}
+	usedNames := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		usedNames[gutil.ReplaceIdToID(column.FieldName)] = struct{}{}
+	}
+
+	var belongsTo, hasMany []Relation
+	for _, fk := range foreignKeys {
+		// 自关联（如 parent_id）同时生成 belongsTo 与 hasMany
+		if fk.Table == tableName {
+			if relation, ok := newRelation(RelationKindBelongsTo, fk, fk.RefTable, tableName, structName, tablePrefix, modelDir, excludeSet, usedNames); ok {
+				belongsTo = append(belongsTo, relation)
+			}
+		}
+		if fk.RefTable == tableName {
+			if relation, ok := newRelation(RelationKindHasMany, fk, fk.Table, tableName, structName, tablePrefix, modelDir, excludeSet, usedNames); ok {
+				hasMany = append(hasMany, relation)
+			}
+		}
+	}
+	return append(belongsTo, hasMany...), nil
+}
+
+// newRelation 根据外键构造关联，关联字段名依次尝试候选名称，与已有字段重名时顺延：
+//   - belongsTo：外键列去除 _id 后缀（user_id -> User）、关联结构体名、二者拼接
+//   - hasMany：关联结构体名 + List、外键列去除 _id 后缀 + 关联结构体名 + List
+func newRelation(kind string, fk foreignKey, relTable, tableName, structName, tablePrefix, modelDir string, excludeSet, usedNames map[string]struct{}) (Relation, bool) {
+	relStruct := structName
+	if relTable != tableName {
+		relStruct = RemoveTablePrefixFromStructName(gutil.SnakeToPascal(relTable), relTable, tablePrefix)
+	}
+	relation := Relation{
+		Kind:       kind,
+		TableName:  relTable,
+		StructName: relStruct,
+		ForeignKey: fieldNameOfColumn(fk.Column),
+		References: fieldNameOfColumn(fk.RefColumn),
+	}
+	if _, ok := excludeSet[relTable]; ok {
+		return relation, false
+	}
+	if _, ok := excludeSet[fk.Column]; ok && kind == RelationKindBelongsTo {
+		return relation, false
+	}
+
+	fkName := fieldNameOfColumn(strings.TrimSuffix(fk.Column, "_id"))
+	candidates := []string{fkName, relStruct, fkName + relStruct}
+	if kind == RelationKindHasMany {
+		candidates = []string{relStruct + "List", fkName + relStruct + "List"}
+	}
+	for _, name := range candidates {
+		if _, used := usedNames[name]; !used {
+			relation.FieldName = name
+			break
+		}
+	}
+	if relation.FieldName == "" {
+		fmt.Printf("[Relation] Skip %s %s.%s -> %s: no available field name\n", kind, fk.Table, fk.Column, fk.RefTable)
+		return relation, false
+	}
+	if _, ok := excludeSet[relation.FieldName]; ok {
+		return relation, false
+	}
+	if !relationModelExists(tableName, relTable, relStruct, modelDir) {
+		fmt.Printf("[Relation] Skip %s %s: model of table %s not found, generate it first\n", kind, relation.FieldName, relTable)
+		return relation, false
+	}
+	usedNames[relation.FieldName] = struct{}{}
+	return relation, true
+}
+
+// fieldNameOfColumn 列名对应的结构体字段名，与 buildModelFields 一致，如 user_id -> UserID
+func fieldNameOfColumn(columnName string) string {
+	return gutil.ReplaceIdToID(gutil.SnakeToPascal(columnName))
+}
+
+// relationModelExists 判断关联表的 model 是否可用：自关联、在本次生成范围内，或 model 目录的 table.go 已声明其表名常量
+func relationModelExists(tableName, relTable, relStruct, modelDir string) bool {
+	if relTable == tableName {
+		return true
+	}
+	if _, ok := batchTableNames[relTable]; ok {
+		return true
+	}
+	content, err := os.ReadFile(filepath.Join(modelDir, "table.go"))
+	if err != nil {
+		return false
+	}
+	return regexp.MustCompile(`\bTableName` + regexp.QuoteMeta(relStruct) + `\s*=`).Match(content)
+}
+
+// loadForeignKeys 从表结构来源中读取与表相关的单列外键，包括本表的外键与其他表引用本表的外键，
+// 按表名、列名排序
+func loadForeignKeys(tableName string) ([]foreignKey, error) {
+	var foreignKeys []foreignKey
+	if cfg.SchemaSource == SchemaSourceDDL {
+		for _, table := range ddlTables {
+			for _, column := range table.Columns {
+				if column.RefTableName == "" || (table.TableName != tableName && column.RefTableName != tableName) {
+					continue
+				}
+				foreignKeys = append(foreignKeys, foreignKey{
+					Table:     table.TableName,
+					Column:    column.ColumnName,
+					RefTable:  column.RefTableName,
+					RefColumn: column.RefColumnName,
+				})
+			}
+		}
+	} else {
+		if DBClient == nil {
+			return nil, fmt.Errorf("database client is not initialized")
+		}
+		var query string
+		switch ddlDialect(cfg.DatabaseDSN) {
+		case DBTypeMySQL:
+			query = `SELECT TABLE_NAME AS table_name, COLUMN_NAME AS column_name, REFERENCED_TABLE_NAME AS ref_table_name,
+	REFERENCED_COLUMN_NAME AS ref_column_name, CONSTRAINT_NAME AS constraint_name
+FROM information_schema.KEY_COLUMN_USAGE
+WHERE TABLE_SCHEMA = DATABASE() AND REFERENCED_TABLE_NAME IS NOT NULL AND (TABLE_NAME = ? OR REFERENCED_TABLE_NAME = ?)`
+		case DBTypePostgres:
+			query = `SELECT cl.relname AS table_name, a.attname AS column_name, rcl.relname AS ref_table_name,
+	ra.attname AS ref_column_name, con.conname AS constraint_name
+FROM pg_constraint con
+JOIN pg_class cl ON cl.oid = con.conrelid
+JOIN pg_class rcl ON rcl.oid = con.confrelid
+JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = ANY (con.conkey)
+JOIN pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = ANY (con.confkey)
+WHERE con.contype = 'f' AND cl.relnamespace = current_schema()::regnamespace AND (cl.relname = ? OR rcl.relname = ?)`
+		case DBTypeSQLite:
+			query = `SELECT m.name AS table_name, p."from" AS column_name, p."table" AS ref_table_name,
+	COALESCE(p."to", 'id') AS ref_column_name, CAST(p.id AS TEXT) AS constraint_name
+FROM sqlite_master m JOIN pragma_foreign_key_list(m.name) p
+WHERE m.type = 'table' AND (m.name = ? OR p."table" = ?)`
+		default:
+			return nil, nil
+		}
+		if err := DBClient.Raw(query, tableName, tableName).Scan(&foreignKeys).Error; err != nil {
+			return nil, fmt.Errorf("get table %s foreign keys error: %v", tableName, err)
+		}
+		foreignKeys = singleColumnForeignKeys(foreignKeys)
+	}
+	sort.SliceStable(foreignKeys, func(i, j int) bool {
+		if foreignKeys[i].Table != foreignKeys[j].Table {
+			return foreignKeys[i].Table < foreignKeys[j].Table
+		}
+		return foreignKeys[i].Column < foreignKeys[j].Column
+	})
+	return foreignKeys, nil
+}
+
+// singleColumnForeignKeys 排除多列外键，同一表中约束名相同的多行属于同一个多列外键
+func singleColumnForeignKeys(foreignKeys []foreignKey) []foreignKey {
+	counts := make(map[string]int, len(foreignKeys))
+	for _, fk := range foreignKeys {
+		counts[fk.Table+"."+fk.Constraint]++
+	}
+	var res []foreignKey
+	for _, fk := range foreignKeys {
+		if counts[fk.Table+"."+fk.Constraint] == 1 {
+			res = append(res, fk)
+		}
+	}
+	return res
+}
+
+// namingForeignKeys 按 <表名>_id 命名约定识别未声明外键约束的关联，被引用列为 id：
+//   - 本表的 <x>_id 列，x 或 表名前缀+x 为已存在的表时视为 belongsTo
+//   - 其他表的 <本表名>_id 列视为 hasMany，本表名去除表名前缀后再匹配
+func namingForeignKeys(tableName string, columns []ColumnSchema, tablePrefix string, declared []foreignKey) ([]foreignKey, error) {
+	tableNames, listErr := listSchemaTables()
+	if listErr != nil {
+		return nil, fmt.Errorf("list tables error: %v", listErr)
+	}
+	tableSet := make(map[string]struct{}, len(tableNames))
+	for _, name := range tableNames {
+		tableSet[name] = struct{}{}
+	}
+	declaredSet := make(map[string]struct{}, len(declared))
+	for _, fk := range declared {
+		declaredSet[fk.Table+"."+fk.Column] = struct{}{}
+	}
+
+	var res []foreignKey
+	hasID := false
+	for _, column := range columns {
+		if column.ColumnName == "id" {
+			hasID = true
+		}
+		if column.IsPrimaryKey || !strings.HasSuffix(column.ColumnName, "_id") {
+			continue
+		}
+		if _, ok := declaredSet[tableName+"."+column.ColumnName]; ok {
+			continue
+		}
+		base := strings.TrimSuffix(column.ColumnName, "_id")
+		for _, refTable := range []string{base, tablePrefix + base} {
+			if _, ok := tableSet[refTable]; ok {
+				res = append(res, foreignKey{Table: tableName, Column: column.ColumnName, RefTable: refTable, RefColumn: "id"})
+				break
+			}
+		}
+	}
+	if !hasID {
+		return res, nil
+	}
+
+	fkColumn := strings.TrimPrefix(tableName, tablePrefix) + "_id"
+	for _, otherTable := range tableNames {
+		if otherTable == tableName {
+			continue
+		}
+		if _, ok := declaredSet[otherTable+"."+fkColumn]; ok {
+			continue
+		}
+		columnNames, columnErr := schemaColumnNames(otherTable)
+		if columnErr != nil {
+			return nil, columnErr
+		}
+		for _, columnName := range columnNames {
+			if columnName == fkColumn {
+				res = append(res, foreignKey{Table: otherTable, Column: fkColumn, RefTable: tableName, RefColumn: "id"})
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// schemaColumnNames 列出数据源（数据库或 DDL 文件）中表的全部列名
+func schemaColumnNames(tableName string) ([]string, error) {
+	var columnNames []string
+	if cfg.SchemaSource == SchemaSourceDDL {
+		if table, ok := ddlTables[tableName]; ok {
+			for _, column := range table.Columns {
+				columnNames = append(columnNames, column.ColumnName)
+			}
+		}
+		return columnNames, nil
+	}
+	if DBClient == nil {
+		return nil, fmt.Errorf("database client is not initialized")
+	}
+	columnTypes, err := DBClient.Migrator().ColumnTypes(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("get table %s columns error: %v", tableName, err)
+	}
+	for _, columnType := range columnTypes {
+		columnNames = append(columnNames, columnType.Name())
+	}
+	return columnNames, nil
+}
@@ -0,0 +1,219 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDDLForeignKeys(t *testing.T) {
+	ddl := `
+CREATE TABLE category (
+    id BIGSERIAL PRIMARY KEY,
+    parent_id BIGINT REFERENCES category
+);
+CREATE TABLE article (
+    id BIGSERIAL PRIMARY KEY,
+    category_id BIGINT NOT NULL REFERENCES public.category (id) ON DELETE CASCADE,
+    author_id BIGINT NOT NULL,
+    tenant_id BIGINT NOT NULL,
+    tenant_code VARCHAR(32) NOT NULL,
+    CONSTRAINT fk_article_tenant FOREIGN KEY (tenant_id, tenant_code) REFERENCES tenant (id, code)
+);
+CREATE TABLE comment (
+    id BIGSERIAL PRIMARY KEY,
+    article_id BIGINT NOT NULL,
+    FOREIGN KEY (article_id) REFERENCES article (id)
+);
+ALTER TABLE ONLY public.article ADD CONSTRAINT fk_article_author FOREIGN KEY (author_id) REFERENCES users(id);`
+	tables, err := ParseDDL(ddl, DBTypePostgres)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	tests := []struct {
+		table, column, refTable, refColumn string
+	}{
+		{"category", "parent_id", "category", "id"},
+		{"article", "category_id", "category", "id"},
+		{"article", "author_id", "users", "id"},
+		{"article", "tenant_id", "", ""},
+		{"comment", "article_id", "article", "id"},
+	}
+	tableMap := make(map[string]*TableSchema)
+	for _, table := range tables {
+		tableMap[table.TableName] = table
+	}
+	for _, tt := range tests {
+		column := findColumn(t, tableMap[tt.table], tt.column)
+		if column.RefTableName != tt.refTable || column.RefColumnName != tt.refColumn {
+			t.Errorf("%s.%s references %s(%s), want %s(%s)", tt.table, tt.column,
+				column.RefTableName, column.RefColumnName, tt.refTable, tt.refColumn)
+		}
+	}
+	if column := findColumn(t, tableMap["article"], "category_id"); column.ColumnType != "bigint" || column.IsNullable {
+		t.Errorf("category_id column = %+v", column)
+	}
+}
+
+func TestBuildRelations(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	tables, err := ParseDDL(`
+CREATE TABLE iam_user (id BIGINT PRIMARY KEY, name VARCHAR(32));
+CREATE TABLE iam_category (id BIGINT PRIMARY KEY, parent_id BIGINT REFERENCES iam_category (id));
+CREATE TABLE iam_order (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT,
+    buyer_id BIGINT REFERENCES iam_user (id),
+    category_id BIGINT REFERENCES iam_category (id),
+    coupon_id BIGINT
+);
+CREATE TABLE iam_order_item (id BIGINT PRIMARY KEY, order_id BIGINT);`, DBTypeMySQL)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	ddlTables = make(map[string]*TableSchema)
+	for _, table := range tables {
+		ddlTables[table.TableName] = table
+	}
+	cfg = &Config{SchemaSource: SchemaSourceDDL}
+	setBatchTables([]TableConfig{{TableName: "iam_user"}, {TableName: "iam_category"}, {TableName: "iam_order"}, {TableName: "iam_order_item"}})
+
+	relationNames := func(relations []Relation) []string {
+		var names []string
+		for _, relation := range relations {
+			names = append(names, relation.Kind+":"+relation.FieldName+"->"+relation.StructName+"("+relation.ForeignKey+","+relation.References+")")
+		}
+		return names
+	}
+
+	order := ddlTables["iam_order"]
+	relations, err := buildRelations("iam_order", "Order", order.Columns, RelationConfig{}, "iam_", "")
+	if err != nil {
+		t.Fatalf("buildRelations error: %v", err)
+	}
+	want := []string{"belongsTo:Buyer->User(BuyerID,ID)", "belongsTo:Category->Category(CategoryID,ID)"}
+	if got := relationNames(relations); !reflect.DeepEqual(got, want) {
+		t.Errorf("fk relations = %v, want %v", got, want)
+	}
+
+	relations, err = buildRelations("iam_order", "Order", order.Columns, RelationConfig{Detect: RelationDetectNaming, Exclude: []string{"category_id"}}, "iam_", "")
+	if err != nil {
+		t.Fatalf("buildRelations error: %v", err)
+	}
+	want = []string{"belongsTo:Buyer->User(BuyerID,ID)", "belongsTo:User->User(UserID,ID)", "hasMany:OrderItemList->OrderItem(OrderID,ID)"}
+	if got := relationNames(relations); !reflect.DeepEqual(got, want) {
+		t.Errorf("naming relations = %v, want %v", got, want)
+	}
+
+	category := ddlTables["iam_category"]
+	relations, err = buildRelations("iam_category", "Category", category.Columns, RelationConfig{}, "iam_", "")
+	if err != nil {
+		t.Fatalf("buildRelations error: %v", err)
+	}
+	want = []string{"belongsTo:Parent->Category(ParentID,ID)", "hasMany:CategoryList->Category(ParentID,ID)", "hasMany:OrderList->Order(CategoryID,ID)"}
+	if got := relationNames(relations); !reflect.DeepEqual(got, want) {
+		t.Errorf("category relations = %v, want %v", got, want)
+	}
+
+	// 关联表的 model 未生成且不在本次生成范围内时跳过
+	setBatchTables([]TableConfig{{TableName: "iam_category"}})
+	var relationsOut []Relation
+	output := captureStdout(t, func() {
+		relationsOut, err = buildRelations("iam_category", "Category", category.Columns, RelationConfig{}, "iam_", t.TempDir())
+	})
+	if err != nil || len(relationsOut) != 2 || !strings.Contains(output, "Skip hasMany OrderList: model of table iam_order not found") {
+		t.Errorf("relations = %v, err = %v, output = %s", relationNames(relationsOut), err, output)
+	}
+
+	if relations, err = buildRelations("iam_order", "Order", order.Columns, RelationConfig{Detect: RelationDetectNone}, "iam_", ""); err != nil || relations != nil {
+		t.Errorf("detect none should not generate relations: %v, %v", relations, err)
+	}
+	if _, err = buildRelations("iam_order", "Order", order.Columns, RelationConfig{Detect: "unknown"}, "iam_", ""); err == nil {
+		t.Error("invalid detect should fail")
+	}
+}
+
+// TestGenerateModelRelations 外键生成 belongsTo 字段、DAO 预加载方法与 Cond 预加载选项，单表配置可关闭关联
+func TestGenerateModelRelations(t *testing.T) {
+	resetGenerateState()
+	ddlContent, err := os.ReadFile(filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql"))
+	if err != nil {
+		t.Fatalf("read ddl: %v", err)
+	}
+	ddlFile := filepath.Join(t.TempDir(), "relation.sql")
+	ddl := strings.Replace(string(ddlContent), "KEY          `idx_user_id` (`user_id`),",
+		"KEY          `idx_user_id` (`user_id`),\n    CONSTRAINT `fk_login_log_user` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`),", 1)
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+model:
+  tables:
+    - table_name: user_login_log
+      package_name: loginlog
+      description: 登录日志
+`)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "model", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute model command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	model := readFile(t, filepath.Join(appDir, "model", "user_login_log.go"))
+	if want := "User      *UserEntity `gorm:\"foreignKey:UserID;references:ID\"` // 关联 user 表（belongsTo）"; !strings.Contains(model, want) {
+		t.Errorf("model file missing %q:\n%s", want, model)
+	}
+	dao := readFile(t, filepath.Join(appDir, "dao", "user_login_log.go"))
+	for _, want := range []string{
+		`"context"`,
+		`"errors"`,
+		"PreloadUser bool // 预加载 User",
+		"if c.PreloadUser {\n\t\tdb.Preload(\"User\")\n\t}",
+		"func (d *UserLoginLogDao) GetByIDWithUser(ctx context.Context, id uint) (*model.UserLoginLogEntity, error) {",
+		`err := dbclient.MysqlDB(ctx).Preload("User").Where("id = ?", id).Take(&entity).Error`,
+	} {
+		if !strings.Contains(dao, want) {
+			t.Errorf("dao file missing %q:\n%s", want, dao)
+		}
+	}
+
+	// 单表关闭关联后重新生成
+	resetGenerateState()
+	writeCodeGenConfig(t, `
+service_name: mysql
+model:
+  relations:
+    detect: fk
+  tables:
+    - table_name: user_login_log
+      package_name: loginlog
+      relations:
+        detect: none
+`)
+	for _, file := range []string{filepath.Join(appDir, "model", "user_login_log.go"), filepath.Join(appDir, "dao", "user_login_log.go")} {
+		if err := os.Remove(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "model", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute model command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if model := readFile(t, filepath.Join(appDir, "model", "user_login_log.go")); strings.Contains(model, "UserEntity") {
+		t.Errorf("relations should be disabled by table config:\n%s", model)
+	}
+	if dao := readFile(t, filepath.Join(appDir, "dao", "user_login_log.go")); strings.Contains(dao, "GetByIDWithUser") || strings.Contains(dao, `"context"`) {
+		t.Errorf("dao should not contain relation helpers:\n%s", dao)
+	}
+}
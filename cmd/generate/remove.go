@@ -0,0 +1,632 @@
+package generate
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/morehao/golib/gutil"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove generated code",
+}
+
+var removeModuleCmd = &cobra.Command{
+	Use:   "module [table...]",
+	Short: "Remove the code generated by generate module",
+	Long: `Locate and remove everything generate module created for the tables in the module section of code_gen.yaml
+(or the tables given as arguments): the model/dao/object/controller/service files and their tests, the gRPC
+.proto/server files, the dto request/response structs, the router function and its call in RegisterRouter,
+the TableName const in model/table.go, and the error code file in pkg/code with its registerError call.
+Shared files are edited via AST, declarations not generated for the table are kept. A summary is printed
+and confirmed before anything is changed.`,
+	Run: runRemoveModule,
+}
+
+func init() {
+	removeModuleCmd.Flags().StringP("app", "a", "", "App name to remove code from (e.g., demoapp)")
+	removeModuleCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	removeCmd.AddCommand(removeModuleCmd)
+	Cmd.AddCommand(removeCmd)
+}
+
+// removeStep 删除模块时对单个文件的操作：删除整个文件、删除顶层声明或删除函数中的调用语句
+type removeStep struct {
+	Filepath  string
+	Delete    bool     // 删除整个文件
+	DeclNames []string // 删除的顶层声明，删除后文件不再包含声明时删除文件
+	FuncName  string   // 从该函数中删除 Calls 语句
+	Calls     []string
+	Emptied   bool // 预计删除后文件不再包含声明，仅用于汇总展示
+}
+
+// removeModulePlan 单张表的删除计划
+type removeModulePlan struct {
+	Table   TableConfig
+	Steps   []removeStep
+	PkgDirs []string // 模块的包目录（如 ctruser、svcuser），删除后为空时一并删除
+}
+
+func runRemoveModule(cmd *cobra.Command, args []string) {
+	appName, _ := cmd.Flags().GetString("app")
+	if appName == "" {
+		fmt.Println("Please provide an app name using --app flag")
+		return
+	}
+	currentDir, _ := os.Getwd()
+	appDir, resolveErr := resolveAppDir(currentDir, appName)
+	if resolveErr != nil {
+		fmt.Printf("Resolve app directory error: %v\n", resolveErr)
+		return
+	}
+	workDir = appDir
+
+	if cfg == nil {
+		configFilepath := filepath.Join(workDir, "config", "code_gen.yaml")
+		if _, err := os.Stat(configFilepath); os.IsNotExist(err) {
+			fmt.Printf("Config file does not exist: %s\n", configFilepath)
+			return
+		}
+		gutil.LoadYamlConfig(configFilepath, &cfg)
+		appInfo, getAppInfoErr := GetAppInfo(workDir)
+		if getAppInfoErr != nil {
+			fmt.Printf("Get app info error: %v\n", getAppInfoErr)
+			return
+		}
+		cfg.appInfo = *appInfo
+	}
+
+	tables, resolveTablesErr := resolveRemoveTables(cfg.Module, args)
+	if resolveTablesErr != nil {
+		fmt.Printf("Error removing: %v\n", resolveTablesErr)
+		return
+	}
+	var plans []*removeModulePlan
+	for _, table := range tables {
+		plan, planErr := planRemoveModule(table, cfg.Module.TablePrefix)
+		if planErr != nil {
+			fmt.Printf("Error removing: plan table %s error: %v\n", table.TableName, planErr)
+			return
+		}
+		if len(plan.Steps) == 0 {
+			fmt.Printf("[Remove] Module %s (table: %s): nothing to remove\n", table.PackageName, table.TableName)
+			continue
+		}
+		plans = append(plans, plan)
+	}
+	if len(plans) == 0 {
+		fmt.Println("Nothing to remove")
+		return
+	}
+	printRemovePlans(plans)
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes && !confirmRemove(cmd.InOrStdin()) {
+		fmt.Println("Remove cancelled")
+		return
+	}
+	if err := runInTransaction(func() error { return applyRemovePlans(plans) }); err != nil {
+		fmt.Printf("Error removing: %v\n", err)
+		return
+	}
+	fmt.Println("Removed successfully")
+}
+
+// resolveRemoveTables 解析需要删除的表：未指定参数时与 generate module 相同，取 module 配置中的表；
+// 指定参数时按参数中的表名删除，包名取 module 配置中同名表的 package_name，未配置时使用默认包名
+func resolveRemoveTables(moduleCfg ModuleConfig, tableNames []string) ([]TableConfig, error) {
+	if len(tableNames) == 0 {
+		if len(moduleCfg.Tables) == 0 {
+			if moduleCfg.TableName == "" {
+				return nil, fmt.Errorf("table_name is required")
+			}
+			return []TableConfig{{TableName: moduleCfg.TableName, PackageName: moduleCfg.PackageName}}, nil
+		}
+		tableNames = make([]string, 0, len(moduleCfg.Tables))
+		for _, table := range moduleCfg.Tables {
+			tableNames = append(tableNames, table.TableName)
+		}
+	}
+	packageNames := map[string]string{moduleCfg.TableName: moduleCfg.PackageName}
+	for _, table := range moduleCfg.Tables {
+		packageNames[table.TableName] = table.PackageName
+	}
+	var tables []TableConfig
+	for _, tableName := range tableNames {
+		if tableName == "" {
+			return nil, fmt.Errorf("table_name is required in tables")
+		}
+		packageName := packageNames[tableName]
+		if packageName == "" {
+			packageName = defaultPackageName(tableName, moduleCfg.TablePrefix)
+		}
+		tables = append(tables, TableConfig{TableName: tableName, PackageName: packageName})
+	}
+	return tables, nil
+}
+
+// planRemoveModule 按 generate module 的命名规则定位单张表生成的全部产物，只保留实际存在的文件与声明
+func planRemoveModule(table TableConfig, tablePrefix string) (*removeModulePlan, error) {
+	commonCfg := codegen.CommonConfig{
+		PackageName:       table.PackageName,
+		RootDir:           workDir,
+		LayerParentDirMap: defaultLayerParentDirMap,
+		LayerNameMap:      buildLayerNameMap(cfg.ServiceName),
+		LayerPrefixMap:    defaultLayerPrefixMap,
+	}
+	structName := RemoveTablePrefixFromStructName(gutil.SnakeToPascal(table.TableName), table.TableName, tablePrefix)
+	structNameLowerCamel := gutil.FirstLetterToLower(structName)
+	plan := &removeModulePlan{Table: table}
+
+	var modelDir, serviceDir, daoDir, daoFilename string
+	var serviceFilenames []string
+	fileLayers := []codegen.LayerName{
+		codegen.LayerNameModel,
+		codegen.LayerNameDao,
+		codegen.LayerNameObject,
+		codegen.LayerNameController,
+		layerNameControllerTest,
+		codegen.LayerNameService,
+		layerNameServiceTest,
+	}
+	for _, layerName := range fileLayers {
+		_, targetDir, targetFilename := layerTarget(commonCfg, layerName, table.TableName)
+		targetFilename = RemoveTablePrefixFromFilename(targetFilename, table.TableName, tablePrefix)
+		switch layerName {
+		case codegen.LayerNameModel:
+			modelDir = targetDir
+		case codegen.LayerNameDao:
+			// dao 文件生成在 dao 层根目录，不使用包目录
+			targetDir = filepath.Dir(targetDir)
+			daoDir, daoFilename = targetDir, targetFilename
+		case codegen.LayerNameObject, codegen.LayerNameController:
+			plan.PkgDirs = append(plan.PkgDirs, targetDir)
+		case codegen.LayerNameService:
+			serviceDir = targetDir
+			plan.PkgDirs = append(plan.PkgDirs, targetDir)
+		}
+		if layerName == codegen.LayerNameService || layerName == layerNameServiceTest {
+			serviceFilenames = append(serviceFilenames, targetFilename)
+		}
+		plan.addStep(removeStep{Filepath: filepath.Join(targetDir, targetFilename), Delete: true})
+	}
+	// nullable.go 为 service 包内共享的可空字段转换函数，包内没有其他文件时一并删除
+	plan.addSharedFileStep(serviceDir, nullableHelperFilename, serviceFilenames...)
+	// like.go 为 dao 包内共享的 LIKE 通配符转义函数，包内没有其他文件时一并删除
+	plan.addSharedFileStep(daoDir, likeHelperFilename, daoFilename)
+
+	// --with-grpc 生成的 .proto、protoc 编译产物与 gRPC 服务端
+	protoDir, grpcDir := grpcTargetDirs(workDir, table.PackageName)
+	fileBase := gutil.TrimFileExtension(RemoveTablePrefixFromFilename(table.TableName+".go", table.TableName, tablePrefix))
+	for _, name := range []string{fileBase + ".proto", fileBase + ".pb.go", fileBase + "_grpc.pb.go"} {
+		plan.addStep(removeStep{Filepath: filepath.Join(protoDir, name), Delete: true})
+	}
+	plan.addStep(removeStep{Filepath: filepath.Join(grpcDir, fileBase+".go"), Delete: true})
+	plan.PkgDirs = append(plan.PkgDirs, protoDir, grpcDir)
+	// context.go 为包内共享文件，包内没有其他文件时一并删除
+	plan.addSharedFileStep(grpcDir, grpcContextFilename, fileBase+".go")
+
+	_, dtoDir, requestFilename := layerTarget(commonCfg, layerNameRequest, table.TableName)
+	_, _, responseFilename := layerTarget(commonCfg, layerNameResponse, table.TableName)
+	plan.PkgDirs = append(plan.PkgDirs, dtoDir)
+	requestNames := []string{structName + "CreateReq", structName + "UpdateReq", structName + "DetailReq", structName + "PageListReq", structName + "DeleteReq"}
+	responseNames := []string{structName + "CreateResp", structName + "DetailResp", structName + "PageListItem", structName + "PageListResp"}
+	if err := plan.addDeclStep(filepath.Join(dtoDir, requestFilename), requestNames); err != nil {
+		return nil, err
+	}
+	if err := plan.addDeclStep(filepath.Join(dtoDir, responseFilename), responseNames); err != nil {
+		return nil, err
+	}
+
+	routerFunc := structNameLowerCamel + "Router"
+	_, routerDir, _ := layerTarget(commonCfg, codegen.LayerNameRouter, table.TableName)
+	if err := plan.addDeclStep(filepath.Join(routerDir, table.PackageName+".go"), []string{routerFunc}); err != nil {
+		return nil, err
+	}
+	if err := plan.addCallStep(filepath.Join(workDir, "internal", "router", "router.go"), "RegisterRouter", []string{routerFunc + "(groups)"}); err != nil {
+		return nil, err
+	}
+	if err := plan.addDeclStep(filepath.Join(modelDir, "table.go"), []string{"TableName" + structName}); err != nil {
+		return nil, err
+	}
+
+	// 错误码常量取自 <struct>ErrorMsgMap 的键，兼容模板中可选的错误码
+	codeDir := filepath.Join(cfg.appInfo.ProjectRootPath, "pkg", "code")
+	codeFilepath := filepath.Join(codeDir, table.PackageName+".go")
+	errorMsgMapName := structNameLowerCamel + "ErrorMsgMap"
+	if gutil.FileExists(codeFilepath) {
+		codeNames, collectErr := errorMsgMapKeys(codeFilepath, errorMsgMapName)
+		if collectErr != nil {
+			return nil, collectErr
+		}
+		if err := plan.addDeclStep(codeFilepath, append(codeNames, errorMsgMapName)); err != nil {
+			return nil, err
+		}
+	}
+	if err := plan.addCallStep(filepath.Join(codeDir, "code.go"), "init", []string{fmt.Sprintf("registerError(%s)", errorMsgMapName)}); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// addStep 添加删除文件的步骤，文件不存在时跳过
+func (p *removeModulePlan) addStep(step removeStep) {
+	if gutil.FileExists(step.Filepath) {
+		p.Steps = append(p.Steps, step)
+	}
+}
+
+// addSharedFileStep 包内共享文件（如 service 的 nullable.go、dao 的 like.go）在包内只剩当前表的文件时添加删除步骤
+func (p *removeModulePlan) addSharedFileStep(dir, sharedFilename string, ownFilenames ...string) {
+	entries, readErr := os.ReadDir(dir)
+	if readErr != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.Name() != sharedFilename && !slices.Contains(ownFilenames, entry.Name()) {
+			return
+		}
+	}
+	p.addStep(removeStep{Filepath: filepath.Join(dir, sharedFilename), Delete: true})
+}
+
+// addDeclStep 添加删除顶层声明的步骤，只保留文件中实际存在的声明
+func (p *removeModulePlan) addDeclStep(goFilepath string, declNames []string) error {
+	if !gutil.FileExists(goFilepath) {
+		return nil
+	}
+	src, readErr := os.ReadFile(goFilepath)
+	if readErr != nil {
+		return fmt.Errorf("read file %s error: %v", goFilepath, readErr)
+	}
+	_, removed, remaining, planErr := planDeclRemoval(src, declNames)
+	if planErr != nil {
+		return fmt.Errorf("parse file %s error: %v", goFilepath, planErr)
+	}
+	if len(removed) > 0 {
+		p.Steps = append(p.Steps, removeStep{Filepath: goFilepath, DeclNames: removed, Emptied: remaining == 0})
+	}
+	return nil
+}
+
+// addCallStep 添加删除函数中调用语句的步骤，只保留函数中实际存在的调用
+func (p *removeModulePlan) addCallStep(goFilepath, funcName string, calls []string) error {
+	if !gutil.FileExists(goFilepath) {
+		return nil
+	}
+	src, readErr := os.ReadFile(goFilepath)
+	if readErr != nil {
+		return fmt.Errorf("read file %s error: %v", goFilepath, readErr)
+	}
+	_, removed, planErr := planCallRemoval(src, funcName, calls)
+	if planErr != nil {
+		return fmt.Errorf("parse file %s error: %v", goFilepath, planErr)
+	}
+	if len(removed) > 0 {
+		p.Steps = append(p.Steps, removeStep{Filepath: goFilepath, FuncName: funcName, Calls: removed})
+	}
+	return nil
+}
+
+// printRemovePlans 输出删除汇总，文件路径相对项目根目录展示
+func printRemovePlans(plans []*removeModulePlan) {
+	for _, plan := range plans {
+		fmt.Printf("[Remove] Module %s (table: %s):\n", plan.Table.PackageName, plan.Table.TableName)
+		for _, step := range plan.Steps {
+			relPath, relErr := filepath.Rel(cfg.appInfo.ProjectRootPath, step.Filepath)
+			if relErr != nil {
+				relPath = step.Filepath
+			}
+			switch {
+			case step.Delete:
+				fmt.Printf("  delete  %s\n", relPath)
+			case step.FuncName != "":
+				fmt.Printf("  edit    %s: remove %s from %s\n", relPath, strings.Join(step.Calls, ", "), step.FuncName)
+			case step.Emptied:
+				fmt.Printf("  delete  %s (only %s declared)\n", relPath, strings.Join(step.DeclNames, ", "))
+			default:
+				fmt.Printf("  edit    %s: remove %s\n", relPath, strings.Join(step.DeclNames, ", "))
+			}
+		}
+	}
+}
+
+// confirmRemove 读取确认输入，y 或 yes 视为确认
+func confirmRemove(in io.Reader) bool {
+	fmt.Print("Remove the files and declarations above? [y/N]: ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// applyRemovePlans 执行删除计划：每个步骤重新读取文件，同一文件被多张表共享时依次删除各自的声明
+func applyRemovePlans(plans []*removeModulePlan) error {
+	for _, plan := range plans {
+		for _, step := range plan.Steps {
+			if err := applyRemoveStep(step); err != nil {
+				return err
+			}
+		}
+		// 包目录中已无文件时一并删除，目录非空时 os.Remove 失败并保留
+		for _, dir := range plan.PkgDirs {
+			_ = os.Remove(dir)
+		}
+		fmt.Printf("[Remove] Removed module: %s (table: %s)\n", plan.Table.PackageName, plan.Table.TableName)
+	}
+	return nil
+}
+
+func applyRemoveStep(step removeStep) error {
+	if err := trackFiles(step.Filepath); err != nil {
+		return err
+	}
+	if step.Delete {
+		if err := os.Remove(step.Filepath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove file %s error: %v", step.Filepath, err)
+		}
+		return nil
+	}
+	src, readErr := os.ReadFile(step.Filepath)
+	if readErr != nil {
+		if os.IsNotExist(readErr) {
+			return nil
+		}
+		return fmt.Errorf("read file %s error: %v", step.Filepath, readErr)
+	}
+	var edits []textEdit
+	var planErr error
+	remaining := -1
+	if step.FuncName != "" {
+		edits, _, planErr = planCallRemoval(src, step.FuncName, step.Calls)
+	} else {
+		edits, _, remaining, planErr = planDeclRemoval(src, step.DeclNames)
+	}
+	if planErr != nil {
+		return fmt.Errorf("parse file %s error: %v", step.Filepath, planErr)
+	}
+	if remaining == 0 {
+		if err := os.Remove(step.Filepath); err != nil {
+			return fmt.Errorf("remove file %s error: %v", step.Filepath, err)
+		}
+		return nil
+	}
+	res, cleanErr := removeUnusedImports(applyTextEdits(src, edits))
+	if cleanErr != nil {
+		return fmt.Errorf("clean imports of %s error: %v", step.Filepath, cleanErr)
+	}
+	return writeFormattedFile(step.Filepath, res)
+}
+
+// planDeclRemoval 计算删除顶层函数（不含方法）、类型、常量与变量声明的文本编辑，声明连同文档注释按行删除；
+// 分组声明中的部分 spec 被删除时只删除对应的行。返回实际删除的名称与删除后剩余的非 import 声明数
+func planDeclRemoval(src []byte, declNames []string) ([]textEdit, []string, int, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, nil, 0, parseErr
+	}
+	nameSet := make(map[string]struct{}, len(declNames))
+	for _, name := range declNames {
+		nameSet[name] = struct{}{}
+	}
+	allRemoved := func(names []string) bool {
+		for _, name := range names {
+			if _, ok := nameSet[name]; !ok {
+				return false
+			}
+		}
+		return len(names) > 0
+	}
+	lineEdit := func(doc *ast.CommentGroup, node ast.Node) textEdit {
+		start := node.Pos()
+		if doc != nil {
+			start = doc.Pos()
+		}
+		return textEdit{
+			Start: lineStartOffset(src, fset.Position(start).Offset),
+			End:   lineEndOffset(src, fset.Position(node.End()).Offset),
+		}
+	}
+
+	var edits []textEdit
+	var removed []string
+	remaining := 0
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && allRemoved([]string{d.Name.Name}) {
+				edits = append(edits, lineEdit(d.Doc, d))
+				removed = append(removed, d.Name.Name)
+				continue
+			}
+			remaining++
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			var specEdits []textEdit
+			var specRemoved []string
+			for _, spec := range d.Specs {
+				names, doc := specNamesAndDoc(spec)
+				if allRemoved(names) {
+					specEdits = append(specEdits, lineEdit(doc, spec))
+					specRemoved = append(specRemoved, names...)
+				}
+			}
+			removed = append(removed, specRemoved...)
+			if len(specEdits) == len(d.Specs) {
+				edits = append(edits, lineEdit(d.Doc, d))
+				continue
+			}
+			edits = append(edits, specEdits...)
+			remaining++
+		}
+	}
+	return edits, removed, remaining, nil
+}
+
+func specNamesAndDoc(spec ast.Spec) ([]string, *ast.CommentGroup) {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return []string{s.Name.Name}, s.Doc
+	case *ast.ValueSpec:
+		names := make([]string, 0, len(s.Names))
+		for _, name := range s.Names {
+			names = append(names, name.Name)
+		}
+		return names, s.Doc
+	}
+	return nil, nil
+}
+
+// planCallRemoval 计算删除函数体中调用语句的文本编辑，调用按去除空白后的源码匹配，返回实际删除的调用
+func planCallRemoval(src []byte, funcName string, calls []string) ([]textEdit, []string, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+	stripSpaces := func(s string) string {
+		return strings.Join(strings.Fields(s), "")
+	}
+	callSet := make(map[string]string, len(calls))
+	for _, call := range calls {
+		callSet[stripSpaces(call)] = call
+	}
+	var edits []textEdit
+	var removed []string
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || funcDecl.Name.Name != funcName || funcDecl.Body == nil {
+			continue
+		}
+		for _, stmt := range funcDecl.Body.List {
+			if _, isExpr := stmt.(*ast.ExprStmt); !isExpr {
+				continue
+			}
+			start, end := fset.Position(stmt.Pos()).Offset, fset.Position(stmt.End()).Offset
+			call, matched := callSet[stripSpaces(string(src[start:end]))]
+			if !matched {
+				continue
+			}
+			edits = append(edits, textEdit{Start: lineStartOffset(src, start), End: lineEndOffset(src, end)})
+			removed = append(removed, call)
+		}
+	}
+	return edits, removed, nil
+}
+
+// majorVersionPattern 导入路径末尾的主版本号，如 github.com/xxx/v2
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// removeUnusedImports 删除声明删除后不再使用的导入；包名无法从导入路径推断（如 gopkg.in/yaml.v3）、
+// 匿名导入与点导入保持不变
+func removeUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	usedNames := make(map[string]struct{})
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, isIdent := sel.X.(*ast.Ident); isIdent {
+				usedNames[ident.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	isUnused := func(spec *ast.ImportSpec) bool {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		importName := path.Base(importPath)
+		if majorVersionPattern.MatchString(importName) {
+			importName = path.Base(path.Dir(importPath))
+		}
+		if spec.Name != nil {
+			importName = spec.Name.Name
+		}
+		if importName == "_" || importName == "." || !token.IsIdentifier(importName) {
+			return false
+		}
+		_, used := usedNames[importName]
+		return !used
+	}
+
+	var edits []textEdit
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		var specEdits []textEdit
+		for _, spec := range genDecl.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			if isUnused(importSpec) {
+				specEdits = append(specEdits, textEdit{
+					Start: lineStartOffset(src, fset.Position(importSpec.Pos()).Offset),
+					End:   lineEndOffset(src, fset.Position(importSpec.End()).Offset),
+				})
+			}
+		}
+		if len(specEdits) > 0 && len(specEdits) == len(genDecl.Specs) {
+			specEdits = []textEdit{{
+				Start: lineStartOffset(src, fset.Position(genDecl.Pos()).Offset),
+				End:   lineEndOffset(src, fset.Position(genDecl.End()).Offset),
+			}}
+		}
+		edits = append(edits, specEdits...)
+	}
+	return applyTextEdits(src, edits), nil
+}
+
+// errorMsgMapKeys 错误码文件中 <struct>ErrorMsgMap 的键，即 generate module 为该表生成的错误码常量
+func errorMsgMapKeys(goFilepath, mapName string) ([]string, error) {
+	file, parseErr := parser.ParseFile(token.NewFileSet(), goFilepath, nil, 0)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parse file %s error: %v", goFilepath, parseErr)
+	}
+	var keys []string
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if name.Name != mapName || i >= len(valueSpec.Values) {
+					continue
+				}
+				compositeLit, isLit := valueSpec.Values[i].(*ast.CompositeLit)
+				if !isLit {
+					continue
+				}
+				for _, elt := range compositeLit.Elts {
+					if kv, isKV := elt.(*ast.KeyValueExpr); isKV {
+						if ident, isIdent := kv.Key.(*ast.Ident); isIdent {
+							keys = append(keys, ident.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,221 @@
+package generate
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRemoveModuleRoundTrip generate module 后执行 remove module，应用目录与 pkg/code 恢复为生成前的内容
+func TestRemoveModuleRoundTrip(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "article.sql")
+	ddl := "CREATE TABLE `article` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `title` varchar(128) NOT NULL COMMENT '标题',\n" +
+		"  `published_at` datetime DEFAULT NULL COMMENT '发布时间',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB COMMENT='文章表';\n"
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: article
+  description: 文章
+  table_name: article
+`)
+	root, _ := os.Getwd()
+	snapshotDirs := []string{filepath.Join("apps", "demoapp"), filepath.Join("pkg", "code")}
+	before, err := takeSnapshot(root, snapshotDirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "remove", "module", "--app", "demoapp", "--yes"); err != nil {
+			t.Errorf("Failed to execute remove command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Removed successfully") {
+		t.Fatalf("remove did not complete successfully:\n%s", output)
+	}
+	for _, want := range []string{
+		"delete  apps/demoapp/model/article.go",
+		"delete  apps/demoapp/internal/dto/dtoarticle/request.go (only ArticleCreateReq",
+		"edit    apps/demoapp/model/table.go: remove TableNameArticle",
+		"edit    apps/demoapp/internal/router/router.go: remove articleRouter(groups) from RegisterRouter",
+		"edit    pkg/code/code.go: remove registerError(articleErrorMsgMap) from init",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("summary missing %q:\n%s", want, output)
+		}
+	}
+
+	after, err := takeSnapshot(root, snapshotDirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range compareSnapshots(before, after) {
+		t.Errorf("%s differs after remove:\n%s", change.Path, change.After)
+	}
+	for _, dir := range []string{"ctrarticle", "svcarticle", "dtoarticle"} {
+		matches, _ := filepath.Glob(filepath.Join("apps", "demoapp", "internal", "*", dir))
+		if len(matches) > 0 {
+			t.Errorf("package dir not removed: %v", matches)
+		}
+	}
+}
+
+// TestRemoveModuleConfirm 删除示例项目已有的 user 模块：未确认时不做改动，确认后删除文件并从共享文件中移除声明与调用
+func TestRemoveModuleConfirm(t *testing.T) {
+	resetGenerateState()
+	restore := chdirToExample(t)
+	defer restore()
+	defer Cmd.SetIn(nil)
+
+	appDir := filepath.Join("apps", "demoapp")
+	modelFile := filepath.Join(appDir, "model", "user.go")
+	Cmd.SetIn(strings.NewReader("n\n"))
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "remove", "module", "--app", "demoapp", "user"); err != nil {
+			t.Errorf("Failed to execute remove command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Remove cancelled") {
+		t.Fatalf("remove should be cancelled:\n%s", output)
+	}
+	if _, err := os.Stat(modelFile); err != nil {
+		t.Fatalf("model file removed without confirmation: %v", err)
+	}
+
+	Cmd.SetIn(strings.NewReader("y\n"))
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "remove", "module", "--app", "demoapp", "user"); err != nil {
+			t.Errorf("Failed to execute remove command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Removed successfully") {
+		t.Fatalf("remove did not complete successfully:\n%s", output)
+	}
+	for _, removed := range []string{
+		modelFile,
+		filepath.Join(appDir, "model", "table.go"),
+		filepath.Join(appDir, "dao", "user.go"),
+		filepath.Join(appDir, "object", "objuser"),
+		filepath.Join(appDir, "internal", "controller", "ctruser"),
+		filepath.Join(appDir, "internal", "service", "svcuser"),
+		filepath.Join(appDir, "internal", "dto", "dtouser"),
+		filepath.Join(appDir, "internal", "router", "user.go"),
+		filepath.Join("pkg", "code", "user.go"),
+	} {
+		if _, err := os.Stat(removed); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", removed)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(appDir, "dao", "base.go")); err != nil {
+		t.Errorf("dao base file should be kept: %v", err)
+	}
+
+	routerFile := filepath.Join(appDir, "internal", "router", "router.go")
+	codeFile := filepath.Join("pkg", "code", "code.go")
+	if content := readFile(t, routerFile); strings.Contains(content, "userRouter") {
+		t.Errorf("router call not removed:\n%s", content)
+	}
+	code := readFile(t, codeFile)
+	if strings.Contains(code, "userErrorMsgMap") || !strings.Contains(code, "registerError(gconstant.AuthErrorMsgMap)") {
+		t.Errorf("registerError call not removed precisely:\n%s", code)
+	}
+	for _, file := range []string{routerFile, codeFile} {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("edited file %s is not valid Go: %v", file, err)
+		}
+	}
+}
+
+func TestRemoveDecls(t *testing.T) {
+	src := []byte(`package dtoorder
+
+import (
+	"time"
+
+	"github.com/example/demoapp/object/objorder"
+	"github.com/morehao/golib/biz/gobject"
+)
+
+// OrderCreateReq 创建订单
+type OrderCreateReq struct {
+	objorder.OrderBaseInfo
+}
+
+type (
+	OrderPageListReq struct {
+		gobject.PageQuery
+	}
+	OrderExportReq struct {
+		Since time.Time
+	}
+)
+
+func orderRouter() {}
+`)
+	edits, removed, remaining, err := planDeclRemoval(src, []string{"OrderCreateReq", "OrderPageListReq", "orderRouter", "OrderMissingReq"})
+	if err != nil {
+		t.Fatalf("planDeclRemoval error: %v", err)
+	}
+	if strings.Join(removed, ",") != "OrderCreateReq,OrderPageListReq,orderRouter" || remaining != 1 {
+		t.Errorf("removed = %v, remaining = %d", removed, remaining)
+	}
+	res, err := removeUnusedImports(applyTextEdits(src, edits))
+	if err != nil {
+		t.Fatalf("removeUnusedImports error: %v", err)
+	}
+	formatted, err := format.Source(res)
+	if err != nil {
+		t.Fatalf("format result error: %v\n%s", err, res)
+	}
+	want := "package dtoorder\n\nimport (\n\t\"time\"\n)\n\ntype (\n\tOrderExportReq struct {\n\t\tSince time.Time\n\t}\n)\n"
+	if string(formatted) != want {
+		t.Errorf("remove decls result:\n%s", formatted)
+	}
+}
+
+// TestAddSharedFileStep 包内只剩当前表的文件时才删除共享文件
+func TestAddSharedFileStep(t *testing.T) {
+	daoDir := t.TempDir()
+	for _, name := range []string{"article.go", likeHelperFilename} {
+		if err := os.WriteFile(filepath.Join(daoDir, name), []byte("package dao\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plan := &removeModulePlan{}
+	plan.addSharedFileStep(daoDir, likeHelperFilename, "article.go")
+	if len(plan.Steps) != 1 || plan.Steps[0].Filepath != filepath.Join(daoDir, likeHelperFilename) || !plan.Steps[0].Delete {
+		t.Fatalf("steps = %+v, want delete %s", plan.Steps, likeHelperFilename)
+	}
+
+	if err := os.WriteFile(filepath.Join(daoDir, "comment.go"), []byte("package dao\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plan = &removeModulePlan{}
+	plan.addSharedFileStep(daoDir, likeHelperFilename, "article.go")
+	if len(plan.Steps) != 0 {
+		t.Errorf("shared file used by other tables should be kept, steps = %+v", plan.Steps)
+	}
+}
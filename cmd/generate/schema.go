@@ -0,0 +1,390 @@
+package generate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/morehao/golib/gutil"
+)
+
+// 表结构来源
+const (
+	SchemaSourceDB  = "db"  // 连接数据库内省表结构（默认）
+	SchemaSourceDDL = "ddl" // 解析 SQL DDL 文件获取表结构，无需数据库
+)
+
+const (
+	layerNameTable    codegen.LayerName = "table"
+	layerNameRequest  codegen.LayerName = "request"
+	layerNameResponse codegen.LayerName = "response"
+
+	layerNameServiceTest    codegen.LayerName = "service_test"    // service 单元测试，生成到 service 层目录
+	layerNameControllerTest codegen.LayerName = "controller_test" // controller 单元测试，生成到 controller 层目录
+)
+
+// testLayerSuffix 测试模板的层名后缀，如 service_test 对应 service 层
+const testLayerSuffix = "_test"
+
+// ddlTables DDL 模式下解析得到的表结构，key 为表名
+var ddlTables map[string]*TableSchema
+
+// TableSchema 表结构定义，字段与 golib codegen 数据库内省结果对齐
+type TableSchema struct {
+	TableName string
+	Comment   string
+	Columns   []ColumnSchema
+}
+
+// ColumnSchema 列定义
+type ColumnSchema struct {
+	FieldName     string        // 字段名称，列名的 PascalCase 形式，如 CompanyId
+	FieldType     string        // Go 数据类型，如 int64、string
+	ColumnName    string        // 列名
+	ColumnType    string        // 列数据类型，如 varchar(255)
+	IsNullable    bool          // 是否允许为空
+	DefaultValue  string        // 默认值（不含引号），无默认值时为空
+	HasDefault    bool          // 是否有默认值（含 DEFAULT ''、自增与生成列），用于推导 required 校验
+	Comment       string        // 列注释
+	IsPrimaryKey  bool          // 是否主键
+	IndexName     string        // 所属索引名称（主键除外），属于多个索引时取首个，用于 model 的 gorm 标签
+	IsUniqueIndex bool          // IndexName 是否唯一索引
+	Indexes       []ColumnIndex // 所属的全部索引（主键除外），用于生成唯一索引查询与重复校验
+	EnumValues    []string      // 枚举类型的取值（PostgreSQL 枚举类型），MySQL ENUM 的取值从 ColumnType 解析
+	RefTableName  string        // 外键引用的表名，仅识别单列外键
+	RefColumnName string        // 外键引用的列名
+	Serializer    string        // gorm 序列化器，来自 type_overrides，如 json
+}
+
+// ColumnIndex 列所属的索引
+type ColumnIndex struct {
+	Name     string // 索引名称
+	IsUnique bool   // 是否唯一索引
+	Seq      int    // 列在索引中的位置，从 1 开始
+}
+
+// moduleAnalysis 模板与表结构的解析结果，屏蔽表结构来源（数据库 / DDL）的差异
+type moduleAnalysis struct {
+	PackageName     string
+	TableName       string
+	StructName      string
+	PKFieldType     string
+	Columns         []ColumnSchema
+	TplAnalysisList []tplAnalysisItem
+}
+
+// tplAnalysisItem 单个模板的解析结果
+type tplAnalysisItem struct {
+	OriginLayerName codegen.LayerName
+	LayerName       codegen.LayerName
+	TargetDir       string
+	TargetFilename  string
+	Template        *template.Template
+}
+
+// analysisModuleTpl 解析模板与表结构，并按 type_overrides 替换列的 Go 类型，各生成模式与 sync 共用
+func analysisModuleTpl(analysisCfg *codegen.ModuleCfg) (*moduleAnalysis, error) {
+	res, err := analysisModuleTplBySource(analysisCfg)
+	if err != nil {
+		return nil, err
+	}
+	columns, overrideErr := applyTypeOverrides(res.TableName, res.Columns)
+	if overrideErr != nil {
+		return nil, overrideErr
+	}
+	res.Columns = columns
+	return res, nil
+}
+
+// analysisModuleTplBySource 按配置的表结构来源解析模板：db 模式交给 golib codegen 内省数据库，
+// ddl 模式使用预先解析的 DDL 表结构，二者产出相同的 moduleAnalysis。
+// SQLite 没有 information_schema，db 模式下改为通过 PRAGMA 内省表结构。
+func analysisModuleTplBySource(analysisCfg *codegen.ModuleCfg) (*moduleAnalysis, error) {
+	if cfg.SchemaSource == SchemaSourceDDL {
+		table, ok := ddlTables[analysisCfg.TableName]
+		if !ok {
+			return nil, fmt.Errorf("table %s not found in ddl files", analysisCfg.TableName)
+		}
+		return analysisModuleTplFromSchema(analysisCfg, table)
+	}
+	if ddlDialect(cfg.DatabaseDSN) == DBTypeSQLite {
+		table, err := loadSQLiteTable(DBClient, analysisCfg.TableName)
+		if err != nil {
+			return nil, err
+		}
+		return analysisModuleTplFromSchema(analysisCfg, table)
+	}
+
+	gen := codegen.NewGenerator()
+	analysisRes, analysisErr := gen.AnalysisModuleTpl(DBClient, analysisCfg)
+	if analysisErr != nil {
+		return nil, analysisErr
+	}
+	res := &moduleAnalysis{
+		PackageName: analysisRes.PackageName,
+		TableName:   analysisRes.TableName,
+		StructName:  analysisRes.StructName,
+		PKFieldType: analysisRes.PKFieldType,
+	}
+	for _, v := range analysisRes.TplAnalysisList {
+		// 测试模板不是 golib 识别的层，按其所属层重新计算目标目录与文件名
+		if strings.HasSuffix(string(v.OriginLayerName), testLayerSuffix) {
+			v.LayerName, v.TargetDir, v.TargetFilename = layerTarget(analysisCfg.CommonConfig, v.OriginLayerName, analysisRes.TableName)
+		}
+		// 各层模板共享同一张表的字段，取首个即可
+		if res.Columns == nil {
+			for _, field := range v.ModelFields {
+				res.Columns = append(res.Columns, ColumnSchema{
+					FieldName:     field.FieldName,
+					FieldType:     field.FieldType,
+					ColumnName:    field.ColumnName,
+					ColumnType:    field.ColumnType,
+					IsNullable:    field.IsNullable,
+					DefaultValue:  field.DefaultValue,
+					Comment:       field.Comment,
+					IsPrimaryKey:  field.IsPrimaryKey,
+					IndexName:     field.IndexName,
+					IsUniqueIndex: field.IsUniqueIndex,
+				})
+			}
+		}
+		res.TplAnalysisList = append(res.TplAnalysisList, tplAnalysisItem{
+			OriginLayerName: v.OriginLayerName,
+			LayerName:       v.LayerName,
+			TargetDir:       v.TargetDir,
+			TargetFilename:  v.TargetFilename,
+			Template:        v.Template,
+		})
+	}
+	// golib 内省结果无法区分无默认值与 DEFAULT ''，需查询 information_schema 补充
+	hasDefaults, defaultErr := loadColumnHasDefault(DBClient, ddlDialect(cfg.DatabaseDSN), res.TableName)
+	if defaultErr != nil {
+		return nil, defaultErr
+	}
+	for i := range res.Columns {
+		res.Columns[i].HasDefault = hasDefaults[res.Columns[i].ColumnName]
+	}
+	// golib 内省结果每列只保留一个索引，需查询索引元数据补充列所属的全部索引
+	columnIndexes, indexErr := loadColumnIndexes(DBClient, ddlDialect(cfg.DatabaseDSN), res.TableName)
+	if indexErr != nil {
+		return nil, indexErr
+	}
+	for i := range res.Columns {
+		res.Columns[i].Indexes = columnIndexes[res.Columns[i].ColumnName]
+	}
+	// PostgreSQL 枚举类型的列在 information_schema 中为 USER-DEFINED，需查询 pg_enum 补充取值
+	if ddlDialect(cfg.DatabaseDSN) == DBTypePostgres {
+		enumValues, err := loadPostgresEnumValues(DBClient, res.TableName)
+		if err != nil {
+			return nil, err
+		}
+		for i := range res.Columns {
+			res.Columns[i].EnumValues = enumValues[res.Columns[i].ColumnName]
+		}
+	}
+	return res, nil
+}
+
+// analysisModuleTplFromSchema 基于给定表结构解析模板目录，目标目录与文件名遵循 golib codegen 的约定：
+//   - 层目录为 RootDir/[LayerParentDir]/LayerName
+//   - controller/service/dto/object 层再按 "前缀+包名" 建子目录（如 ctruser），dao 层按包名建子目录
+//   - request/response 模板归入 dto 层，文件名取模板名；其余层文件名取表名
+func analysisModuleTplFromSchema(analysisCfg *codegen.ModuleCfg, table *TableSchema) (*moduleAnalysis, error) {
+	res := &moduleAnalysis{
+		PackageName: analysisCfg.PackageName,
+		TableName:   table.TableName,
+		StructName:  gutil.SnakeToPascal(table.TableName),
+		Columns:     table.Columns,
+	}
+	for _, column := range table.Columns {
+		if column.IsPrimaryKey {
+			res.PKFieldType = column.FieldType
+			break
+		}
+	}
+
+	var tplFiles []string
+	walkErr := filepath.WalkDir(analysisCfg.TplDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".tpl") {
+			tplFiles = append(tplFiles, path)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("walk template dir error: %v", walkErr)
+	}
+	sort.Strings(tplFiles)
+
+	for _, tplFile := range tplFiles {
+		tplName := filepath.Base(tplFile)
+		tpl, parseErr := template.New(tplName).Funcs(analysisCfg.TplFuncMap).ParseFiles(tplFile)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse template %s error: %v", tplName, parseErr)
+		}
+		originLayerName := codegen.LayerName(strings.TrimSuffix(tplName, ".go.tpl"))
+		layerName, targetDir, targetFilename := layerTarget(analysisCfg.CommonConfig, originLayerName, table.TableName)
+		res.TplAnalysisList = append(res.TplAnalysisList, tplAnalysisItem{
+			OriginLayerName: originLayerName,
+			LayerName:       layerName,
+			TargetDir:       targetDir,
+			TargetFilename:  targetFilename,
+			Template:        tpl,
+		})
+	}
+	return res, nil
+}
+
+// layerTarget 计算模板层的实际层名、目标目录与目标文件名
+func layerTarget(commonCfg codegen.CommonConfig, originLayerName codegen.LayerName, tableName string) (codegen.LayerName, string, string) {
+	dirLayerName := originLayerName
+	targetFilename := tableName + ".go"
+	if originLayerName == layerNameRequest || originLayerName == layerNameResponse {
+		dirLayerName = codegen.LayerNameDto
+		targetFilename = string(originLayerName) + ".go"
+	}
+	if baseLayerName, isTest := strings.CutSuffix(string(originLayerName), testLayerSuffix); isTest {
+		dirLayerName = codegen.LayerName(baseLayerName)
+		targetFilename = tableName + testLayerSuffix + ".go"
+	}
+
+	layerName := dirLayerName
+	if mapped, ok := commonCfg.LayerNameMap[dirLayerName]; ok && mapped != "" {
+		layerName = mapped
+	}
+
+	targetDir := filepath.Join(commonCfg.RootDir, commonCfg.LayerParentDirMap[dirLayerName], string(layerName))
+	defaultPrefixMap := map[codegen.LayerName]string{
+		codegen.LayerNameController: "ctr",
+		codegen.LayerNameService:    "svc",
+		codegen.LayerNameDto:        "dto",
+		codegen.LayerNameObject:     "obj",
+		codegen.LayerNameDao:        "",
+	}
+	if prefix, ok := defaultPrefixMap[dirLayerName]; ok {
+		if customPrefix, hasCustom := commonCfg.LayerPrefixMap[dirLayerName]; hasCustom {
+			prefix = string(customPrefix)
+		}
+		targetDir = filepath.Join(targetDir, prefix+commonCfg.PackageName)
+	}
+	return layerName, targetDir, targetFilename
+}
+
+// buildModelFields 将表结构列转换为模板使用的 ModelField
+func buildModelFields(columns []ColumnSchema, structName string) []ModelField {
+	var modelFields []ModelField
+	compositeKey := countPrimaryKeys(columns) > 1
+	for _, field := range columns {
+		nullableDesc := nullableDefaultDesc
+		if field.IsNullable {
+			nullableDesc = ""
+		}
+		defaultValue := fmt.Sprintf("%s %s", fieldDefaultKeyword, field.DefaultValue)
+		if field.DefaultValue == "" {
+			defaultValue = fmt.Sprintf("%s ''", fieldDefaultKeyword)
+		}
+		// GormComment 用于 model 层的 gorm tag，格式为 "comment: xxx"
+		gormComment := fmt.Sprintf("%s: %s", fieldCommentKeyword, field.Comment)
+		if field.Comment == "" {
+			gormComment = ""
+		}
+		// Comment 用于 obj 层等其他地方的普通注释，直接使用原始注释
+		comment := field.Comment
+		fieldName := gutil.ReplaceIdToID(field.FieldName)
+		var enumTypeName, enumDesc string
+		var enumItems []EnumItem
+		if !field.IsPrimaryKey && !IsSysField(fieldName) {
+			if desc, values := columnEnumValues(field); len(values) > 0 {
+				enumTypeName = structName + fieldName
+				enumDesc = desc
+				enumItems = buildEnumItems(enumTypeName, field.FieldType, values)
+				if enumItems == nil {
+					enumTypeName, enumDesc = "", ""
+				}
+			}
+		}
+		nullableStyle := fieldNullableStyle(field, fieldName)
+		baseType := field.FieldType
+		if enumTypeName != "" {
+			baseType = enumTypeName
+		}
+		modelType, nullValueField := modelFieldType(nullableStyle, field.FieldType, baseType)
+		modelFields = append(modelFields, ModelField{
+			IsPrimaryKey:         field.IsPrimaryKey,
+			IsKeyField:           compositeKey && field.IsPrimaryKey,
+			FieldName:            fieldName,
+			FieldLowerCaseName:   gutil.SnakeToLowerCamel(field.FieldName),
+			JsonTagName:          SnakeToLowerCamelWithID(field.ColumnName),
+			FieldType:            field.FieldType,
+			ColumnName:           field.ColumnName,
+			ColumnType:           field.ColumnType,
+			NullableDesc:         nullableDesc,
+			DefaultValue:         defaultValue,
+			GormComment:          gormComment,
+			Comment:              comment,
+			StructNameLowerCamel: gutil.FirstLetterToLower(structName),
+			IndexName:            field.IndexName,
+			IsUniqueIndex:        field.IsUniqueIndex,
+			Indexes:              field.Indexes,
+			EnumTypeName:         enumTypeName,
+			EnumDesc:             enumDesc,
+			EnumItems:            enumItems,
+			EnumOneOf:            enumOneOf(enumItems),
+			EnumList:             enumList(enumItems),
+			Binding:              bindingRules(field, enumItems),
+			NullableStyle:        nullableStyle,
+			ModelFieldType:       modelType,
+			NullValueField:       nullValueField,
+			Serializer:           field.Serializer,
+		})
+		if IsBuiltInField(fieldName) {
+			applySysFieldTags(&modelFields[len(modelFields)-1])
+		}
+	}
+	return modelFields
+}
+
+// loadDDLTables 读取 DDL 文件（支持 glob）并解析其中的表结构
+func loadDDLTables(patterns []string, baseDir, dialect string) (map[string]*TableSchema, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no ddl files configured, set ddl_files in code_gen.yaml or use --ddl flag")
+	}
+	tables := make(map[string]*TableSchema)
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+		matches, globErr := filepath.Glob(pattern)
+		if globErr != nil {
+			return nil, fmt.Errorf("invalid ddl file pattern %s: %v", pattern, globErr)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("ddl file not found: %s", pattern)
+		}
+		for _, ddlFile := range matches {
+			content, readErr := os.ReadFile(ddlFile)
+			if readErr != nil {
+				return nil, fmt.Errorf("read ddl file %s error: %v", ddlFile, readErr)
+			}
+			fileDialect := dialect
+			if fileDialect == "" {
+				fileDialect = detectDDLDialect(string(content))
+			}
+			fileTables, parseErr := ParseDDL(string(content), fileDialect)
+			if parseErr != nil {
+				return nil, fmt.Errorf("parse ddl file %s error: %v", ddlFile, parseErr)
+			}
+			for _, table := range fileTables {
+				tables[table.TableName] = table
+			}
+		}
+	}
+	return tables, nil
+}
@@ -0,0 +1,95 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/morehao/gocli/internal/scaffold"
+)
+
+// fileSnapshot 文件内容快照，key 为相对快照根目录的路径
+type fileSnapshot map[string][]byte
+
+// fileChange 两次快照之间的文件变更
+type fileChange struct {
+	Path    string // 相对快照根目录的路径
+	Before  []byte
+	After   []byte
+	Created bool
+	Deleted bool
+}
+
+// generateTargetDirs 代码生成可能写入的目录（相对项目根目录）：应用目录与项目级错误码目录
+func generateTargetDirs() ([]string, error) {
+	appRelDir, err := filepath.Rel(cfg.appInfo.ProjectRootPath, workDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve app dir error: %v", err)
+	}
+	return []string{appRelDir, filepath.Join("pkg", "code")}, nil
+}
+
+// takeSnapshot 读取 root 下指定目录中的全部文件，目录不存在时忽略
+func takeSnapshot(root string, dirs []string) (fileSnapshot, error) {
+	snapshot := make(fileSnapshot)
+	for _, dir := range dirs {
+		walkErr := filepath.WalkDir(filepath.Join(root, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			relPath, relErr := filepath.Rel(root, path)
+			if relErr != nil {
+				return relErr
+			}
+			if scaffold.ShouldIgnore(filepath.ToSlash(relPath)) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			content, readErr := os.ReadFile(path)
+			if readErr != nil {
+				return readErr
+			}
+			snapshot[relPath] = content
+			return nil
+		})
+		if walkErr != nil {
+			return nil, fmt.Errorf("snapshot dir %s error: %v", dir, walkErr)
+		}
+	}
+	return snapshot, nil
+}
+
+// compareSnapshots 对比两次快照，返回按路径排序的新增、修改、删除文件
+func compareSnapshots(before, after fileSnapshot) []fileChange {
+	var changes []fileChange
+	for path, afterContent := range after {
+		beforeContent, exists := before[path]
+		if !exists {
+			changes = append(changes, fileChange{Path: path, After: afterContent, Created: true})
+			continue
+		}
+		if !bytes.Equal(beforeContent, afterContent) {
+			changes = append(changes, fileChange{Path: path, Before: beforeContent, After: afterContent})
+		}
+	}
+	for path, beforeContent := range before {
+		if _, exists := after[path]; !exists {
+			changes = append(changes, fileChange{Path: path, Before: beforeContent, Deleted: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
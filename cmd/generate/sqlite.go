@@ -0,0 +1,150 @@
+package generate
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+	"gorm.io/gorm"
+)
+
+// sqliteColumnInfo pragma_table_info 的结果行
+type sqliteColumnInfo struct {
+	Cid       int            `gorm:"column:cid"`
+	Name      string         `gorm:"column:name"`
+	Type      string         `gorm:"column:type"`
+	NotNull   int            `gorm:"column:notnull"`
+	DfltValue sql.NullString `gorm:"column:dflt_value"`
+	Pk        int            `gorm:"column:pk"` // 主键中的位置，从 1 开始，非主键为 0
+}
+
+// sqliteIndexInfo pragma_index_list 的结果行
+type sqliteIndexInfo struct {
+	Seq    int    `gorm:"column:seq"`
+	Name   string `gorm:"column:name"`
+	Unique int    `gorm:"column:unique"`
+	Origin string `gorm:"column:origin"` // c: CREATE INDEX，u: UNIQUE 约束，pk: 主键
+}
+
+// sqliteDialector 根据 sqlite:// 之后的连接串创建 gorm Dialector，相对路径基于 baseDir。
+// 数据库文件不存在时 SQLite 会静默创建空库，这里提前校验以免生成时报找不到表。
+func sqliteDialector(connStr, baseDir string) (gorm.Dialector, error) {
+	dbFile, query, hasQuery := strings.Cut(connStr, "?")
+	if dbFile == "" {
+		return nil, fmt.Errorf("sqlite database file is empty")
+	}
+	if !filepath.IsAbs(dbFile) {
+		dbFile = filepath.Join(baseDir, dbFile)
+	}
+	if _, err := os.Stat(dbFile); err != nil {
+		return nil, fmt.Errorf("sqlite database file does not exist: %s", dbFile)
+	}
+	if hasQuery {
+		dbFile += "?" + query
+	}
+	return openSQLite(dbFile)
+}
+
+// loadSQLiteTable 通过 PRAGMA 内省 SQLite 表结构：pragma_table_info 获取列与主键，
+// pragma_index_list / pragma_index_info 获取索引
+func loadSQLiteTable(db *gorm.DB, tableName string) (*TableSchema, error) {
+	var columns []sqliteColumnInfo
+	if err := db.Raw("SELECT cid, name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?)", tableName).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("get table %s columns error: %v", tableName, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found in database", tableName)
+	}
+
+	var indexList []sqliteIndexInfo
+	if err := db.Raw("SELECT seq, name, \"unique\", origin FROM pragma_index_list(?)", tableName).Scan(&indexList).Error; err != nil {
+		return nil, fmt.Errorf("get table %s indexes error: %v", tableName, err)
+	}
+	// pragma_index_list 的顺序与建表顺序无关，按索引名排序保证多次生成结果一致
+	sort.Slice(indexList, func(i, j int) bool { return indexList[i].Name < indexList[j].Name })
+
+	var indexes []ddlIndex
+	for _, item := range indexList {
+		if item.Origin == "pk" {
+			continue
+		}
+		var indexColumns []string
+		if err := db.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", item.Name).Scan(&indexColumns).Error; err != nil {
+			return nil, fmt.Errorf("get index %s columns error: %v", item.Name, err)
+		}
+		indexes = append(indexes, sqliteIndex(item, indexColumns))
+	}
+	return buildSQLiteTable(tableName, columns, indexes), nil
+}
+
+// sqliteIndex 转换索引定义，列级 UNIQUE 约束生成的 sqlite_autoindex_* 与 DDL 解析保持一致，以列名作为索引名
+func sqliteIndex(item sqliteIndexInfo, columns []string) ddlIndex {
+	index := ddlIndex{name: item.Name, columns: columns, isUnique: item.Unique == 1}
+	if item.Origin == "u" && len(columns) == 1 && strings.HasPrefix(item.Name, "sqlite_autoindex_") {
+		index.name = columns[0]
+	}
+	return index
+}
+
+// buildSQLiteTable 将 PRAGMA 结果转换为表结构，SQLite 不支持列注释与表注释，二者均为空
+func buildSQLiteTable(tableName string, columns []sqliteColumnInfo, indexes []ddlIndex) *TableSchema {
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Cid < columns[j].Cid })
+	table := &TableSchema{TableName: tableName}
+	for _, item := range columns {
+		column := ColumnSchema{
+			FieldName:    gutil.SnakeToPascal(item.Name),
+			ColumnName:   item.Name,
+			ColumnType:   normalizeColumnType(strings.ToLower(item.Type)),
+			IsNullable:   item.NotNull == 0 && item.Pk == 0,
+			IsPrimaryKey: item.Pk > 0,
+		}
+		column.FieldType = SQLiteColumnGoType(column.ColumnType)
+		if item.DfltValue.Valid {
+			column.HasDefault = !strings.EqualFold(item.DfltValue.String, "NULL")
+			// dflt_value 为默认值的 SQL 表达式文本，如 'abc'、0、CURRENT_TIMESTAMP
+			if tokens, err := tokenizeDDL(item.DfltValue.String); err == nil {
+				column.DefaultValue, _ = parseDefaultValue(tokens, 0)
+			}
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	applyIndexes(table, indexes)
+	return table
+}
+
+// SQLiteColumnGoType 根据 SQLite 声明类型推导 Go 类型：
+//   - 可识别的类型与 MySQL / PostgreSQL 保持一致，SQLite 的 INTEGER 与 REAL 均按 8 字节存储，映射为 int64、float64
+//   - 其余类型按 SQLite 类型亲和性规则推导：含 INT 为整数，含 CHAR/CLOB/TEXT 为字符串，含 BLOB 为 []byte，含 REAL/FLOA/DOUB 为浮点数
+//   - 未声明类型或无法识别的类型按 string 处理
+func SQLiteColumnGoType(columnType string) string {
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	baseType := columnType
+	if idx := strings.IndexAny(baseType, " ("); idx >= 0 {
+		baseType = baseType[:idx]
+	}
+	if _, ok := ddlGoTypeMap[baseType]; ok {
+		goType := ColumnGoType(columnType)
+		switch goType {
+		case "int":
+			return "int64"
+		case "float32":
+			return "float64"
+		}
+		return goType
+	}
+	switch {
+	case strings.Contains(columnType, "int"):
+		return "int64"
+	case strings.Contains(columnType, "char"), strings.Contains(columnType, "clob"), strings.Contains(columnType, "text"):
+		return "string"
+	case strings.Contains(columnType, "blob"):
+		return "[]byte"
+	case strings.Contains(columnType, "real"), strings.Contains(columnType, "floa"), strings.Contains(columnType, "doub"):
+		return "float64"
+	}
+	return "string"
+}
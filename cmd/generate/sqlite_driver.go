@@ -0,0 +1,11 @@
+package generate
+
+import (
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// openSQLite 使用纯 Go 实现的 SQLite 驱动（无需 cgo）打开数据库文件
+func openSQLite(dsn string) (gorm.Dialector, error) {
+	return sqlite.Open(dsn), nil
+}
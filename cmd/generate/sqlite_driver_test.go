@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// TestLoadSQLiteTable 基于真实的 SQLite 数据库文件内省表结构
+func TestLoadSQLiteTable(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "demo.db")
+	dialector, err := openSQLite(dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite error: %v", err)
+	}
+	statements := []string{
+		`CREATE TABLE user_login_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			login_ip VARCHAR(64) NOT NULL DEFAULT '' UNIQUE,
+			login_time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX idx_user_id ON user_login_log (user_id)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("exec %q error: %v", statement, err)
+		}
+	}
+
+	table, err := loadSQLiteTable(db, "user_login_log")
+	if err != nil {
+		t.Fatalf("loadSQLiteTable error: %v", err)
+	}
+	if col := findColumn(t, table, "id"); !col.IsPrimaryKey || col.FieldType != "int64" {
+		t.Errorf("id column = %+v", col)
+	}
+	if col := findColumn(t, table, "user_id"); col.IndexName != "idx_user_id" || col.IsNullable {
+		t.Errorf("user_id column = %+v", col)
+	}
+	if col := findColumn(t, table, "login_ip"); col.IndexName != "login_ip" || !col.IsUniqueIndex {
+		t.Errorf("login_ip column = %+v", col)
+	}
+	if col := findColumn(t, table, "login_time"); col.FieldType != "time.Time" || col.DefaultValue != "CURRENT_TIMESTAMP" {
+		t.Errorf("login_time column = %+v", col)
+	}
+	if _, err := loadSQLiteTable(db, "not_exist"); err == nil {
+		t.Errorf("loadSQLiteTable(not_exist) should fail")
+	}
+}
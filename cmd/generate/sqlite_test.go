@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteColumnGoType(t *testing.T) {
+	tests := []struct {
+		columnType string
+		want       string
+	}{
+		{"INTEGER", "int64"},
+		{"int", "int64"},
+		{"bigint unsigned", "uint"},
+		{"tinyint(1)", "int8"},
+		{"unsigned big int", "int64"},
+		{"varchar(64)", "string"},
+		{"nvarchar(100)", "string"},
+		{"CLOB", "string"},
+		{"TEXT", "string"},
+		{"REAL", "float64"},
+		{"double precision", "float64"},
+		{"FLOAT", "float64"},
+		{"decimal(10,2)", "float64"},
+		{"BLOB", "[]byte"},
+		{"DATETIME", "time.Time"},
+		{"boolean", "bool"},
+		{"", "string"},
+	}
+	for _, tt := range tests {
+		if got := SQLiteColumnGoType(tt.columnType); got != tt.want {
+			t.Errorf("SQLiteColumnGoType(%q) = %q, want %q", tt.columnType, got, tt.want)
+		}
+	}
+}
+
+// TestBuildSQLiteTable PRAGMA 结果到表结构的转换
+func TestBuildSQLiteTable(t *testing.T) {
+	columns := []sqliteColumnInfo{
+		{Cid: 1, Name: "user_id", Type: "INTEGER", NotNull: 1},
+		{Cid: 0, Name: "id", Type: "INTEGER", Pk: 1},
+		{Cid: 2, Name: "login_ip", Type: "VARCHAR(64)", DfltValue: sql.NullString{String: "'127.0.0.1'", Valid: true}},
+		{Cid: 3, Name: "login_time", Type: "DATETIME", NotNull: 1, DfltValue: sql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}},
+		{Cid: 4, Name: "remark", Type: "TEXT", DfltValue: sql.NullString{String: "NULL", Valid: true}},
+	}
+	indexes := []ddlIndex{
+		sqliteIndex(sqliteIndexInfo{Name: "idx_user_id", Origin: "c"}, []string{"user_id", "login_time"}),
+		sqliteIndex(sqliteIndexInfo{Name: "sqlite_autoindex_user_login_log_1", Unique: 1, Origin: "u"}, []string{"login_ip"}),
+	}
+	table := buildSQLiteTable("user_login_log", columns, indexes)
+
+	var names []string
+	for _, column := range table.Columns {
+		names = append(names, column.ColumnName)
+	}
+	if got := strings.Join(names, ","); got != "id,user_id,login_ip,login_time,remark" {
+		t.Fatalf("columns = %s, want ordered by cid", got)
+	}
+
+	tests := []struct {
+		column       string
+		fieldName    string
+		fieldType    string
+		isNullable   bool
+		defaultValue string
+		indexName    string
+		isUnique     bool
+	}{
+		{"id", "Id", "int64", false, "", "", false},
+		{"user_id", "UserId", "int64", false, "", "idx_user_id", false},
+		{"login_ip", "LoginIp", "string", true, "127.0.0.1", "login_ip", true},
+		{"login_time", "LoginTime", "time.Time", false, "CURRENT_TIMESTAMP", "idx_user_id", false},
+		{"remark", "Remark", "string", true, "", "", false},
+	}
+	for _, tt := range tests {
+		col := findColumn(t, table, tt.column)
+		if col.FieldName != tt.fieldName || col.FieldType != tt.fieldType || col.IsNullable != tt.isNullable ||
+			col.DefaultValue != tt.defaultValue || col.IndexName != tt.indexName || col.IsUniqueIndex != tt.isUnique {
+			t.Errorf("column %s = %+v", tt.column, col)
+		}
+	}
+	if col := findColumn(t, table, "id"); !col.IsPrimaryKey {
+		t.Errorf("id should be primary key")
+	}
+}
+
+// TestParseDDLSQLite SQLite 方言的 DDL 与 PRAGMA 内省使用相同的类型映射
+func TestParseDDLSQLite(t *testing.T) {
+	ddl := `CREATE TABLE user_login_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    score REAL,
+    login_time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+	tables, err := ParseDDL(ddl, DBTypeSQLite)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	for column, want := range map[string]string{"id": "int64", "user_id": "int64", "score": "float64", "login_time": "time.Time"} {
+		if got := findColumn(t, tables[0], column).FieldType; got != want {
+			t.Errorf("column %s type = %s, want %s", column, got, want)
+		}
+	}
+}
+
+func TestSQLiteDialectorMissingFile(t *testing.T) {
+	baseDir := t.TempDir()
+	_, err := sqliteDialector("scripts/demo.db?_pragma=foreign_keys(1)", baseDir)
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(baseDir, "scripts", "demo.db")) {
+		t.Fatalf("sqliteDialector error = %v, want missing file error with resolved path", err)
+	}
+
+	dbFile := filepath.Join(baseDir, "demo.db")
+	if err := os.WriteFile(dbFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqliteDialector(dbFile, baseDir); err != nil && strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("sqliteDialector(%s) error = %v", dbFile, err)
+	}
+}
@@ -0,0 +1,757 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/morehao/golib/codegen"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync generated model/object/dao fields with the current table schema",
+	Long: `Re-introspect the tables configured in the model section and update only the generated parts:
+model Entity fields, object BaseInfo fields, and dao Cond fields with their BuildCondition clauses.
+Custom fields, methods and comments are preserved.`,
+	Run: runGenerate(genSync),
+}
+
+// fieldSyncResult 单个文件的字段同步结果
+type fieldSyncResult struct {
+	Added   []string
+	Removed []string
+	Retyped []string
+}
+
+func (r *fieldSyncResult) isEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Retyped) == 0
+}
+
+// syncBlockItem 结构体字段或 BuildCondition 中的条件语句，位置均为字节偏移
+type syncBlockItem struct {
+	Key          string // 字段名
+	LineStart    int    // 所在行（含文档注释）的起始偏移，删除时使用
+	ReplaceStart int    // 所在行（不含文档注释）的起始偏移，替换时使用
+	LineEnd      int    // 所在行结束换行符之后的偏移
+	Text         string // ReplaceStart 到 LineEnd 的源码
+	Compare      string // 判断是否变更的比较文本
+	TypeText     string // 字段类型，仅结构体字段
+}
+
+// textEdit 源码文本替换
+type textEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+func genSync() error {
+	modelCfg := cfg.Model
+	single := TableConfig{
+		TableName:   modelCfg.TableName,
+		PackageName: modelCfg.PackageName,
+		Description: modelCfg.Description,
+	}
+	tables, _, resolveErr := resolveTables(single, modelCfg.Tables, modelCfg.TablePrefix, tablePatterns)
+	if resolveErr != nil {
+		return resolveErr
+	}
+	setBatchTables(tables)
+	for _, table := range tables {
+		if err := syncTable(ModelConfig{
+			PackageName: table.PackageName,
+			Description: table.Description,
+			TableName:   table.TableName,
+			TablePrefix: modelCfg.TablePrefix,
+			Relations:   resolveRelationConfig(table, modelCfg.Relations),
+			PageList:    resolvePageListConfig(table, modelCfg.PageList),
+		}); err != nil {
+			return fmt.Errorf("sync table %s error: %v", table.TableName, err)
+		}
+	}
+	return nil
+}
+
+// syncTable 按最新表结构渲染 model/object/dao 模板，并将其中生成的字段与条件语句合并到已有文件
+func syncTable(modelGenCfg ModelConfig) error {
+	fmt.Printf("[Sync] Syncing fields based on table: %s\n", modelGenCfg.TableName)
+
+	plan, planErr := planModelTable(modelGenCfg)
+	if planErr != nil {
+		return planErr
+	}
+	structName := plan.analysisRes.StructName
+	entityName := structName + "Entity"
+
+	// 旧 Entity 中由表字段生成的字段名，object/dao 中同名字段视为生成字段
+	oldEntityFields := make(map[string]struct{})
+	if oldModelSrc, readErr := os.ReadFile(plan.modelFilepath); readErr == nil {
+		items, _, collectErr := collectStructItems(oldModelSrc, entityName)
+		if collectErr != nil {
+			return collectErr
+		}
+		for _, item := range items {
+			if isEntityColumnField(item) {
+				oldEntityFields[item.Key] = struct{}{}
+			}
+		}
+	}
+
+	var enumTypeNames []string
+	for _, field := range buildModelFields(plan.analysisRes.Columns, structName) {
+		if field.EnumTypeName != "" {
+			enumTypeNames = append(enumTypeNames, field.EnumTypeName)
+		}
+	}
+
+	for i, params := range plan.genParamsList {
+		var structTargets []string
+		switch plan.layerNames[i] {
+		case codegen.LayerNameModel:
+			structTargets = []string{entityName}
+		case codegen.LayerNameObject:
+			structTargets = []string{structName + "BaseInfo"}
+		case codegen.LayerNameDao:
+			structTargets = []string{structName + "Cond"}
+		default:
+			continue
+		}
+
+		targetFilepath := filepath.Join(params.TargetDir, params.TargetFileName)
+		relPath, relErr := filepath.Rel(cfg.appInfo.ProjectRootPath, targetFilepath)
+		if relErr != nil {
+			relPath = targetFilepath
+		}
+		src, readErr := os.ReadFile(targetFilepath)
+		if os.IsNotExist(readErr) {
+			fmt.Printf("[Sync] %s: file not found, run generate model first\n", relPath)
+			continue
+		}
+		if readErr != nil {
+			return fmt.Errorf("read %s error: %v", relPath, readErr)
+		}
+
+		var renderBuf bytes.Buffer
+		if err := params.Template.Execute(&renderBuf, params.ExtraParams); err != nil {
+			return fmt.Errorf("render %s error: %v", relPath, err)
+		}
+		rendered := renderBuf.Bytes()
+		if formatted, formatErr := format.Source(rendered); formatErr == nil {
+			rendered = formatted
+		}
+
+		result := &fieldSyncResult{}
+		newSrc := src
+		for _, target := range structTargets {
+			isManaged := func(item syncBlockItem) bool {
+				if target == entityName {
+					return isEntityColumnField(item)
+				}
+				_, ok := oldEntityFields[item.Key]
+				return ok
+			}
+			var syncErr error
+			newSrc, syncErr = syncStructFields(newSrc, rendered, target, isManaged, result)
+			if syncErr != nil {
+				return fmt.Errorf("sync %s in %s error: %v", target, relPath, syncErr)
+			}
+		}
+		if plan.layerNames[i] == codegen.LayerNameModel {
+			var syncErr error
+			newSrc, syncErr = syncEnumDecls(newSrc, rendered, enumTypeNames, result)
+			if syncErr != nil {
+				return fmt.Errorf("sync enum declarations in %s error: %v", relPath, syncErr)
+			}
+		}
+		if plan.layerNames[i] == codegen.LayerNameDao {
+			var syncErr error
+			newSrc, syncErr = syncBuildCondition(newSrc, rendered, structName+"Cond", oldEntityFields)
+			if syncErr != nil {
+				return fmt.Errorf("sync BuildCondition in %s error: %v", relPath, syncErr)
+			}
+		}
+
+		if bytes.Equal(newSrc, src) {
+			fmt.Printf("[Sync] %s: up to date\n", relPath)
+			continue
+		}
+		newSrc, importErr := syncFieldImports(newSrc)
+		if importErr != nil {
+			return fmt.Errorf("fix imports in %s error: %v", relPath, importErr)
+		}
+		formatted, formatErr := format.Source(newSrc)
+		if formatErr != nil {
+			return fmt.Errorf("format %s error: %v", relPath, formatErr)
+		}
+		if err := trackFiles(targetFilepath); err != nil {
+			return err
+		}
+		if err := os.WriteFile(targetFilepath, formatted, 0644); err != nil {
+			return fmt.Errorf("write %s error: %v", relPath, err)
+		}
+		printFieldSyncResult(relPath, result)
+	}
+	return nil
+}
+
+// isEntityColumnField 判断 Entity 字段是否由表字段生成：内嵌的 gorm.Model 或带 gorm column 标签的字段
+func isEntityColumnField(item syncBlockItem) bool {
+	return item.Key == "Model" && item.TypeText == "gorm.Model" || strings.Contains(item.Compare, `gorm:"column:`)
+}
+
+// syncStructFields 用渲染结果中的字段更新已有文件中的结构体：托管字段按渲染结果增删改，其余字段保持不变
+func syncStructFields(src, rendered []byte, structName string, isManaged func(syncBlockItem) bool, result *fieldSyncResult) ([]byte, error) {
+	existingItems, closeOffset, collectErr := collectStructItems(src, structName)
+	if collectErr != nil {
+		return nil, collectErr
+	}
+	renderedItems, _, collectErr := collectStructItems(rendered, structName)
+	if collectErr != nil {
+		return nil, fmt.Errorf("rendered template: %v", collectErr)
+	}
+	var managedItems []syncBlockItem
+	for _, item := range existingItems {
+		if isManaged(item) {
+			managedItems = append(managedItems, item)
+		}
+	}
+	renderedKeys := make(map[string]struct{}, len(renderedItems))
+	for _, item := range renderedItems {
+		renderedKeys[item.Key] = struct{}{}
+	}
+	// 渲染结果中存在但未被识别为托管的同名字段（如手工添加）不重复生成
+	for _, item := range existingItems {
+		if !isManaged(item) {
+			if _, ok := renderedKeys[item.Key]; ok {
+				managedItems = append(managedItems, item)
+			}
+		}
+	}
+	sort.Slice(managedItems, func(i, j int) bool {
+		return managedItems[i].LineStart < managedItems[j].LineStart
+	})
+
+	edits, added, removed, changed := diffBlockItems(managedItems, renderedItems, closeOffset)
+	result.Added = append(result.Added, added...)
+	result.Removed = append(result.Removed, removed...)
+	existingMap := make(map[string]syncBlockItem, len(managedItems))
+	for _, item := range managedItems {
+		existingMap[item.Key] = item
+	}
+	for _, item := range renderedItems {
+		if _, ok := changed[item.Key]; ok && existingMap[item.Key].TypeText != item.TypeText {
+			result.Retyped = append(result.Retyped, fmt.Sprintf("%s(%s -> %s)", item.Key, existingMap[item.Key].TypeText, item.TypeText))
+		}
+	}
+	return applyTextEdits(src, edits), nil
+}
+
+// syncEnumDecls 将渲染结果中新增枚举类型的声明（类型、常量、取值映射与方法）追加到已有文件末尾，
+// 已存在的同名声明保持不变，避免字段改为枚举类型后缺少类型定义
+func syncEnumDecls(src, rendered []byte, enumTypeNames []string, result *fieldSyncResult) ([]byte, error) {
+	if len(enumTypeNames) == 0 {
+		return src, nil
+	}
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	existingNames := make(map[string]struct{})
+	for _, decl := range file.Decls {
+		for _, name := range topLevelDeclNames(decl) {
+			existingNames[name] = struct{}{}
+		}
+	}
+
+	renderedFset := token.NewFileSet()
+	renderedFile, parseErr := parser.ParseFile(renderedFset, "", rendered, parser.ParseComments)
+	if parseErr != nil {
+		return nil, fmt.Errorf("rendered template: %v", parseErr)
+	}
+	var appendText strings.Builder
+	for _, typeName := range enumTypeNames {
+		if _, ok := existingNames[typeName]; ok {
+			continue
+		}
+		for _, decl := range renderedFile.Decls {
+			if !isEnumDecl(decl, typeName) {
+				continue
+			}
+			start := renderedFset.Position(decl.Pos()).Offset
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Doc != nil {
+					start = renderedFset.Position(d.Doc.Pos()).Offset
+				}
+			case *ast.FuncDecl:
+				if d.Doc != nil {
+					start = renderedFset.Position(d.Doc.Pos()).Offset
+				}
+			}
+			appendText.WriteString("\n")
+			appendText.Write(rendered[start:renderedFset.Position(decl.End()).Offset])
+			appendText.WriteString("\n")
+		}
+		result.Added = append(result.Added, "type "+typeName)
+	}
+	if appendText.Len() == 0 {
+		return src, nil
+	}
+	return append(bytes.TrimRight(src, "\n"), append([]byte("\n"), appendText.String()...)...), nil
+}
+
+// isEnumDecl 判断顶层声明是否属于枚举类型 typeName：类型定义、该类型的常量、<typeName>Map 变量或其方法
+func isEnumDecl(decl ast.Decl, typeName string) bool {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return false
+		}
+		ident, ok := d.Recv.List[0].Type.(*ast.Ident)
+		return ok && ident.Name == typeName
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if s.Name.Name == typeName {
+					return true
+				}
+			case *ast.ValueSpec:
+				if ident, ok := s.Type.(*ast.Ident); ok && d.Tok == token.CONST && ident.Name == typeName {
+					return true
+				}
+				for _, name := range s.Names {
+					if d.Tok == token.VAR && name.Name == typeName+"Map" {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// topLevelDeclNames 顶层声明定义的名称，方法以 接收者.方法名 表示
+func topLevelDeclNames(decl ast.Decl) []string {
+	var names []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			if ident, ok := d.Recv.List[0].Type.(*ast.Ident); ok {
+				return []string{ident.Name + "." + d.Name.Name}
+			}
+		}
+		return []string{d.Name.Name}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// syncBuildCondition 同步 Cond.BuildCondition 中按字段生成的条件语句（以 c.<字段> 为条件的 if 语句）
+func syncBuildCondition(src, rendered []byte, condName string, oldEntityFields map[string]struct{}) ([]byte, error) {
+	existingItems, closeOffset, found, collectErr := collectConditionItems(src, condName)
+	if collectErr != nil {
+		return nil, collectErr
+	}
+	if !found {
+		return src, nil
+	}
+	renderedItems, _, _, collectErr := collectConditionItems(rendered, condName)
+	if collectErr != nil {
+		return nil, fmt.Errorf("rendered template: %v", collectErr)
+	}
+	renderedKeys := make(map[string]struct{}, len(renderedItems))
+	for _, item := range renderedItems {
+		renderedKeys[item.Key] = struct{}{}
+	}
+	var managedItems []syncBlockItem
+	for _, item := range existingItems {
+		_, wasGenerated := oldEntityFields[item.Key]
+		_, isGenerated := renderedKeys[item.Key]
+		if wasGenerated || isGenerated {
+			managedItems = append(managedItems, item)
+		}
+	}
+	edits, _, _, _ := diffBlockItems(managedItems, renderedItems, closeOffset)
+	return applyTextEdits(src, edits), nil
+}
+
+// diffBlockItems 对比托管项与渲染项，生成文本编辑：
+//   - 渲染结果中不存在的托管项删除
+//   - 双方都存在但内容不同的项替换为渲染结果
+//   - 新增项插入到渲染顺序中前一个已存在项之后，没有前一项时插入到首个托管项之前（或块末尾）
+func diffBlockItems(existingItems, renderedItems []syncBlockItem, closeOffset int) ([]textEdit, []string, []string, map[string]struct{}) {
+	existingMap := make(map[string]syncBlockItem, len(existingItems))
+	for _, item := range existingItems {
+		existingMap[item.Key] = item
+	}
+	renderedMap := make(map[string]syncBlockItem, len(renderedItems))
+	for _, item := range renderedItems {
+		renderedMap[item.Key] = item
+	}
+
+	var edits []textEdit
+	var added, removed []string
+	changed := make(map[string]struct{})
+	for _, item := range existingItems {
+		renderedItem, ok := renderedMap[item.Key]
+		if !ok {
+			edits = append(edits, textEdit{Start: item.LineStart, End: item.LineEnd})
+			removed = append(removed, item.Key)
+			continue
+		}
+		if renderedItem.Compare != item.Compare {
+			edits = append(edits, textEdit{Start: item.ReplaceStart, End: item.LineEnd, Text: renderedItem.Text})
+			changed[item.Key] = struct{}{}
+		}
+	}
+
+	defaultOffset := closeOffset
+	if len(existingItems) > 0 {
+		defaultOffset = existingItems[0].LineStart
+	}
+	insertTexts := make(map[int]string)
+	var insertOffsets []int
+	prevOffset := -1
+	for _, item := range renderedItems {
+		if existingItem, ok := existingMap[item.Key]; ok {
+			prevOffset = existingItem.LineEnd
+			continue
+		}
+		offset := prevOffset
+		if offset < 0 {
+			offset = defaultOffset
+		}
+		if _, ok := insertTexts[offset]; !ok {
+			insertOffsets = append(insertOffsets, offset)
+		}
+		insertTexts[offset] += item.Text
+		if item.TypeText != "" {
+			added = append(added, fmt.Sprintf("%s(%s)", item.Key, item.TypeText))
+		} else {
+			added = append(added, item.Key)
+		}
+	}
+	for _, offset := range insertOffsets {
+		edits = append(edits, textEdit{Start: offset, End: offset, Text: insertTexts[offset]})
+	}
+	return edits, added, removed, changed
+}
+
+// applyTextEdits 由后向前应用文本编辑，同一位置的插入先于替换应用，保证偏移有效
+func applyTextEdits(src []byte, edits []textEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Start != edits[j].Start {
+			return edits[i].Start > edits[j].Start
+		}
+		return edits[i].End > edits[j].End
+	})
+	res := append([]byte(nil), src...)
+	for _, edit := range edits {
+		res = append(res[:edit.Start], append([]byte(edit.Text), res[edit.End:]...)...)
+	}
+	return res
+}
+
+// collectStructItems 收集结构体的字段及其行范围，返回字段列表与右花括号所在行的起始偏移
+func collectStructItems(src []byte, structName string) ([]syncBlockItem, int, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, 0, parseErr
+	}
+	var structType *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != structName {
+			return structType == nil
+		}
+		if st, isStruct := typeSpec.Type.(*ast.StructType); isStruct {
+			structType = st
+		}
+		return false
+	})
+	if structType == nil {
+		return nil, 0, fmt.Errorf("struct %s not found", structName)
+	}
+
+	var items []syncBlockItem
+	for _, field := range structType.Fields.List {
+		key := fieldKey(field)
+		if key == "" {
+			continue
+		}
+		startPos := field.Pos()
+		if field.Doc != nil {
+			startPos = field.Doc.Pos()
+		}
+		endPos := field.End()
+		if field.Comment != nil {
+			endPos = field.Comment.End()
+		}
+		replaceStart := lineStartOffset(src, fset.Position(field.Pos()).Offset)
+		lineEnd := lineEndOffset(src, fset.Position(endPos).Offset)
+		typeText := string(src[fset.Position(field.Type.Pos()).Offset:fset.Position(field.Type.End()).Offset])
+		compare := typeText
+		if field.Tag != nil {
+			compare += " " + field.Tag.Value
+		}
+		items = append(items, syncBlockItem{
+			Key:          key,
+			LineStart:    lineStartOffset(src, fset.Position(startPos).Offset),
+			ReplaceStart: replaceStart,
+			LineEnd:      lineEnd,
+			Text:         string(src[replaceStart:lineEnd]),
+			Compare:      compare,
+			TypeText:     typeText,
+		})
+	}
+	closeOffset := lineStartOffset(src, fset.Position(structType.Fields.Closing).Offset)
+	return items, closeOffset, nil
+}
+
+// collectConditionItems 收集 <condName>.BuildCondition 方法体中以 c.<字段> 为条件的 if 语句
+func collectConditionItems(src []byte, condName string) ([]syncBlockItem, int, bool, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, 0, false, parseErr
+	}
+	var funcDecl *ast.FuncDecl
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "BuildCondition" || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Body == nil {
+			continue
+		}
+		recvType := fn.Recv.List[0].Type
+		if star, isStar := recvType.(*ast.StarExpr); isStar {
+			recvType = star.X
+		}
+		if ident, isIdent := recvType.(*ast.Ident); isIdent && ident.Name == condName {
+			funcDecl = fn
+			break
+		}
+	}
+	if funcDecl == nil {
+		return nil, 0, false, nil
+	}
+	recvName := ""
+	if len(funcDecl.Recv.List[0].Names) > 0 {
+		recvName = funcDecl.Recv.List[0].Names[0].Name
+	}
+
+	var items []syncBlockItem
+	for _, stmt := range funcDecl.Body.List {
+		ifStmt, ok := stmt.(*ast.IfStmt)
+		if !ok {
+			continue
+		}
+		key := conditionFieldKey(ifStmt.Cond, recvName)
+		if key == "" {
+			continue
+		}
+		lineStart := lineStartOffset(src, fset.Position(ifStmt.Pos()).Offset)
+		lineEnd := lineEndOffset(src, fset.Position(ifStmt.End()).Offset)
+		text := string(src[lineStart:lineEnd])
+		items = append(items, syncBlockItem{
+			Key:          key,
+			LineStart:    lineStart,
+			ReplaceStart: lineStart,
+			LineEnd:      lineEnd,
+			Text:         text,
+			Compare:      strings.Join(strings.Fields(text), " "),
+		})
+	}
+	closeOffset := lineStartOffset(src, fset.Position(funcDecl.Body.Rbrace).Offset)
+	return items, closeOffset, true, nil
+}
+
+// conditionFieldKey 提取 if 条件中引用的接收者字段名，如 c.Name != "" 返回 Name
+func conditionFieldKey(cond ast.Expr, recvName string) string {
+	var key string
+	ast.Inspect(cond, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || key != "" {
+			return key == ""
+		}
+		if ident, isIdent := sel.X.(*ast.Ident); isIdent && ident.Name == recvName {
+			key = sel.Sel.Name
+			return false
+		}
+		return true
+	})
+	return key
+}
+
+// fieldKey 字段名，内嵌字段取类型名（如 gorm.Model 取 Model），多名字段声明不参与同步
+func fieldKey(field *ast.Field) string {
+	if len(field.Names) == 1 {
+		return field.Names[0].Name
+	}
+	if len(field.Names) > 1 {
+		return ""
+	}
+	fieldType := field.Type
+	if star, ok := fieldType.(*ast.StarExpr); ok {
+		fieldType = star.X
+	}
+	switch t := fieldType.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+func lineStartOffset(src []byte, offset int) int {
+	return bytes.LastIndexByte(src[:offset], '\n') + 1
+}
+
+func lineEndOffset(src []byte, offset int) int {
+	if idx := bytes.IndexByte(src[offset:], '\n'); idx >= 0 {
+		return offset + idx + 1
+	}
+	return len(src)
+}
+
+// syncFieldImports 根据使用情况增删 fieldTypeImportMap 与 type_overrides 中的导入（如 time、encoding/json）与枚举使用的 fmt，其余导入保持不变
+func syncFieldImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, parseErr := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	usedNames := make(map[string]struct{})
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, isIdent := sel.X.(*ast.Ident); isIdent {
+				usedNames[ident.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	importedSpecs := make(map[string]*ast.ImportSpec)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		importedSpecs[importPath] = spec
+	}
+
+	var edits []textEdit
+	var missing []string
+	// 整体删除的 import 声明（仅含一个被删除的导入）对应的 edits 下标
+	removedDecls := make(map[*ast.GenDecl]int)
+	importInfos := make([]FieldTypeImport, 0, len(fieldTypeImportMap)+1)
+	seenPaths := make(map[string]struct{}, len(fieldTypeImportMap))
+	candidates := typeOverrideImports()
+	for _, importInfo := range fieldTypeImportMap {
+		candidates = append(candidates, importInfo)
+	}
+	for _, importInfo := range candidates {
+		// 多个类型共用同一导入（如 sql.NullString、sql.NullInt64），只处理一次
+		if _, seen := seenPaths[importInfo.ImportPath]; seen {
+			continue
+		}
+		seenPaths[importInfo.ImportPath] = struct{}{}
+		importInfos = append(importInfos, importInfo)
+	}
+	// 枚举类型的 String 方法使用 fmt
+	importInfos = append(importInfos, FieldTypeImport{ImportPath: "fmt", ImportName: "fmt"})
+	for _, importInfo := range importInfos {
+		_, used := usedNames[importInfo.ImportName]
+		spec, imported := importedSpecs[importInfo.ImportPath]
+		switch {
+		case used && !imported:
+			missing = append(missing, importInfo.ImportPath)
+		case !used && imported && spec.Name == nil:
+			start := lineStartOffset(src, fset.Position(spec.Pos()).Offset)
+			end := lineEndOffset(src, fset.Position(spec.End()).Offset)
+			genDecl := importDeclOf(file, spec)
+			if genDecl != nil && len(genDecl.Specs) == 1 {
+				start = lineStartOffset(src, fset.Position(genDecl.Pos()).Offset)
+				end = lineEndOffset(src, fset.Position(genDecl.End()).Offset)
+				removedDecls[genDecl] = len(edits)
+			}
+			edits = append(edits, textEdit{Start: start, End: end})
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		var specText strings.Builder
+		for _, importPath := range missing {
+			specText.WriteString("\t" + strconv.Quote(importPath) + "\n")
+		}
+		var groupDecl *ast.GenDecl
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT && genDecl.Lparen.IsValid() {
+				groupDecl = genDecl
+				break
+			}
+		}
+		if idx, removed := removedDecls[groupDecl]; groupDecl != nil && removed {
+			// 分组中唯一的导入被删除，新导入替换整个分组，避免与删除的区间重叠
+			edits[idx].Text = "import (\n" + specText.String() + ")\n"
+		} else if groupDecl != nil {
+			offset := lineEndOffset(src, fset.Position(groupDecl.Lparen).Offset)
+			edits = append(edits, textEdit{Start: offset, End: offset, Text: specText.String()})
+		} else {
+			offset := lineEndOffset(src, fset.Position(file.Name.End()).Offset)
+			edits = append(edits, textEdit{Start: offset, End: offset, Text: "\nimport (\n" + specText.String() + ")\n"})
+		}
+	}
+	return applyTextEdits(src, edits), nil
+}
+
+func importDeclOf(file *ast.File, spec *ast.ImportSpec) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		for _, s := range genDecl.Specs {
+			if s == spec {
+				return genDecl
+			}
+		}
+	}
+	return nil
+}
+
+// printFieldSyncResult 输出单个文件的字段变更
+func printFieldSyncResult(relPath string, result *fieldSyncResult) {
+	if result.isEmpty() {
+		fmt.Printf("[Sync] %s: updated\n", relPath)
+		return
+	}
+	fmt.Printf("[Sync] %s:\n", relPath)
+	if len(result.Added) > 0 {
+		fmt.Printf("  added:   %s\n", strings.Join(result.Added, ", "))
+	}
+	if len(result.Removed) > 0 {
+		fmt.Printf("  removed: %s\n", strings.Join(result.Removed, ", "))
+	}
+	if len(result.Retyped) > 0 {
+		fmt.Printf("  retyped: %s\n", strings.Join(result.Retyped, ", "))
+	}
+}
@@ -0,0 +1,153 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSyncModelFields 表结构变更后同步 model/object/dao 字段，保留自定义字段、方法与注释
+func TestSyncModelFields(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+	ddlContent, err := os.ReadFile(ddlFile)
+	if err != nil {
+		t.Fatalf("read ddl: %v", err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+model:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+	if _, err := ExecuteCommand(Cmd, "model", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+		t.Fatalf("Failed to execute model command: %v", err)
+	}
+
+	modelFile := filepath.Join("apps", "demoapp", "model", "user_login_log.go")
+	daoFile := filepath.Join("apps", "demoapp", "dao", "user_login_log.go")
+	objectFile := filepath.Join("apps", "demoapp", "object", "objloginlog", "user_login_log.go")
+	editFile(t, modelFile, map[string]string{
+		"\tLoginIp ":       "\t// LoginIp 客户端地址\n\tLoginIp ",
+		"\tDeletedBy uint": "\tExtra string `gorm:\"-\"` // 扩展信息，不落库\n\tDeletedBy uint",
+	})
+	appendFile(t, modelFile, "\n// IsLocal 是否本机登录\nfunc (e *UserLoginLogEntity) IsLocal() bool {\n\treturn e.LoginIp == \"127.0.0.1\"\n}\n")
+	editFile(t, daoFile, map[string]string{
+		"\tDeletedBy uint\n}":     "\tDeletedBy uint\n\tLoginIps  []string // 自定义条件\n}",
+		"\tif c.DeletedBy != 0 {": "\tif len(c.LoginIps) > 0 {\n\t\tdb.Where(tableName+\".login_ip IN ?\", c.LoginIps)\n\t}\n\tif c.DeletedBy != 0 {",
+	})
+
+	// 修改列类型、删除列、新增列
+	alteredDDL := strings.NewReplacer(
+		"`user_id`    bigint unsigned NOT NULL COMMENT '用户ID'", "`user_id`    varchar(64) NOT NULL COMMENT '用户ID'",
+		"`user_agent` varchar(512)      DEFAULT NULL COMMENT '用户代理信息',", "`device`     varchar(64)       DEFAULT NULL COMMENT '登录设备',",
+		"`login_time` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '登录时间'", "`login_time` bigint NOT NULL DEFAULT 0 COMMENT '登录时间'",
+	).Replace(string(ddlContent))
+	alteredDDLFile := filepath.Join(t.TempDir(), "altered.sql")
+	if err := os.WriteFile(alteredDDLFile, []byte(alteredDDL), 0644); err != nil {
+		t.Fatalf("write altered ddl: %v", err)
+	}
+
+	resetGenerateState()
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "sync", "--app", "demoapp", "--ddl", alteredDDLFile); err != nil {
+			t.Errorf("Failed to execute sync command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	for _, want := range []string{
+		"[Sync] apps/demoapp/model/user_login_log.go:",
+		"added:   Device(string)",
+		"removed: UserAgent",
+		"retyped: UserID(uint -> string), LoginTime(time.Time -> int64)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("sync output missing %q:\n%s", want, output)
+		}
+	}
+
+	modelSrc := readFile(t, modelFile)
+	for _, want := range []string{
+		"// LoginIp 客户端地址\n\tLoginIp ",
+		"Device    string",
+		"Extra     string `gorm:\"-\"` // 扩展信息，不落库",
+		"func (e *UserLoginLogEntity) IsLocal() bool",
+		"UserID string",
+		"LoginTime int64",
+	} {
+		if !strings.Contains(modelSrc, want) {
+			t.Errorf("model file missing %q:\n%s", want, modelSrc)
+		}
+	}
+	if strings.Contains(modelSrc, "UserAgent") || strings.Contains(modelSrc, "\"time\"") {
+		t.Errorf("model file should drop UserAgent and unused time import:\n%s", modelSrc)
+	}
+
+	daoSrc := readFile(t, daoFile)
+	for _, want := range []string{
+		"LoginIps  []string // 自定义条件",
+		"if len(c.LoginIps) > 0 {",
+		"if c.Device != \"\" {",
+		"if c.UserID != \"\" {",
+		"if c.LoginTime != 0 {",
+	} {
+		if !strings.Contains(daoSrc, want) {
+			t.Errorf("dao file missing %q:\n%s", want, daoSrc)
+		}
+	}
+	if strings.Contains(daoSrc, "UserAgent") || strings.Contains(daoSrc, "IsZero") {
+		t.Errorf("dao file should drop UserAgent condition:\n%s", daoSrc)
+	}
+
+	objectSrc := readFile(t, objectFile)
+	if !strings.Contains(objectSrc, "Device") || strings.Contains(objectSrc, "UserAgent") {
+		t.Errorf("object file not synced:\n%s", objectSrc)
+	}
+
+	// 再次同步不产生变更
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "sync", "--app", "demoapp", "--ddl", alteredDDLFile); err != nil {
+			t.Errorf("Failed to execute sync command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if count := strings.Count(output, ": up to date"); count != 3 {
+		t.Errorf("second sync should report 3 up-to-date files, got %d:\n%s", count, output)
+	}
+}
+
+func editFile(t *testing.T, path string, replacements map[string]string) {
+	t.Helper()
+	content := readFile(t, path)
+	for old, replacement := range replacements {
+		if !strings.Contains(content, old) {
+			t.Fatalf("%s does not contain %q:\n%s", path, old, content)
+		}
+		content = strings.Replace(content, old, replacement, 1)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(readFile(t, path)+content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(content)
+}
@@ -0,0 +1,248 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+)
+
+// 软删除方式
+const (
+	SoftDeleteGorm = "gorm" // gorm.DeletedAt 时间列（默认），删除时写入删除时间
+	SoftDeleteFlag = "flag" // gorm.io/plugin/soft_delete 的标记列，删除时置为 1
+	SoftDeleteNone = "none" // 不做软删除，直接物理删除
+)
+
+// 系统字段的默认列名，与 gorm.Model 及 gormdao 的约定一致
+const (
+	defaultCreatedAtColumn   = "created_at"
+	defaultUpdatedAtColumn   = "updated_at"
+	defaultDeletedAtColumn   = "deleted_at"
+	defaultCreatedByColumn   = "created_by"
+	defaultUpdatedByColumn   = "updated_by"
+	defaultDeletedByColumn   = "deleted_by"
+	defaultDeletedFlagColumn = "is_deleted"
+)
+
+// softDeleteFlagModule soft_delete 为 flag 时生成代码依赖的模块，脚手架的 go.mod 默认不包含
+const softDeleteFlagModule = "gorm.io/plugin/soft_delete"
+
+// SysFieldParams 系统字段的模板参数，由 sys_fields 配置与表结构确定，表中不存在的字段为 nil
+type SysFieldParams struct {
+	EmbedGormModel bool        // Entity 内嵌 gorm.Model：单列数值主键且时间列、软删除均为 gorm.Model 的默认约定
+	CustomDelete   bool        // DAO 生成按 sys_fields 配置删除的 Delete 方法，覆盖 gormdao 按默认列名的实现
+	CreatedAtField *ModelField // 创建时间字段，内嵌 gorm.Model 时为 CreatedAt
+	UpdatedAtField *ModelField // 更新时间字段，内嵌 gorm.Model 时为 UpdatedAt
+	CreatedByField *ModelField // 创建人字段
+	UpdatedByField *ModelField // 更新人字段
+	DeletedByField *ModelField // 删除人字段，soft_delete 为 none 时为 nil
+}
+
+// currentSysFields 填充默认值后的 sys_fields 配置
+func currentSysFields() SysFieldConfig {
+	if cfg == nil {
+		return resolveSysFields(SysFieldConfig{})
+	}
+	return resolveSysFields(cfg.SysFields)
+}
+
+// resolveSysFields 为未配置的列名与软删除方式填充默认值
+func resolveSysFields(sysFields SysFieldConfig) SysFieldConfig {
+	if sysFields.SoftDelete == "" {
+		sysFields.SoftDelete = SoftDeleteGorm
+	}
+	defaults := []struct {
+		column *string
+		value  string
+	}{
+		{&sysFields.CreatedAt, defaultCreatedAtColumn},
+		{&sysFields.UpdatedAt, defaultUpdatedAtColumn},
+		{&sysFields.CreatedBy, defaultCreatedByColumn},
+		{&sysFields.UpdatedBy, defaultUpdatedByColumn},
+		{&sysFields.DeletedBy, defaultDeletedByColumn},
+	}
+	for _, item := range defaults {
+		if *item.column == "" {
+			*item.column = item.value
+		}
+	}
+	if sysFields.DeletedAt == "" {
+		switch sysFields.SoftDelete {
+		case SoftDeleteGorm:
+			sysFields.DeletedAt = defaultDeletedAtColumn
+		case SoftDeleteFlag:
+			sysFields.DeletedAt = defaultDeletedFlagColumn
+		}
+	}
+	return sysFields
+}
+
+// validateSysFields 校验 sys_fields 配置：软删除方式取值合法，同一列不能同时作为多个系统字段
+func validateSysFields(sysFields SysFieldConfig) error {
+	switch sysFields.SoftDelete {
+	case "", SoftDeleteGorm, SoftDeleteFlag, SoftDeleteNone:
+	default:
+		return fmt.Errorf("invalid sys_fields.soft_delete %s, expected gorm, flag or none", sysFields.SoftDelete)
+	}
+	if sysFields.SoftDelete == SoftDeleteNone && sysFields.DeletedAt != "" {
+		return fmt.Errorf("sys_fields.deleted_at %s requires soft_delete gorm or flag", sysFields.DeletedAt)
+	}
+	resolved := resolveSysFields(sysFields)
+	seen := make(map[string]string)
+	for _, item := range []struct{ key, column string }{
+		{"created_at", resolved.CreatedAt},
+		{"updated_at", resolved.UpdatedAt},
+		{"deleted_at", resolved.DeletedAt},
+		{"created_by", resolved.CreatedBy},
+		{"updated_by", resolved.UpdatedBy},
+		{"deleted_by", resolved.DeletedBy},
+	} {
+		if item.column == "" {
+			continue
+		}
+		if key, exists := seen[item.column]; exists {
+			return fmt.Errorf("sys_fields.%s and sys_fields.%s cannot use the same column %s", key, item.key, item.column)
+		}
+		seen[item.column] = item.key
+	}
+	return nil
+}
+
+// sysFieldName 系统字段列名对应的结构体字段名，与 buildModelFields 的命名规则一致
+func sysFieldName(column string) string {
+	if column == "" {
+		return ""
+	}
+	return gutil.ReplaceIdToID(gutil.SnakeToPascal(column))
+}
+
+// builtInFieldNames 由 gorm 维护的内置字段：主键、创建与更新时间、软删除列
+func builtInFieldNames() map[string]struct{} {
+	sysFields := currentSysFields()
+	names := map[string]struct{}{"ID": {}}
+	for _, column := range []string{sysFields.CreatedAt, sysFields.UpdatedAt, sysFields.DeletedAt} {
+		if column != "" {
+			names[sysFieldName(column)] = struct{}{}
+		}
+	}
+	return names
+}
+
+// sysFieldNames 系统字段：内置字段、操作人字段与 extra 中的列，不由请求传入
+func sysFieldNames() map[string]struct{} {
+	sysFields := currentSysFields()
+	names := builtInFieldNames()
+	columns := append([]string{sysFields.CreatedBy, sysFields.UpdatedBy, sysFields.DeletedBy}, sysFields.Extra...)
+	for _, column := range columns {
+		if column != "" {
+			names[sysFieldName(column)] = struct{}{}
+		}
+	}
+	return names
+}
+
+// isGormModelConvention 时间列与软删除是否为 gorm.Model 的默认约定，是则数值主键的 Entity 内嵌 gorm.Model
+func isGormModelConvention(sysFields SysFieldConfig) bool {
+	return sysFields.SoftDelete == SoftDeleteGorm && sysFields.CreatedAt == defaultCreatedAtColumn &&
+		sysFields.UpdatedAt == defaultUpdatedAtColumn && sysFields.DeletedAt == defaultDeletedAtColumn
+}
+
+// applySysFieldTags 为 Entity 显式声明的内置字段设置 gorm 类型与标签：软删除列使用 gorm.DeletedAt 或 soft_delete.DeletedAt，
+// 非默认字段名的时间列通过 autoCreateTime、autoUpdateTime 由 gorm 自动维护
+func applySysFieldTags(field *ModelField) {
+	sysFields := currentSysFields()
+	switch field.ColumnName {
+	case sysFields.DeletedAt:
+		field.SoftDelete = sysFields.SoftDelete
+		if sysFields.SoftDelete == SoftDeleteFlag {
+			field.ModelFieldType = "soft_delete.DeletedAt"
+			field.GormTagExtra = "softDelete:flag"
+		} else {
+			field.ModelFieldType = "gorm.DeletedAt"
+		}
+	case sysFields.CreatedAt:
+		if field.FieldName != "CreatedAt" {
+			field.GormTagExtra = "autoCreateTime"
+		}
+	case sysFields.UpdatedAt:
+		if field.FieldName != "UpdatedAt" {
+			field.GormTagExtra = "autoUpdateTime"
+		}
+	}
+}
+
+// buildSysFieldParams 查找表中的系统字段，生成模板参数
+func buildSysFieldParams(pkFieldType string, fields []ModelField) SysFieldParams {
+	sysFields := currentSysFields()
+	// gorm.Model 自带 ID 主键，复合主键表显式声明全部字段
+	params := SysFieldParams{
+		EmbedGormModel: IsNumID(pkFieldType) && isGormModelConvention(sysFields) && len(buildKeyFields(fields)) == 0,
+		CustomDelete:   !isGormModelConvention(sysFields) || sysFields.DeletedBy != defaultDeletedByColumn,
+	}
+	if params.EmbedGormModel {
+		params.CreatedAtField = &ModelField{FieldName: "CreatedAt", FieldType: "time.Time", ColumnName: defaultCreatedAtColumn}
+		params.UpdatedAtField = &ModelField{FieldName: "UpdatedAt", FieldType: "time.Time", ColumnName: defaultUpdatedAtColumn}
+	}
+	for i := range fields {
+		field := &fields[i]
+		switch field.ColumnName {
+		case sysFields.CreatedAt:
+			params.CreatedAtField = field
+		case sysFields.UpdatedAt:
+			params.UpdatedAtField = field
+		case sysFields.CreatedBy:
+			params.CreatedByField = field
+		case sysFields.UpdatedBy:
+			params.UpdatedByField = field
+		case sysFields.DeletedBy:
+			// 物理删除后记录不存在，删除人无处保存
+			if sysFields.SoftDelete != SoftDeleteNone {
+				params.DeletedByField = field
+			}
+		}
+	}
+	return params
+}
+
+// calcModelImports model 层的字段类型导入，未内嵌 gorm.Model 时内置字段（时间列、软删除列）也由 Entity 显式声明
+func calcModelImports(fields []ModelField, embedGormModel bool) []string {
+	if embedGormModel {
+		return calcFieldImports(fields)
+	}
+	var imports []string
+	for importPath := range GetFieldImports(fields) {
+		imports = appendImport(imports, importPath)
+	}
+	return imports
+}
+
+// OperatorID 操作人字段取请求上下文中用户 ID 的表达式，字符串类型使用 GetUserIDString
+func OperatorID(field ModelField) string {
+	if field.FieldType == "string" {
+		return "gincontext.GetUserIDString(ctx)"
+	}
+	return fmt.Sprintf("%s(gincontext.GetUserID(ctx))", field.FieldType)
+}
+
+// UnixTime 时间字段转为 Unix 时间戳的表达式，整型时间列直接转换为 int64
+func UnixTime(field ModelField, src string) string {
+	if field.FieldType == "time.Time" {
+		return src + ".Unix()"
+	}
+	return fmt.Sprintf("int64(%s)", src)
+}
+
+// softDeleteDependencyHint soft_delete 为 flag 且 app 的 go.mod 未引入 gorm.io/plugin/soft_delete 时，返回 go get 提示
+func softDeleteDependencyHint(appDir string) string {
+	if currentSysFields().SoftDelete != SoftDeleteFlag {
+		return ""
+	}
+	content, err := os.ReadFile(filepath.Join(appDir, "go.mod"))
+	if err == nil && strings.Contains(string(content), softDeleteFlagModule+" ") {
+		return ""
+	}
+	return fmt.Sprintf("sys_fields.soft_delete flag requires %s, run `go get %s` in %s", softDeleteFlagModule, softDeleteFlagModule, appDir)
+}
@@ -0,0 +1,262 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sysFieldDDL 审计字段与软删除列不使用 gorm.Model 默认列名的表
+const sysFieldDDL = "CREATE TABLE `notice` (\n" +
+	"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+	"  `title` varchar(128) NOT NULL COMMENT '标题',\n" +
+	"  `tenant_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '租户ID',\n" +
+	"  `creator_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '创建人',\n" +
+	"  `modifier_id` bigint unsigned DEFAULT NULL COMMENT '修改人',\n" +
+	"  `deleter_id` bigint unsigned DEFAULT NULL COMMENT '删除人',\n" +
+	"  `create_time` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+	"  `modify_time` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+	"  `is_deleted` tinyint NOT NULL DEFAULT 0 COMMENT '是否删除: 0-否,1-是',\n" +
+	"  `delete_time` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB COMMENT='公告表';\n"
+
+// TestValidateSysFields sys_fields 配置校验
+func TestValidateSysFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		sysFields SysFieldConfig
+		wantErr   string
+	}{
+		{"default", SysFieldConfig{}, ""},
+		{"flag", SysFieldConfig{SoftDelete: SoftDeleteFlag, CreatedAt: "create_time"}, ""},
+		{"none", SysFieldConfig{SoftDelete: SoftDeleteNone}, ""},
+		{"invalid strategy", SysFieldConfig{SoftDelete: "bool"}, "invalid sys_fields.soft_delete"},
+		{"none with deleted_at", SysFieldConfig{SoftDelete: SoftDeleteNone, DeletedAt: "is_deleted"}, "requires soft_delete gorm or flag"},
+		{"duplicate column", SysFieldConfig{CreatedBy: "operator_id", UpdatedBy: "operator_id"}, "cannot use the same column operator_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSysFields(tt.sysFields)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateSysFields() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateSysFields() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSysFieldNamesFromConfig 内置字段与系统字段按 sys_fields 配置的列名判断
+func TestSysFieldNamesFromConfig(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	cfg = &Config{SysFields: SysFieldConfig{
+		CreatedAt:  "create_time",
+		UpdatedAt:  "modify_time",
+		CreatedBy:  "creator_id",
+		SoftDelete: SoftDeleteFlag,
+		Extra:      []string{"tenant_id"},
+	}}
+	for _, name := range []string{"ID", "CreateTime", "ModifyTime", "IsDeleted"} {
+		if !IsBuiltInField(name) {
+			t.Errorf("IsBuiltInField(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"CreatedAt", "DeletedAt", "CreatorID"} {
+		if IsBuiltInField(name) {
+			t.Errorf("IsBuiltInField(%q) = true, want false", name)
+		}
+	}
+	for _, name := range []string{"CreatorID", "UpdatedBy", "DeletedBy", "TenantID", "IsDeleted"} {
+		if !IsSysField(name) {
+			t.Errorf("IsSysField(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"CreatedAt", "CreatedBy", "Title"} {
+		if IsSysField(name) {
+			t.Errorf("IsSysField(%q) = true, want false", name)
+		}
+	}
+}
+
+// TestBuildUniqueIndexesSoftDeleteFlag 唯一索引包含 sys_fields 配置的软删除标记列时，去掉该列后按其余列查询
+func TestBuildUniqueIndexesSoftDeleteFlag(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	cfg = &Config{SysFields: SysFieldConfig{SoftDelete: SoftDeleteFlag}}
+	fields := []ModelField{
+		{FieldName: "Title", ColumnName: "title", Indexes: []ColumnIndex{{Name: "uk_title", IsUnique: true, Seq: 1}}},
+		{FieldName: "IsDeleted", ColumnName: "is_deleted", Indexes: []ColumnIndex{{Name: "uk_title", IsUnique: true, Seq: 2}}},
+	}
+	indexes := buildUniqueIndexes(fields)
+	if len(indexes) != 1 || indexes[0].FuncSuffix != "Title" {
+		t.Errorf("buildUniqueIndexes() = %+v, want GetByTitle", indexes)
+	}
+}
+
+// TestSoftDeleteDependencyHint soft_delete 为 flag 且 go.mod 未引入 soft_delete 插件时提示 go get
+func TestSoftDeleteDependencyHint(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	appDir := t.TempDir()
+	goModPath := filepath.Join(appDir, "go.mod")
+	if err := os.WriteFile(goModPath, []byte("module example.com/demo\n\nrequire gorm.io/gorm v1.31.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if hint := softDeleteDependencyHint(appDir); hint != "" {
+		t.Errorf("default soft_delete hint = %q, want empty", hint)
+	}
+	cfg = &Config{SysFields: SysFieldConfig{SoftDelete: SoftDeleteFlag}}
+	if hint := softDeleteDependencyHint(appDir); !strings.Contains(hint, "go get gorm.io/plugin/soft_delete") {
+		t.Errorf("flag soft_delete hint = %q, want go get hint", hint)
+	}
+	if err := os.WriteFile(goModPath, []byte("module example.com/demo\n\nrequire gorm.io/plugin/soft_delete v1.2.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if hint := softDeleteDependencyHint(appDir); hint != "" {
+		t.Errorf("hint with soft_delete required = %q, want empty", hint)
+	}
+}
+
+// TestGenerateModuleSysFields 自定义 sys_fields 时系统字段不进入请求 DTO，Entity 显式声明软删除与时间列，
+// service 从请求上下文填充操作人，DAO 按软删除方式生成 Delete
+func TestGenerateModuleSysFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		sysFields  string
+		modelWants []string
+		daoWants   []string
+		svcWants   []string
+	}{
+		{
+			name: "flag",
+			sysFields: `
+sys_fields:
+  created_at: create_time
+  updated_at: modify_time
+  created_by: creator_id
+  updated_by: modifier_id
+  deleted_by: deleter_id
+  soft_delete: flag
+  extra: [tenant_id, delete_time]
+`,
+			modelWants: []string{
+				`"gorm.io/plugin/soft_delete"`,
+				`"time"`,
+				"ID uint `gorm:\"column:id;",
+				"ModifierID uint `gorm:\"column:modifier_id;",
+				"CreateTime time.Time `gorm:\"column:create_time;type:datetime;not null;default CURRENT_TIMESTAMP;comment:创建时间;autoCreateTime\"`",
+				"ModifyTime time.Time `gorm:\"column:modify_time;type:datetime;not null;default CURRENT_TIMESTAMP;comment:更新时间;autoUpdateTime\"`",
+				"IsDeleted soft_delete.DeletedAt `gorm:\"column:is_deleted;type:tinyint;not null;default 0;comment:是否删除: 0-否,1-是;softDelete:flag\"`",
+			},
+			daoWants: []string{
+				"func (d *NoticeDao) Delete(ctx context.Context, id uint, deletedBy uint) error {",
+				`tx.Model(&model.NoticeEntity{}).Where("id = ?", id).Update("deleter_id", deletedBy)`,
+				`return tx.Where("id = ?", id).Delete(&model.NoticeEntity{}).Error`,
+			},
+			svcWants: []string{
+				"CreatorID: uint(gincontext.GetUserID(ctx)),",
+				"ModifierID: uint(gincontext.GetUserID(ctx)),",
+				`updateMap["modifier_id"] = uint(gincontext.GetUserID(ctx))`,
+				"deletedBy := uint(gincontext.GetUserID(ctx))",
+				"Delete(ctx, req.NoticeID, deletedBy)",
+				"CreatedAt: noticeEntity.CreateTime.Unix(),",
+				"UpdatedAt: v.ModifyTime.Unix(),",
+			},
+		},
+		{
+			name: "none",
+			sysFields: `
+sys_fields:
+  created_at: create_time
+  updated_at: modify_time
+  created_by: creator_id
+  updated_by: modifier_id
+  deleted_by: deleter_id
+  soft_delete: none
+  extra: [tenant_id, is_deleted, delete_time]
+`,
+			modelWants: []string{
+				"IsDeleted int8",
+				"DeleteTime time.Time",
+			},
+			daoWants: []string{
+				"func (d *NoticeDao) Delete(ctx context.Context, id uint) error {",
+				`return dbclient.MysqlDB(ctx).Where("id = ?", id).Delete(&model.NoticeEntity{}).Error`,
+			},
+			svcWants: []string{
+				"Delete(ctx, req.NoticeID); err != nil",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetGenerateState()
+			defer resetGenerateState()
+			ddlFile := filepath.Join(t.TempDir(), "notice.sql")
+			if err := os.WriteFile(ddlFile, []byte(sysFieldDDL), 0644); err != nil {
+				t.Fatal(err)
+			}
+			restore := chdirToExample(t)
+			defer restore()
+			writeCodeGenConfig(t, `
+service_name: mysql
+nullable_style: pointer
+module:
+  package_name: notice
+  description: 公告
+  table_name: notice
+`+tt.sysFields)
+
+			output := captureStdout(t, func() {
+				if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+					t.Errorf("Failed to execute module command: %v", err)
+				}
+			})
+			assertGenerateSuccess(t, output)
+
+			appDir := filepath.Join("apps", "demoapp")
+			modelFile := filepath.Join(appDir, "model", "notice.go")
+			files := map[string][]string{
+				modelFile: tt.modelWants,
+				filepath.Join(appDir, "dao", "notice.go"):                              tt.daoWants,
+				filepath.Join(appDir, "internal", "service", "svcnotice", "notice.go"): tt.svcWants,
+			}
+			for file, wants := range files {
+				content := compactSpaces(readFile(t, file))
+				for _, want := range wants {
+					if !strings.Contains(content, want) {
+						t.Errorf("%s missing %q:\n%s", file, want, content)
+					}
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+					t.Errorf("generated file %s is not valid Go: %v", file, err)
+				}
+			}
+			if modelSrc := readFile(t, modelFile); strings.Contains(modelSrc, "gorm.Model") || strings.Contains(modelSrc, "NoticeIsDeleted") {
+				t.Errorf("model should declare sys fields explicitly without enum types:\n%s", modelSrc)
+			}
+
+			// 系统字段不出现在 object 与请求 DTO 中
+			for _, file := range []string{
+				filepath.Join(appDir, "object", "objnotice", "notice.go"),
+				filepath.Join(appDir, "internal", "dto", "dtonotice", "request.go"),
+			} {
+				content := readFile(t, file)
+				for _, name := range []string{"TenantID", "CreatorID", "ModifierID", "DeleterID", "CreateTime", "ModifyTime", "IsDeleted", "DeleteTime"} {
+					if strings.Contains(content, name) {
+						t.Errorf("%s should not contain sys field %s:\n%s", file, name, content)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 单表生成结果状态
+const (
+	tableGenStatusCreated = "created"
+	tableGenStatusSkipped = "skipped"
+)
+
+// tableGenResult 单表生成结果，用于批量生成结束后输出汇总
+type tableGenResult struct {
+	TableName   string
+	PackageName string
+	Status      string
+	Reason      string   // 跳过原因
+	Files       []string // 创建或修改的文件（绝对路径）
+	RouterFunc  string   // 待注册的路由函数名，仅 module 模式
+}
+
+// resolveTables 解析本次需要生成的表：
+//   - 指定 --tables 时，从 tables 列表（未配置时为数据源中的全部表）中筛选匹配的表
+//   - 未指定 --tables 但配置了 tables 列表时，生成列表中的全部表
+//   - 均未配置时退化为单表模式，直接使用 single
+//
+// 返回值 batch 表示是否为批量模式。
+func resolveTables(single TableConfig, tables []TableConfig, tablePrefix string, patterns []string) ([]TableConfig, bool, error) {
+	if len(patterns) == 0 && len(tables) == 0 {
+		if single.TableName == "" {
+			return nil, false, fmt.Errorf("table_name is required")
+		}
+		return []TableConfig{single}, false, nil
+	}
+
+	candidates := tables
+	if len(candidates) == 0 {
+		tableNames, listErr := listSchemaTables()
+		if listErr != nil {
+			return nil, true, fmt.Errorf("list tables error: %v", listErr)
+		}
+		for _, tableName := range tableNames {
+			candidates = append(candidates, TableConfig{TableName: tableName})
+		}
+	}
+
+	var matchers []func(string) bool
+	for _, pattern := range patterns {
+		matcher, compileErr := compileTablePattern(pattern)
+		if compileErr != nil {
+			return nil, true, compileErr
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	var res []TableConfig
+	for _, table := range candidates {
+		if table.TableName == "" {
+			return nil, true, fmt.Errorf("table_name is required in tables")
+		}
+		if len(matchers) > 0 && !matchAnyTable(matchers, table.TableName) {
+			continue
+		}
+		if table.PackageName == "" {
+			table.PackageName = defaultPackageName(table.TableName, tablePrefix)
+		}
+		if table.Description == "" {
+			table.Description = schemaTableComment(table.TableName)
+		}
+		res = append(res, table)
+	}
+	if len(res) == 0 {
+		return nil, true, fmt.Errorf("no table matched: %s", strings.Join(patterns, ","))
+	}
+	return res, true, nil
+}
+
+// compileTablePattern 编译表名匹配规则，以 / 包裹的视为正则（如 /^iam_(user|role)$/），其余按 glob 匹配（如 iam_*）
+func compileTablePattern(pattern string) (func(string) bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid table pattern %s: %v", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid table pattern %s: %v", pattern, err)
+	}
+	return func(tableName string) bool {
+		matched, _ := path.Match(pattern, tableName)
+		return matched
+	}, nil
+}
+
+func matchAnyTable(matchers []func(string) bool, tableName string) bool {
+	for _, matcher := range matchers {
+		if matcher(tableName) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultPackageName 默认包名：去除表名前缀后移除下划线，如 iam_user_role -> userrole
+func defaultPackageName(tableName, tablePrefix string) string {
+	name := tableName
+	if tablePrefix != "" && strings.HasPrefix(name, tablePrefix) && len(name) > len(tablePrefix) {
+		name = strings.TrimPrefix(name, tablePrefix)
+	}
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// listSchemaTables 列出数据源（数据库或 DDL 文件）中的全部表名
+func listSchemaTables() ([]string, error) {
+	var tableNames []string
+	if cfg.SchemaSource == SchemaSourceDDL {
+		for tableName := range ddlTables {
+			tableNames = append(tableNames, tableName)
+		}
+	} else {
+		if DBClient == nil {
+			return nil, fmt.Errorf("database client is not initialized")
+		}
+		dbTables, err := DBClient.Migrator().GetTables()
+		if err != nil {
+			return nil, err
+		}
+		tableNames = dbTables
+	}
+	sort.Strings(tableNames)
+	return tableNames, nil
+}
+
+// schemaTableComment 获取表注释作为默认描述，获取不到时使用表名
+func schemaTableComment(tableName string) string {
+	if cfg.SchemaSource == SchemaSourceDDL {
+		if table, ok := ddlTables[tableName]; ok && table.Comment != "" {
+			return table.Comment
+		}
+		return tableName
+	}
+	if DBClient != nil {
+		if tableType, err := DBClient.Migrator().TableType(tableName); err == nil {
+			if comment, ok := tableType.Comment(); ok && comment != "" {
+				return comment
+			}
+		}
+	}
+	return tableName
+}
+
+// printTableGenSummary 输出批量生成汇总，文件路径相对项目根目录展示
+func printTableGenSummary(tag string, results []*tableGenResult) {
+	var createdCount, skippedCount int
+	for _, result := range results {
+		if result.Status == tableGenStatusCreated {
+			createdCount++
+		} else {
+			skippedCount++
+		}
+	}
+	fmt.Printf("[%s] Summary: %d created, %d skipped\n", tag, createdCount, skippedCount)
+	for _, result := range results {
+		if result.Status == tableGenStatusSkipped {
+			fmt.Printf("  - %s (package %s): skipped, %s\n", result.TableName, result.PackageName, result.Reason)
+			continue
+		}
+		fmt.Printf("  - %s (package %s): created %d files\n", result.TableName, result.PackageName, len(result.Files))
+		for _, file := range result.Files {
+			if relPath, err := filepath.Rel(cfg.appInfo.ProjectRootPath, file); err == nil {
+				file = relPath
+			}
+			fmt.Printf("      %s\n", file)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompileTablePattern(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		tableName string
+		want      bool
+	}{
+		{"iam_*", "iam_user", true},
+		{"iam_*", "user", false},
+		{"iam_?ser", "iam_user", true},
+		{"/^iam_(user|role)$/", "iam_role", true},
+		{"/^iam_(user|role)$/", "iam_user_role", false},
+		{"user", "user", true},
+	}
+	for _, tt := range tests {
+		matcher, err := compileTablePattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("compileTablePattern(%q) error = %v", tt.pattern, err)
+		}
+		if got := matcher(tt.tableName); got != tt.want {
+			t.Errorf("pattern %q match %q = %v, want %v", tt.pattern, tt.tableName, got, tt.want)
+		}
+	}
+
+	for _, pattern := range []string{"iam_[", "/iam_(/"} {
+		if _, err := compileTablePattern(pattern); err == nil {
+			t.Errorf("compileTablePattern(%q) expected error", pattern)
+		}
+	}
+}
+
+func TestDefaultPackageName(t *testing.T) {
+	tests := []struct {
+		tableName   string
+		tablePrefix string
+		want        string
+	}{
+		{"iam_user_role", "iam_", "userrole"},
+		{"user_login_log", "", "userloginlog"},
+		{"iam_", "iam_", "iam"},
+	}
+	for _, tt := range tests {
+		if got := defaultPackageName(tt.tableName, tt.tablePrefix); got != tt.want {
+			t.Errorf("defaultPackageName(%q, %q) = %q, want %q", tt.tableName, tt.tablePrefix, got, tt.want)
+		}
+	}
+}
+
+func TestResolveTables(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	cfg = &Config{SchemaSource: SchemaSourceDDL}
+	ddlTables = map[string]*TableSchema{
+		"iam_user":     {TableName: "iam_user", Comment: "用户表"},
+		"iam_role":     {TableName: "iam_role"},
+		"sys_tenant":   {TableName: "sys_tenant"},
+		"iam_user_log": {TableName: "iam_user_log"},
+	}
+
+	single := TableConfig{TableName: "iam_user", PackageName: "user", Description: "用户"}
+	tables, batch, err := resolveTables(single, nil, "iam_", nil)
+	if err != nil || batch || len(tables) != 1 || tables[0] != single {
+		t.Fatalf("single mode = %+v, %v, %v", tables, batch, err)
+	}
+
+	// 未配置 tables 列表时从数据源中筛选，包名与描述取默认值
+	tables, batch, err = resolveTables(single, nil, "iam_", []string{"iam_*"})
+	if err != nil || !batch {
+		t.Fatalf("resolveTables() = %v, %v", batch, err)
+	}
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.TableName)
+	}
+	if got := strings.Join(names, ","); got != "iam_role,iam_user,iam_user_log" {
+		t.Errorf("matched tables = %s", got)
+	}
+	if tables[1].PackageName != "user" || tables[1].Description != "用户表" {
+		t.Errorf("iam_user defaults = %+v", tables[1])
+	}
+	if tables[0].Description != "iam_role" {
+		t.Errorf("iam_role description = %q, want table name", tables[0].Description)
+	}
+
+	// 配置了 tables 列表时仅在列表中筛选，保留显式配置
+	configured := []TableConfig{
+		{TableName: "iam_user", PackageName: "account", Description: "账号"},
+		{TableName: "sys_tenant"},
+	}
+	tables, _, err = resolveTables(single, configured, "", []string{"/^iam_/"})
+	if err != nil || len(tables) != 1 || tables[0] != configured[0] {
+		t.Errorf("filtered configured tables = %+v, %v", tables, err)
+	}
+	tables, _, err = resolveTables(single, configured, "", nil)
+	if err != nil || len(tables) != 2 || tables[1].PackageName != "systenant" {
+		t.Errorf("configured tables = %+v, %v", tables, err)
+	}
+
+	if _, _, err = resolveTables(single, nil, "", []string{"order_*"}); err == nil {
+		t.Error("expected error when no table matched")
+	}
+}
+
+// TestGenerateModuleBatchFromDDL 批量生成多张表：已生成过的表跳过，路由统一注册
+func TestGenerateModuleBatchFromDDL(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--tables", "user*"); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if !strings.Contains(output, "Summary: 1 created, 1 skipped") {
+		t.Errorf("unexpected summary:\n%s", output)
+	}
+	if !strings.Contains(output, "user (package user): skipped") {
+		t.Errorf("existing table user should be skipped:\n%s", output)
+	}
+	if _, statErr := os.Stat(filepath.Join("apps", "demoapp", "internal", "controller", "ctruserloginlog", "user_login_log.go")); statErr != nil {
+		t.Errorf("generated controller file not found: %v", statErr)
+	}
+	routerFile, readErr := os.ReadFile(filepath.Join("apps", "demoapp", "internal", "router", "router.go"))
+	if readErr != nil {
+		t.Fatalf("read router file: %v", readErr)
+	}
+	if strings.Count(string(routerFile), "userLoginLogRouter(groups)") != 1 || strings.Count(string(routerFile), "userRouter(groups)") != 1 {
+		t.Errorf("unexpected router registration:\n%s", routerFile)
+	}
+}
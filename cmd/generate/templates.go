@@ -0,0 +1,164 @@
+package generate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+	"github.com/spf13/cobra"
+)
+
+// 生成模式对应的模板子目录
+const (
+	tplModeModule   = "module"
+	tplModeModel    = "model"
+	tplModeApi      = "api"
+	tplModeGrpc     = "grpc"     // module --with-grpc 使用的 .proto 与 gRPC 服务端模板
+	tplModeNullable = "nullable" // nullable_style 为 pointer/sqlnull 时 service 包内的可空字段转换函数
+	tplModeLike     = "like"     // page_list 配置 like 筛选或关键字搜索时 dao 包内的 LIKE 通配符转义函数
+)
+
+// embeddedTplRoot 内嵌代码生成模板在 TemplatesFS 中的根目录
+const embeddedTplRoot = "generate"
+
+// defaultCustomTplDir 应用级自定义模板目录（相对应用目录）
+var defaultCustomTplDir = filepath.Join("config", "codegen_tpl")
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage code generation templates",
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the built-in generate templates as a starting point for customization",
+	Long: `Export the built-in module/model/api/client templates. Templates placed in apps/<app>/config/codegen_tpl
+(or the template_dir configured in code_gen.yaml) shadow the built-in ones with the same name.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		outputDir, _ := cmd.Flags().GetString("output")
+		appName, _ := cmd.Flags().GetString("app")
+		force, _ := cmd.Flags().GetBool("force")
+		if outputDir == "" {
+			if appName == "" {
+				fmt.Println("Please provide an output directory using --output flag or an app name using --app flag")
+				return
+			}
+			currentDir, _ := os.Getwd()
+			appDir, resolveErr := resolveAppDir(currentDir, appName)
+			if resolveErr != nil {
+				fmt.Printf("Resolve app directory error: %v\n", resolveErr)
+				return
+			}
+			outputDir = filepath.Join(appDir, defaultCustomTplDir)
+		}
+		if err := exportTemplates(outputDir, force); err != nil {
+			fmt.Printf("Export templates error: %v\n", err)
+			return
+		}
+		fmt.Printf("Templates exported to %s\n", outputDir)
+	},
+}
+
+func init() {
+	templatesExportCmd.Flags().StringP("app", "a", "", "App name, templates are exported to apps/<app>/config/codegen_tpl")
+	templatesExportCmd.Flags().StringP("output", "o", "", "Output directory, takes precedence over --app")
+	templatesExportCmd.Flags().Bool("force", false, "Overwrite existing template files")
+	templatesCmd.AddCommand(templatesExportCmd)
+	Cmd.AddCommand(templatesCmd)
+}
+
+// exportTemplates 将内嵌模板导出到 outputDir，保持 {module,model,api,client}/*.tpl 目录结构，已存在的文件默认跳过
+func exportTemplates(outputDir string, force bool) error {
+	return fs.WalkDir(TemplatesFS, embeddedTplRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, relErr := filepath.Rel(embeddedTplRoot, path)
+		if relErr != nil {
+			return relErr
+		}
+		targetPath := filepath.Join(outputDir, relPath)
+		if !force && gutil.FileExists(targetPath) {
+			fmt.Printf("[Template] Skipped existing file: %s\n", relPath)
+			return nil
+		}
+		data, readErr := TemplatesFS.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+		if mkDirErr := os.MkdirAll(filepath.Dir(targetPath), 0755); mkDirErr != nil {
+			return mkDirErr
+		}
+		if writeErr := os.WriteFile(targetPath, data, 0644); writeErr != nil {
+			return writeErr
+		}
+		fmt.Printf("[Template] Exported: %s\n", relPath)
+		return nil
+	})
+}
+
+// customTplDir 返回自定义模板根目录：优先使用 code_gen.yaml 中的 template_dir（相对路径基于项目根目录），
+// 否则使用应用目录下的 config/codegen_tpl，目录不存在时返回空
+func customTplDir() string {
+	tplDir := filepath.Join(workDir, defaultCustomTplDir)
+	if cfg.TemplateDir != "" {
+		tplDir = cfg.TemplateDir
+		if !filepath.IsAbs(tplDir) {
+			tplDir = filepath.Join(cfg.appInfo.ProjectRootPath, tplDir)
+		}
+	}
+	if info, err := os.Stat(tplDir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return tplDir
+}
+
+// prepareTemplateDir 准备指定生成模式的模板目录：复制内嵌模板到临时目录，
+// 再用自定义模板目录中同名的 .tpl 文件覆盖，调用方负责删除返回的临时目录
+func prepareTemplateDir(mode string) (string, error) {
+	tplDir, copyErr := CopyEmbeddedTemplatesToTempDir(TemplatesFS, embeddedTplRoot+"/"+mode)
+	if copyErr != nil {
+		return "", copyErr
+	}
+	customRoot := customTplDir()
+	if customRoot == "" {
+		return tplDir, nil
+	}
+	customDir := filepath.Join(customRoot, mode)
+	entries, readErr := os.ReadDir(customDir)
+	if os.IsNotExist(readErr) {
+		return tplDir, nil
+	}
+	if readErr != nil {
+		os.RemoveAll(tplDir)
+		return "", fmt.Errorf("read custom template dir error: %v", readErr)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".tpl") {
+			continue
+		}
+		targetPath := filepath.Join(tplDir, name)
+		if !gutil.FileExists(targetPath) {
+			fmt.Printf("[Template] Ignored custom template %s/%s: no built-in template with the same name\n", mode, name)
+			continue
+		}
+		data, fileErr := os.ReadFile(filepath.Join(customDir, name))
+		if fileErr != nil {
+			os.RemoveAll(tplDir)
+			return "", fmt.Errorf("read custom template %s error: %v", name, fileErr)
+		}
+		if writeErr := os.WriteFile(targetPath, data, 0644); writeErr != nil {
+			os.RemoveAll(tplDir)
+			return "", fmt.Errorf("write custom template %s error: %v", name, writeErr)
+		}
+		fmt.Printf("[Template] Using custom template: %s/%s\n", mode, name)
+	}
+	return tplDir, nil
+}
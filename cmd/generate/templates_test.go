@@ -0,0 +1,100 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportTemplates(t *testing.T) {
+	outputDir := t.TempDir()
+	if err := exportTemplates(outputDir, false); err != nil {
+		t.Fatalf("exportTemplates() error = %v", err)
+	}
+	for _, name := range []string{"module/controller.go.tpl", "model/model.go.tpl", "api/router.go.tpl"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("exported template %s not found: %v", name, err)
+		}
+	}
+
+	// 已存在的文件默认不覆盖，--force 时覆盖
+	customFile := filepath.Join(outputDir, "module", "controller.go.tpl")
+	if err := os.WriteFile(customFile, []byte("custom"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if err := exportTemplates(outputDir, false); err != nil {
+		t.Fatalf("exportTemplates() error = %v", err)
+	}
+	if content, _ := os.ReadFile(customFile); string(content) != "custom" {
+		t.Errorf("existing template should be kept, got %q", content)
+	}
+	if err := exportTemplates(outputDir, true); err != nil {
+		t.Fatalf("exportTemplates() error = %v", err)
+	}
+	if content, _ := os.ReadFile(customFile); string(content) == "custom" {
+		t.Error("existing template should be overwritten with force")
+	}
+}
+
+func TestPrepareTemplateDir(t *testing.T) {
+	resetGenerateState()
+	defer resetGenerateState()
+	projectDir := t.TempDir()
+	workDir = filepath.Join(projectDir, "apps", "demoapp")
+	cfg = &Config{appInfo: AppInfo{ProjectRootPath: projectDir}}
+
+	customModuleDir := filepath.Join(workDir, "config", "codegen_tpl", "module")
+	if err := os.MkdirAll(customModuleDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(customModuleDir, "controller.go.tpl"), []byte("custom controller"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(customModuleDir, "unknown.go.tpl"), []byte("unknown"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	var tplDir string
+	output := captureStdout(t, func() {
+		var err error
+		tplDir, err = prepareTemplateDir(tplModeModule)
+		if err != nil {
+			t.Fatalf("prepareTemplateDir() error = %v", err)
+		}
+	})
+	defer os.RemoveAll(tplDir)
+
+	if content, _ := os.ReadFile(filepath.Join(tplDir, "controller.go.tpl")); string(content) != "custom controller" {
+		t.Errorf("controller template not overridden, got %q", content)
+	}
+	if content, _ := os.ReadFile(filepath.Join(tplDir, "service.go.tpl")); !strings.Contains(string(content), "package svc") {
+		t.Errorf("service template should be the built-in one, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(tplDir, "unknown.go.tpl")); !os.IsNotExist(err) {
+		t.Errorf("unknown template should be ignored, stat err = %v", err)
+	}
+	if !strings.Contains(output, "Ignored custom template module/unknown.go.tpl") {
+		t.Errorf("expected ignore notice, got:\n%s", output)
+	}
+
+	// template_dir 配置优先于应用目录下的默认位置
+	cfg.TemplateDir = "codegen_tpl"
+	sharedModuleDir := filepath.Join(projectDir, "codegen_tpl", "module")
+	if err := os.MkdirAll(sharedModuleDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sharedModuleDir, "controller.go.tpl"), []byte("shared controller"), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	captureStdout(t, func() {
+		sharedTplDir, err := prepareTemplateDir(tplModeModule)
+		if err != nil {
+			t.Fatalf("prepareTemplateDir() error = %v", err)
+		}
+		defer os.RemoveAll(sharedTplDir)
+		if content, _ := os.ReadFile(filepath.Join(sharedTplDir, "controller.go.tpl")); string(content) != "shared controller" {
+			t.Errorf("template_dir not applied, got %q", content)
+		}
+	})
+}
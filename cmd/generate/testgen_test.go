@@ -0,0 +1,129 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSampleValue(t *testing.T) {
+	tests := []struct {
+		field ModelField
+		want  string
+	}{
+		{ModelField{FieldType: "string"}, `"test_" + strconv.FormatInt(sampleRunID, 36)`},
+		{ModelField{FieldType: "string", ColumnType: "varchar(15)"}, "strconv.FormatInt(sampleRunID, 36)"},
+		{ModelField{FieldType: "string", ColumnType: "char(2)"}, "strconv.FormatInt(sampleRunID%1296, 36)"},
+		{ModelField{FieldType: "uint64"}, "1"},
+		{ModelField{FieldType: "uint64", Indexes: []ColumnIndex{{Name: "uk_code", IsUnique: true, Seq: 1}}}, "uint64(sampleRunID%1000000000 + 1)"},
+		{ModelField{FieldType: "int8", Indexes: []ColumnIndex{{Name: "uk_code", IsUnique: true, Seq: 1}}}, "int8(sampleRunID%127 + 1)"},
+		{ModelField{FieldType: "float64"}, "1"},
+		{ModelField{FieldType: "time.Time"}, "1700000000"},
+		{ModelField{FieldType: "json.RawMessage"}, "[]byte(`{}`)"},
+		{ModelField{FieldType: "string", EnumItems: []EnumItem{{Value: `"paid"`}, {Value: `"cancelled"`}}}, `"paid"`},
+		{ModelField{FieldType: "[]string"}, ""},
+	}
+	for _, tt := range tests {
+		if got := SampleValue(tt.field); got != tt.want {
+			t.Errorf("SampleValue(%+v) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+// TestGenerateModuleUnitTests 项目存在 pkg/testsetup 时为 service 与 controller 生成单元测试，否则跳过
+func TestGenerateModuleUnitTests(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+	codeGenConfig := `
+service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`
+
+	restore := chdirToExample(t)
+	defer func() { restore() }()
+	writeCodeGenConfig(t, codeGenConfig)
+	appDir := filepath.Join("apps", "demoapp")
+	serviceTestFile := filepath.Join(appDir, "internal", "service", "svcloginlog", "user_login_log_test.go")
+	controllerTestFile := filepath.Join(appDir, "internal", "controller", "ctrloginlog", "user_login_log_test.go")
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if !strings.Contains(output, "[Module] Skip unit tests:") {
+		t.Errorf("output should mention skipped unit tests:\n%s", output)
+	}
+	for _, file := range []string{serviceTestFile, controllerTestFile} {
+		if _, err := os.Stat(file); !os.IsNotExist(err) {
+			t.Errorf("%s should not be generated without pkg/testsetup", file)
+		}
+	}
+
+	// 在新的示例副本中补充 pkg/testsetup 后重新生成
+	restore()
+	resetGenerateState()
+	restore = chdirToExample(t)
+	writeCodeGenConfig(t, codeGenConfig)
+	if err := os.MkdirAll(filepath.Join("pkg", "testsetup"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	output = captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	service := readFile(t, serviceTestFile)
+	for _, want := range []string{
+		"package svcloginlog",
+		`"github.com/stretchr/testify/assert"`,
+		"testsetup.Initialize(testsetup.AppNameDemoapp)",
+		"ctx := testsetup.NewContext()",
+		"svc := NewUserLoginLogSvc()",
+		`"strconv"`,
+		"sampleRunID := time.Now().UnixNano()",
+		"UserID:    1,",
+		`LoginIp:   "test_" + strconv.FormatInt(sampleRunID, 36),`,
+		"LoginTime: 1700000000,",
+		"createResp, err := svc.Create(ctx, &dtologinlog.UserLoginLogCreateReq{",
+		"userLoginLogID := createResp.UserLoginLogID",
+		"err = svc.Update(ctx, &dtologinlog.UserLoginLogUpdateReq{",
+		"err = svc.Delete(ctx, &dtologinlog.UserLoginLogDeleteReq{",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service test missing %q:\n%s", want, service)
+		}
+	}
+
+	controller := readFile(t, controllerTestFile)
+	for _, want := range []string{
+		"package ctrloginlog",
+		`const userLoginLogTestPath = "/user-login-logs"`,
+		"router.POST(userLoginLogTestPath, userLoginLogCtr.Create)",
+		"router.GET(userLoginLogTestPath, userLoginLogCtr.PageList)",
+		`router.GET(userLoginLogTestPath+"/:userLoginLogID", userLoginLogCtr.Detail)`,
+		`router.PUT(userLoginLogTestPath+"/:userLoginLogID", userLoginLogCtr.Update)`,
+		`router.DELETE(userLoginLogTestPath+"/:userLoginLogID", userLoginLogCtr.Delete)`,
+		"recorder := httptest.NewRecorder()",
+		"doUserLoginLogRequest(t, router, http.MethodDelete, itemPath, nil)",
+	} {
+		if !strings.Contains(controller, want) {
+			t.Errorf("controller test missing %q:\n%s", want, controller)
+		}
+	}
+
+	for _, file := range []string{serviceTestFile, controllerTestFile} {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated test %s is not valid Go: %v", file, err)
+		}
+	}
+}
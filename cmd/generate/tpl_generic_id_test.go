@@ -27,6 +27,12 @@ func renderTpl(t *testing.T, fsPath string, params map[string]any) string {
 		TplFuncIsStringID:         IsStringID,
 		TplFuncIsIntID:            IsIntID,
 		TplFuncHasTimeFieldAny:    HasTimeFieldAny,
+		TplFuncHasEnumField:       HasEnumField,
+		TplFuncNullableToModel:    NullableToModel,
+		TplFuncNullableToObject:   NullableToObject,
+		TplFuncNullableModelPtr:   NullableModelPtr,
+		TplFuncOperatorID:         OperatorID,
+		TplFuncUnixTime:           UnixTime,
 	}).ParseFS(TemplatesFS, fsPath)
 	if err != nil {
 		t.Fatalf("parse %s: %v", fsPath, err)
@@ -0,0 +1,201 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/morehao/golib/codegen"
+)
+
+// genTransaction 生成事务：生成器写入或删除文件前上报目标路径，首次上报时记录文件原状态，
+// 生成失败时只回滚这些文件，不读取整个应用目录
+type genTransaction struct {
+	files   map[string]fileState // 已上报的文件，key 为绝对路径
+	newDirs map[string]struct{}  // 上报时尚不存在的目录，可能由生成新建
+}
+
+// fileState 文件在生成前的状态
+type fileState struct {
+	Existed bool
+	Content []byte
+	Mode    fs.FileMode
+}
+
+// activeTransaction 执行中的生成事务，runInTransaction 之外（如 dry-run）为 nil
+var activeTransaction *genTransaction
+
+// runInTransaction 以事务方式执行生成：生成器通过 trackFiles 上报目标文件，生成失败时恢复被修改或删除的文件（含原权限）、
+// 删除新建的文件与目录，并输出回滚明细，避免应用处于生成一半、无法编译的状态。
+func runInTransaction(genFn func() error) error {
+	txn := &genTransaction{
+		files:   make(map[string]fileState),
+		newDirs: make(map[string]struct{}),
+	}
+	activeTransaction = txn
+	defer func() { activeTransaction = nil }()
+
+	genErr := genFn()
+	if genErr == nil {
+		return nil
+	}
+	changes, rollbackErr := txn.rollback()
+	if rollbackErr != nil {
+		return fmt.Errorf("%v; rollback failed: %v", genErr, rollbackErr)
+	}
+	printRollbackReport(changes)
+	return genErr
+}
+
+// trackFiles 上报即将写入或删除的文件，事务中首次上报时记录原内容与权限，不在事务中时忽略
+func trackFiles(paths ...string) error {
+	if activeTransaction == nil {
+		return nil
+	}
+	for _, path := range paths {
+		if err := activeTransaction.track(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackGenParams 上报 codegen 各生成项的目标文件
+func trackGenParams(params *codegen.GenParams) error {
+	for _, item := range params.ParamsList {
+		if err := trackFiles(filepath.Join(item.TargetDir, item.TargetFileName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// track 记录文件的原状态，文件不存在时记录尚不存在的上级目录
+func (txn *genTransaction) track(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve path %s error: %v", path, err)
+	}
+	if _, tracked := txn.files[absPath]; tracked {
+		return nil
+	}
+	info, statErr := os.Stat(absPath)
+	if os.IsNotExist(statErr) {
+		txn.files[absPath] = fileState{}
+		for dir := filepath.Dir(absPath); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if _, dirErr := os.Stat(dir); !os.IsNotExist(dirErr) {
+				break
+			}
+			txn.newDirs[dir] = struct{}{}
+		}
+		return nil
+	}
+	if statErr != nil {
+		return fmt.Errorf("stat %s error: %v", path, statErr)
+	}
+	content, readErr := os.ReadFile(absPath)
+	if readErr != nil {
+		return fmt.Errorf("read %s error: %v", path, readErr)
+	}
+	txn.files[absPath] = fileState{Existed: true, Content: content, Mode: info.Mode().Perm()}
+	return nil
+}
+
+// rollback 将已上报的文件恢复到生成前的状态：新建文件删除，修改或删除的文件按原权限恢复原内容，
+// 随后清理生成过程中新建且已为空的目录，返回按路径排序的回滚明细
+func (txn *genTransaction) rollback() ([]fileChange, error) {
+	paths := make([]string, 0, len(txn.files))
+	for path := range txn.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var changes []fileChange
+	for _, path := range paths {
+		before := txn.files[path]
+		info, statErr := os.Stat(path)
+		exists := statErr == nil
+		if statErr != nil && !os.IsNotExist(statErr) {
+			return nil, fmt.Errorf("stat %s error: %v", path, statErr)
+		}
+		if !before.Existed {
+			if !exists {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("remove %s error: %v", path, err)
+			}
+			changes = append(changes, fileChange{Path: transactionDisplayPath(path), Created: true})
+			continue
+		}
+		if exists {
+			content, readErr := os.ReadFile(path)
+			if readErr != nil {
+				return nil, fmt.Errorf("read %s error: %v", path, readErr)
+			}
+			if bytes.Equal(content, before.Content) && info.Mode().Perm() == before.Mode {
+				continue
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("restore %s error: %v", path, err)
+		}
+		if err := os.WriteFile(path, before.Content, before.Mode); err != nil {
+			return nil, fmt.Errorf("restore %s error: %v", path, err)
+		}
+		// WriteFile 不修改已存在文件的权限，新建时也受 umask 影响，需显式恢复
+		if err := os.Chmod(path, before.Mode); err != nil {
+			return nil, fmt.Errorf("restore %s mode error: %v", path, err)
+		}
+		changes = append(changes, fileChange{Path: transactionDisplayPath(path), Before: before.Content, Deleted: !exists})
+	}
+
+	// 由深到浅删除新建目录，非空目录（包含生成之外的文件）保留
+	dirs := make([]string, 0, len(txn.newDirs))
+	for dir := range txn.newDirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		return strings.Count(dirs[i], string(filepath.Separator)) > strings.Count(dirs[j], string(filepath.Separator))
+	})
+	for _, dir := range dirs {
+		_ = os.Remove(dir)
+	}
+	return changes, nil
+}
+
+// transactionDisplayPath 回滚明细中展示的路径，位于项目根目录下时使用相对路径
+func transactionDisplayPath(path string) string {
+	if cfg == nil || cfg.appInfo.ProjectRootPath == "" {
+		return path
+	}
+	root, err := filepath.Abs(cfg.appInfo.ProjectRootPath)
+	if err != nil {
+		return path
+	}
+	relPath, relErr := filepath.Rel(root, path)
+	if relErr != nil || strings.HasPrefix(relPath, "..") {
+		return path
+	}
+	return relPath
+}
+
+// printRollbackReport 输出回滚明细
+func printRollbackReport(changes []fileChange) {
+	if len(changes) == 0 {
+		fmt.Println("[Rollback] Generation failed, no files were changed")
+		return
+	}
+	fmt.Printf("[Rollback] Generation failed, rolled back %d files:\n", len(changes))
+	for _, change := range changes {
+		if change.Created {
+			fmt.Printf("  removed:  %s\n", filepath.ToSlash(change.Path))
+		} else {
+			fmt.Printf("  restored: %s\n", filepath.ToSlash(change.Path))
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTransactionRollback(t *testing.T) {
+	root := t.TempDir()
+	routerFile := filepath.Join(root, "app", "router", "router.go")
+	keepFile := filepath.Join(root, "app", "keep.sh")
+	newFile := filepath.Join(root, "app", "controller", "ctruser", "user.go")
+	untrackedFile := filepath.Join(root, "app", "other.go")
+	if err := os.MkdirAll(filepath.Dir(routerFile), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(routerFile, []byte("origin"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.WriteFile(keepFile, []byte("keep"), 0755); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.WriteFile(untrackedFile, []byte("other"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		err := runInTransaction(func() error {
+			// 模拟生成：新建多级目录文件、修改已有文件、删除已有文件，未上报的文件不参与回滚
+			if err := trackFiles(newFile, routerFile, keepFile); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(newFile), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(newFile, []byte("new"), 0644); err != nil {
+				return err
+			}
+			if err := os.WriteFile(routerFile, []byte("modified"), 0644); err != nil {
+				return err
+			}
+			if err := os.Remove(keepFile); err != nil {
+				return err
+			}
+			return errors.New("generate failed")
+		})
+		if err == nil || err.Error() != "generate failed" {
+			t.Errorf("runInTransaction() error = %v, want generate failed", err)
+		}
+	})
+	if !strings.Contains(output, "rolled back 3 files") {
+		t.Errorf("rollback output = %s", output)
+	}
+
+	for path, want := range map[string]string{routerFile: "origin", keepFile: "keep", untrackedFile: "other"} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if string(content) != want {
+			t.Errorf("%s = %q, want %q", path, content, want)
+		}
+	}
+	info, err := os.Stat(keepFile)
+	if err != nil {
+		t.Fatalf("stat restored file: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("restored file mode = %v, want 0755", info.Mode().Perm())
+	}
+	if _, statErr := os.Stat(filepath.Join(root, "app", "controller")); !os.IsNotExist(statErr) {
+		t.Errorf("created dir should be removed, stat err = %v", statErr)
+	}
+}
+
+// TestGenerateModuleRollback 路由注册失败时回滚已生成的文件与错误码注册
+func TestGenerateModuleRollback(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`)
+	// router.go 缺少 RegisterRouter 函数，路由注册必然失败
+	if err := os.WriteFile(filepath.Join("apps", "demoapp", "internal", "router", "router.go"), []byte("package router\n"), 0644); err != nil {
+		t.Fatalf("write router file: %v", err)
+	}
+	before, err := takeSnapshot(".", []string{"apps", "pkg"})
+	if err != nil {
+		t.Fatalf("takeSnapshot() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Error generating") {
+		t.Fatalf("generation should fail:\n%s", output)
+	}
+	for _, want := range []string{
+		"[Rollback] Generation failed, rolled back",
+		"removed:  apps/demoapp/internal/controller/ctrloginlog/user_login_log.go",
+		"restored: pkg/code/code.go",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("rollback output missing %q:\n%s", want, output)
+		}
+	}
+
+	after, err := takeSnapshot(".", []string{"apps", "pkg"})
+	if err != nil {
+		t.Fatalf("takeSnapshot() error = %v", err)
+	}
+	if changes := compareSnapshots(before, after); len(changes) != 0 {
+		t.Errorf("files not rolled back: %s", changes[0].Path)
+	}
+	if _, statErr := os.Stat(filepath.Join("apps", "demoapp", "internal", "controller", "ctrloginlog")); !os.IsNotExist(statErr) {
+		t.Errorf("created dir should be removed, stat err = %v", statErr)
+	}
+}
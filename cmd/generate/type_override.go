@@ -0,0 +1,132 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+)
+
+// validateTypeOverrides 校验 type_overrides 配置：go_type 必填，column 与 db_type 二选一，
+// 引用了第三方包的 go_type 需要填写 import
+func validateTypeOverrides(overrides []TypeOverrideConfig) error {
+	for i, override := range overrides {
+		if override.GoType == "" {
+			return fmt.Errorf("type_overrides[%d]: go_type is required", i)
+		}
+		switch {
+		case override.Column == "" && override.DBType == "":
+			return fmt.Errorf("type_overrides[%d]: one of column and db_type is required", i)
+		case override.Column != "" && override.DBType != "":
+			return fmt.Errorf("type_overrides[%d]: column and db_type cannot be set at the same time", i)
+		case override.Column != "":
+			tableName, columnName, ok := strings.Cut(override.Column, ".")
+			if !ok || tableName == "" || columnName == "" || strings.Contains(columnName, ".") {
+				return fmt.Errorf("type_overrides[%d]: invalid column %s, expected table.column", i, override.Column)
+			}
+		default:
+			if _, err := path.Match(strings.ToLower(override.DBType), ""); err != nil {
+				return fmt.Errorf("type_overrides[%d]: invalid db_type pattern %s: %v", i, override.DBType, err)
+			}
+		}
+		if pkg := goTypePackage(override.GoType); pkg != "" && override.Import == "" {
+			if _, ok := lookupFieldTypeImport(override.GoType); !ok {
+				return fmt.Errorf("type_overrides[%d]: import is required for go_type %s", i, override.GoType)
+			}
+		}
+	}
+	return nil
+}
+
+// goTypePackage Go 类型引用的包名，如 []decimal.Decimal -> decimal，内置类型返回空
+func goTypePackage(goType string) string {
+	pkg, _, ok := strings.Cut(goTypeElem(goType), ".")
+	if !ok {
+		return ""
+	}
+	return pkg
+}
+
+// goTypeElem 去掉切片、指针前缀与泛型参数后的类型名，如 *sql.Null[int] -> sql.Null
+func goTypeElem(goType string) string {
+	goType = strings.TrimLeft(goType, "[]*")
+	if idx := strings.Index(goType, "["); idx > 0 {
+		goType = goType[:idx]
+	}
+	return goType
+}
+
+// typeOverrideImports type_overrides 中配置了 import 的类型对应的导入
+func typeOverrideImports() []FieldTypeImport {
+	if cfg == nil {
+		return nil
+	}
+	var imports []FieldTypeImport
+	for _, override := range cfg.TypeOverrides {
+		if override.Import != "" {
+			imports = append(imports, FieldTypeImport{ImportPath: override.Import, ImportName: goTypePackage(override.GoType)})
+		}
+	}
+	return imports
+}
+
+// matchTypeOverride 查找列适用的类型覆盖，table.column 匹配优先于 db_type
+func matchTypeOverride(tableName string, column ColumnSchema) (TypeOverrideConfig, bool) {
+	if cfg == nil {
+		return TypeOverrideConfig{}, false
+	}
+	for _, override := range cfg.TypeOverrides {
+		if override.Column == tableName+"."+column.ColumnName {
+			return override, true
+		}
+	}
+	for _, override := range cfg.TypeOverrides {
+		if override.DBType != "" && matchColumnType(override.DBType, column.ColumnType) {
+			return override, true
+		}
+	}
+	return TypeOverrideConfig{}, false
+}
+
+// matchColumnType 列类型是否匹配 db_type 模式（不区分大小写）。模式先与完整列类型匹配，
+// 不匹配时再与去掉长度、unsigned 等修饰的类型名匹配，如 decimal 匹配 decimal(20,4)，tinyint(1) 不匹配 tinyint(4)
+func matchColumnType(pattern, columnType string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	if ok, _ := path.Match(pattern, columnType); ok {
+		return true
+	}
+	typeName := columnType
+	if idx := strings.IndexAny(typeName, "( "); idx > 0 {
+		typeName = typeName[:idx]
+	}
+	ok, _ := path.Match(pattern, typeName)
+	return ok
+}
+
+// applyTypeOverrides 按 type_overrides 替换列的 Go 类型，返回新的列切片，不修改传入的表结构。
+// 主键与系统字段的类型由模板与 service 固定使用，db_type 匹配时跳过，column 显式指定时报错
+func applyTypeOverrides(tableName string, columns []ColumnSchema) ([]ColumnSchema, error) {
+	if cfg == nil || len(cfg.TypeOverrides) == 0 {
+		return columns, nil
+	}
+	result := make([]ColumnSchema, len(columns))
+	copy(result, columns)
+	for i := range result {
+		column := &result[i]
+		override, ok := matchTypeOverride(tableName, *column)
+		if !ok {
+			continue
+		}
+		if column.IsPrimaryKey || IsSysField(gutil.ReplaceIdToID(column.FieldName)) {
+			if override.Column != "" {
+				return nil, fmt.Errorf("type_overrides: column %s is a primary key or system field, its type cannot be overridden", override.Column)
+			}
+			continue
+		}
+		column.FieldType = override.GoType
+		column.Serializer = override.Serializer
+	}
+	return result, nil
+}
@@ -0,0 +1,232 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// typeOverrideDDL 含 decimal、tinyint(1)、json 等需要自定义类型的列
+const typeOverrideDDL = "CREATE TABLE `shop_order` (\n" +
+	"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+	"  `order_no` char(36) NOT NULL COMMENT '订单号',\n" +
+	"  `amount` decimal(20,4) NOT NULL DEFAULT '0.0000' COMMENT '金额',\n" +
+	"  `is_paid` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否已支付',\n" +
+	"  `level` tinyint NOT NULL DEFAULT 0 COMMENT '等级',\n" +
+	"  `extra` json DEFAULT NULL COMMENT '扩展信息',\n" +
+	"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+	"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+	"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB COMMENT='订单表';\n"
+
+const typeOverrideConfig = `
+type_overrides:
+  - column: shop_order.order_no
+    go_type: uuid.UUID
+    import: github.com/google/uuid
+  - column: shop_order.extra
+    go_type: types.OrderExtra
+    import: github.com/example/demoapp/pkg/types
+    serializer: json
+  - db_type: decimal
+    go_type: decimal.Decimal
+    import: github.com/shopspring/decimal
+  - db_type: tinyint(1)
+    go_type: bool
+`
+
+// TestMatchColumnType db_type 模式与完整列类型或去掉修饰的类型名匹配
+func TestMatchColumnType(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		columnType string
+		want       bool
+	}{
+		{"decimal", "decimal(20,4)", true},
+		{"DECIMAL", "decimal(20,4)", true},
+		{"decimal(*,2)", "decimal(10,2)", true},
+		{"decimal(*,2)", "decimal(20,4)", false},
+		{"tinyint(1)", "tinyint(1)", true},
+		{"tinyint(1)", "tinyint(4)", false},
+		{"tinyint", "tinyint(1)", true},
+		{"bigint", "bigint unsigned", true},
+		{"bigint unsigned", "bigint unsigned", true},
+		{"uuid", "uuid", true},
+		{"json*", "jsonb", true},
+		{"int", "bigint", false},
+	}
+	for _, tt := range tests {
+		if got := matchColumnType(tt.pattern, tt.columnType); got != tt.want {
+			t.Errorf("matchColumnType(%q, %q) = %v, want %v", tt.pattern, tt.columnType, got, tt.want)
+		}
+	}
+}
+
+// TestValidateTypeOverrides type_overrides 配置校验
+func TestValidateTypeOverrides(t *testing.T) {
+	resetGenerateState()
+	tests := []struct {
+		name     string
+		override TypeOverrideConfig
+		wantErr  string
+	}{
+		{"db type", TypeOverrideConfig{DBType: "decimal", GoType: "decimal.Decimal", Import: "github.com/shopspring/decimal"}, ""},
+		{"builtin type", TypeOverrideConfig{DBType: "tinyint(1)", GoType: "bool"}, ""},
+		{"known import", TypeOverrideConfig{Column: "user.profile", GoType: "json.RawMessage"}, ""},
+		{"missing go type", TypeOverrideConfig{DBType: "decimal"}, "go_type is required"},
+		{"missing match", TypeOverrideConfig{GoType: "bool"}, "one of column and db_type is required"},
+		{"both match", TypeOverrideConfig{Column: "user.flag", DBType: "tinyint(1)", GoType: "bool"}, "cannot be set at the same time"},
+		{"invalid column", TypeOverrideConfig{Column: "flag", GoType: "bool"}, "expected table.column"},
+		{"invalid pattern", TypeOverrideConfig{DBType: "decimal[", GoType: "float64"}, "invalid db_type pattern"},
+		{"missing import", TypeOverrideConfig{DBType: "uuid", GoType: "uuid.UUID"}, "import is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTypeOverrides([]TypeOverrideConfig{tt.override})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateTypeOverrides() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateTypeOverrides() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGenerateModuleTypeOverrides type_overrides 替换 model、object、dao Cond 的字段类型与导入，--with-grpc 拒绝非标量类型
+func TestGenerateModuleTypeOverrides(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "shop_order.sql")
+	if err := os.WriteFile(ddlFile, []byte(typeOverrideDDL), 0644); err != nil {
+		t.Fatal(err)
+	}
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: shoporder
+  description: 订单
+  table_name: shop_order
+`+typeOverrideConfig)
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	files := map[string][]string{
+		filepath.Join(appDir, "model", "shop_order.go"): {
+			`"github.com/google/uuid"`,
+			`"github.com/shopspring/decimal"`,
+			`"github.com/example/demoapp/pkg/types"`,
+			"OrderNo uuid.UUID",
+			"Amount decimal.Decimal",
+			"IsPaid bool",
+			"Level int8",
+			"Extra types.OrderExtra `gorm:\"column:extra;type:json;serializer:json;",
+		},
+		filepath.Join(appDir, "object", "objshoporder", "shop_order.go"): {
+			`"github.com/shopspring/decimal"`,
+			"OrderNo uuid.UUID `json:\"orderNo\" form:\"orderNo\" binding:\"required\"`",
+			"Amount decimal.Decimal `json:\"amount\" form:\"amount\"`",
+			"IsPaid bool `json:\"isPaid\" form:\"isPaid\"`",
+			"Extra types.OrderExtra",
+		},
+		filepath.Join(appDir, "dao", "shop_order.go"): {
+			`"github.com/google/uuid"`,
+			"Amount decimal.Decimal",
+			"IsPaid bool",
+		},
+		// gorm 按 map 更新时不经过序列化器，json 列写入序列化后的文本
+		filepath.Join(appDir, "internal", "service", "svcshoporder", "shop_order.go"): {
+			`"extra": gutil.ToJsonString(updateEntity.Extra),`,
+			`"amount": updateEntity.Amount,`,
+		},
+	}
+	for file, wants := range files {
+		content := compactSpaces(readFile(t, file))
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q:\n%s", file, want, content)
+			}
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("generated file %s is not valid Go: %v", file, err)
+		}
+	}
+
+	resetGenerateState()
+	output = captureStdout(t, func() {
+		ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile, "--with-grpc")
+	})
+	if want := "does not support column shop_order.order_no of type uuid.UUID"; !strings.Contains(output, want) {
+		t.Errorf("output missing %q:\n%s", want, output)
+	}
+}
+
+// TestSyncTypeOverrides 新增 type_overrides 后执行 sync，字段改为覆盖的类型并补充导入
+func TestSyncTypeOverrides(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "shop_order.sql")
+	if err := os.WriteFile(ddlFile, []byte(typeOverrideDDL), 0644); err != nil {
+		t.Fatal(err)
+	}
+	restore := chdirToExample(t)
+	defer restore()
+	modelConfig := `
+service_name: mysql
+model:
+  package_name: shoporder
+  description: 订单
+  table_name: shop_order
+`
+	writeCodeGenConfig(t, modelConfig)
+	if _, err := ExecuteCommand(Cmd, "model", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+		t.Fatalf("Failed to execute model command: %v", err)
+	}
+
+	resetGenerateState()
+	writeCodeGenConfig(t, modelConfig+typeOverrideConfig)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "sync", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute sync command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if want := "retyped: OrderNo(string -> uuid.UUID), Amount(float64 -> decimal.Decimal), IsPaid(int8 -> bool), Extra(json.RawMessage -> types.OrderExtra)"; !strings.Contains(output, want) {
+		t.Errorf("sync output missing %q:\n%s", want, output)
+	}
+
+	modelFile := filepath.Join("apps", "demoapp", "model", "shop_order.go")
+	modelSrc := readFile(t, modelFile)
+	for _, want := range []string{
+		`"github.com/shopspring/decimal"`,
+		`"github.com/google/uuid"`,
+		`"github.com/example/demoapp/pkg/types"`,
+		"serializer:json",
+	} {
+		if !strings.Contains(modelSrc, want) {
+			t.Errorf("model file missing %q:\n%s", want, modelSrc)
+		}
+	}
+	objectFile := filepath.Join("apps", "demoapp", "object", "objshoporder", "shop_order.go")
+	if objectSrc := readFile(t, objectFile); strings.Contains(objectSrc, `"encoding/json"`) {
+		t.Errorf("object file should drop the unused encoding/json import:\n%s", objectSrc)
+	}
+	for _, file := range []string{modelFile, objectFile} {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, 0); err != nil {
+			t.Errorf("synced file %s is not valid Go: %v", file, err)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/morehao/golib/gutil"
@@ -22,15 +24,26 @@ const (
 	TplFuncHasTimeField        = "hasTimeField"
 	TplFuncGetFieldImports     = "getFieldImports"
 	TplFuncIsBasicType         = "isBasicType"
-	TplFuncToKebabCase        = "toKebabCase"
-	TplFuncPluralize          = "pluralize"
-	TplFuncIsNumID            = "isNumID"
-	TplFuncIsStringID         = "isStringID"
-	TplFuncIsIntID            = "isIntID"
-	TplFuncHasTimeFieldAny    = "hasTimeFieldAny"
+	TplFuncToKebabCase         = "toKebabCase"
+	TplFuncPluralize           = "pluralize"
+	TplFuncIsNumID             = "isNumID"
+	TplFuncIsStringID          = "isStringID"
+	TplFuncIsIntID             = "isIntID"
+	TplFuncHasTimeFieldAny     = "hasTimeFieldAny"
+	TplFuncHasEnumField        = "hasEnumField"
+	TplFuncSampleValue         = "sampleValue"
+	TplFuncSampleImports       = "sampleImports"
+	TplFuncFirstLetterToUpper  = "firstLetterToUpper"
+	TplFuncToLower             = "toLower"
+	TplFuncNullableToModel     = "nullableToModel"
+	TplFuncNullableToObject    = "nullableToObject"
+	TplFuncNullableModelPtr    = "nullableModelPtr"
+	TplFuncOperatorID          = "operatorID"
+	TplFuncUnixTime            = "unixTime"
 
 	DBTypeMySQL    = "mysql"
 	DBTypePostgres = "postgresql"
+	DBTypeSQLite   = "sqlite"
 )
 
 type DatabaseConfig struct {
@@ -56,38 +69,34 @@ func ParseDatabaseDSN(dsn string) (*DatabaseConfig, error) {
 			Type:    dbType,
 			ConnStr: dsn,
 		}, nil
-	case DBTypeMySQL:
+	case DBTypeMySQL, DBTypeSQLite:
 		return &DatabaseConfig{
 			Type:    dbType,
 			ConnStr: parts[1],
 		}, nil
 	default:
-		return nil, fmt.Errorf("unsupported database type: %s, supported types: mysql, postgres", dbType)
+		return nil, fmt.Errorf("unsupported database type: %s, supported types: mysql, postgresql, sqlite", dbType)
 	}
 }
 
-func IsBuiltInField(name string) bool {
-	buildInFieldMap := map[string]struct{}{
-		"ID":        {},
-		"CreatedAt": {},
-		"UpdatedAt": {},
-		"DeletedAt": {},
+// ddlDialect 根据 database_dsn 的 schema 确定 DDL 方言，未配置或无法识别时返回空，由 DDL 内容自动推断
+func ddlDialect(dsn string) string {
+	dbCfg, err := ParseDatabaseDSN(dsn)
+	if err != nil {
+		return ""
 	}
-	_, ok := buildInFieldMap[name]
+	return dbCfg.Type
+}
+
+// IsBuiltInField 判断是否为由 gorm 维护的内置字段：主键 ID、sys_fields 配置的创建与更新时间列、软删除列
+func IsBuiltInField(name string) bool {
+	_, ok := builtInFieldNames()[name]
 	return ok
 }
 
+// IsSysField 判断是否为系统字段：内置字段、sys_fields 配置的操作人列与 extra 列，不由请求传入
 func IsSysField(name string) bool {
-	sysFieldMap := map[string]struct{}{
-		"ID":        {},
-		"CreatedAt": {},
-		"CreatedBy": {},
-		"UpdatedAt": {},
-		"UpdatedBy": {},
-		"DeletedAt": {},
-		"DeletedBy": {},
-	}
-	_, ok := sysFieldMap[name]
+	_, ok := sysFieldNames()[name]
 	return ok
 }
 
@@ -116,13 +125,45 @@ type FieldTypeImport struct {
 var fieldTypeImportMap = map[string]FieldTypeImport{
 	"json.RawMessage": {ImportPath: "encoding/json", ImportName: "json"},
 	"time.Time":       {ImportPath: "time", ImportName: "time"},
+	// nullable_style 为 sqlnull 时可空列使用的类型，泛型 sql.Null[T] 以 sql.Null 查找
+	"sql.NullString":  {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullInt64":   {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullInt32":   {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullInt16":   {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullByte":    {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullFloat64": {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullBool":    {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.NullTime":    {ImportPath: "database/sql", ImportName: "sql"},
+	"sql.Null":        {ImportPath: "database/sql", ImportName: "sql"},
+	// sys_fields 配置的软删除列类型
+	"gorm.DeletedAt":        {ImportPath: "gorm.io/gorm", ImportName: "gorm"},
+	"soft_delete.DeletedAt": {ImportPath: "gorm.io/plugin/soft_delete", ImportName: "soft_delete"},
+}
+
+// lookupFieldTypeImport 查找字段类型需要的导入，切片与指针按元素类型、泛型按类型名（如 sql.Null[uint64] -> sql.Null）查找，
+// 内置映射之外再查找 type_overrides 中配置的类型
+func lookupFieldTypeImport(fieldType string) (FieldTypeImport, bool) {
+	fieldType = goTypeElem(fieldType)
+	if importInfo, ok := fieldTypeImportMap[fieldType]; ok {
+		return importInfo, true
+	}
+	if cfg != nil {
+		for _, override := range cfg.TypeOverrides {
+			if override.Import != "" && goTypeElem(override.GoType) == fieldType {
+				return FieldTypeImport{ImportPath: override.Import, ImportName: goTypePackage(override.GoType)}, true
+			}
+		}
+	}
+	return FieldTypeImport{}, false
 }
 
 func GetFieldImports(fields []ModelField) map[string]struct{} {
 	imports := make(map[string]struct{})
 	for _, field := range fields {
-		if importInfo, ok := fieldTypeImportMap[field.FieldType]; ok {
-			imports[importInfo.ImportPath] = struct{}{}
+		for _, fieldType := range []string{field.FieldType, field.ModelFieldType} {
+			if importInfo, ok := lookupFieldTypeImport(fieldType); ok {
+				imports[importInfo.ImportPath] = struct{}{}
+			}
 		}
 	}
 	return imports
@@ -185,6 +226,94 @@ func IsBasicType(fieldType string) bool {
 	return ok
 }
 
+// columnLengthRegexp 匹配定长与变长字符串列类型的长度，如 varchar(255)、char(32)
+var columnLengthRegexp = regexp.MustCompile(`^(?:varchar|char)\((\d+)\)`)
+
+// sampleRunIDVar 单元测试中每次运行的唯一标识变量，由模板以 time.Now().UnixNano() 初始化
+const sampleRunIDVar = "sampleRunID"
+
+// sampleIntBounds 唯一索引中的整型字段由 sampleRunID 取模得到示例值，模数不超过类型的取值范围
+var sampleIntBounds = map[string]int64{
+	"int8": 127, "uint8": 255, "int16": 32767, "uint16": 65535,
+}
+
+// SampleValue 生成单元测试中 obj 层字段的示例取值（Go 表达式），枚举字段取首个枚举值，
+// time.Time 字段在 obj 层为 Unix 时间戳；无法构造示例值的类型返回空，由模板跳过该字段。
+// 字符串字段与唯一索引、复合主键中的数值字段由每次运行的 sampleRunID 派生，上次运行失败残留的记录不会触发唯一约束冲突
+func SampleValue(field ModelField) string {
+	// 可空字段在 object 层为指针，测试数据中保持 nil
+	if field.NullableStyle != "" {
+		return ""
+	}
+	if len(field.EnumItems) > 0 {
+		return field.EnumItems[0].Value
+	}
+	switch field.FieldType {
+	case "string":
+		return sampleString(field.ColumnType)
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		// 复合主键列由请求传入，与唯一索引列一样每次运行取不同的值
+		if !field.IsKeyField && (!inUniqueIndex(field) || field.IsPrimaryKey) {
+			return "1"
+		}
+		bound, ok := sampleIntBounds[field.FieldType]
+		if !ok {
+			bound = 1000000000
+		}
+		return fmt.Sprintf("%s(%s%%%d + 1)", field.FieldType, sampleRunIDVar, bound)
+	case "bool":
+		return "true"
+	case "time.Time":
+		return "1700000000"
+	case "json.RawMessage":
+		return "[]byte(`{}`)"
+	}
+	return ""
+}
+
+// sampleString 字符串字段的示例值：test_ 加 sampleRunID 的 36 进制形式（12 位），
+// varchar(N)、char(N) 放不下时只取 sampleRunID 的低位，长度不超过 N
+func sampleString(columnType string) string {
+	full := fmt.Sprintf(`"test_" + strconv.FormatInt(%s, 36)`, sampleRunIDVar)
+	match := columnLengthRegexp.FindStringSubmatch(columnType)
+	if match == nil {
+		return full
+	}
+	length, err := strconv.Atoi(match[1])
+	if err != nil || length >= len("test_")+12 {
+		return full
+	}
+	if length >= 12 {
+		return fmt.Sprintf("strconv.FormatInt(%s, 36)", sampleRunIDVar)
+	}
+	modulus := int64(1)
+	for i := 0; i < length; i++ {
+		modulus *= 36
+	}
+	return fmt.Sprintf("strconv.FormatInt(%s%%%d, 36)", sampleRunIDVar, modulus)
+}
+
+// SampleImports 单元测试示例值需要的导入：存在字符串示例值时导入 strconv，使用 sampleRunID 时导入 time
+func SampleImports(fields []ModelField) []string {
+	var useStrconv, useTime bool
+	for _, field := range fields {
+		if IsSysField(field.FieldName) && !field.IsKeyField {
+			continue
+		}
+		value := SampleValue(field)
+		useStrconv = useStrconv || strings.Contains(value, "strconv.")
+		useTime = useTime || strings.Contains(value, sampleRunIDVar)
+	}
+	var imports []string
+	if useStrconv {
+		imports = append(imports, "strconv")
+	}
+	if useTime {
+		imports = append(imports, "time")
+	}
+	return imports
+}
+
 // RemoveTablePrefixFromStructName 从结构体名中去除表名前缀
 // 例如：表名 iam_users，前缀 iam_，结构体名 IamUsers -> Users
 // 参数：
@@ -333,13 +462,14 @@ func findGitRoot(workDir string) (string, error) {
 }
 
 // GetAppInfo 应用模块路径信息
-// 输入示例：/Users/morehao/xxx/ark-iam/apps/iam
+// 输入示例：/Users/morehao/xxx/ark-iam/apps/iam 或 create project 布局下的 /Users/morehao/xxx/ark-iam/backend/apps/iam
 func GetAppInfo(workDir string) (*AppInfo, error) {
 	cleanPath := filepath.Clean(workDir)
 	segments := strings.Split(cleanPath, string(filepath.Separator))
 
+	// 取离 app 最近的 apps 目录，避免上层路径中同名目录的干扰
 	var appsIndex = -1
-	for i := 0; i < len(segments)-1; i++ {
+	for i := len(segments) - 2; i >= 0; i-- {
 		if segments[i] == "apps" {
 			appsIndex = i
 			break
@@ -354,6 +484,10 @@ func GetAppInfo(workDir string) (*AppInfo, error) {
 
 	appName := segments[appsIndex+1]
 	projectName := segments[appsIndex-1]
+	// create project 生成的布局为 <project>/backend/apps/<app>，项目名取 backend 的上级目录
+	if projectName == "backend" && appsIndex >= 2 && segments[appsIndex-2] != "" {
+		projectName = segments[appsIndex-2]
+	}
 
 	projectRootPath := filepath.Join(segments[:appsIndex]...)
 	if len(projectRootPath) == 0 {
@@ -0,0 +1,219 @@
+package generate
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/morehao/golib/gutil"
+	"gorm.io/gorm"
+)
+
+// bindingRules 根据列约束推导 obj 层的 binding 校验规则：
+//   - NOT NULL 且无默认值 → required；数值、bool 列的 0、false 是合法取值，而 required 会拒绝零值，不加 required
+//   - NOT NULL 且无默认值的枚举列 → 取值不含零值时加 required，含零值时只校验 oneof，零值可正常传入
+//   - 无符号整型 → min=0
+//   - varchar(N)、char(N) → max=N
+//   - 枚举字段 → oneof，不再追加 min、max
+//
+// 非 required 字段存在其他规则时以 omitempty 开头，未传值时跳过校验
+func bindingRules(column ColumnSchema, enumItems []EnumItem) string {
+	if column.IsPrimaryKey {
+		return ""
+	}
+	var rules []string
+	switch {
+	case len(enumItems) > 0:
+		// 枚举字段的取值范围已由 oneof 限定
+		rules = append(rules, "oneof="+enumOneOf(enumItems))
+	case strings.Contains(column.ColumnType, "unsigned") && (IsNumID(column.FieldType) || IsIntID(column.FieldType)):
+		rules = append(rules, "min=0")
+	case column.FieldType == "string":
+		if match := columnLengthRegexp.FindStringSubmatch(column.ColumnType); match != nil {
+			rules = append(rules, "max="+match[1])
+		}
+	}
+	if !column.IsNullable && !column.HasDefault {
+		switch {
+		case len(enumItems) > 0 && enumHasZeroValue(enumItems):
+			return strings.Join(rules, ",")
+		case len(enumItems) > 0 || !zeroValueAllowed(column.FieldType):
+			return strings.Join(append([]string{"required"}, rules...), ",")
+		}
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return strings.Join(append([]string{"omitempty"}, rules...), ",")
+}
+
+// zeroValueAllowed 字段类型的零值是否为合法取值：数值列的 0 与 bool 列的 false
+func zeroValueAllowed(fieldType string) bool {
+	switch fieldType {
+	case "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// enumHasZeroValue 枚举取值是否包含字段类型的零值（0 或空字符串）
+func enumHasZeroValue(items []EnumItem) bool {
+	for _, item := range items {
+		if item.RawValue == "" || item.RawValue == "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// UniqueIndex 唯一索引，用于生成 DAO 按唯一键查询的方法与 service 的重复校验
+type UniqueIndex struct {
+	IndexName  string             // 索引名称
+	FuncSuffix string             // 方法名后缀，由索引字段名拼接，如 TenantIDCode
+	Fields     []UniqueIndexField // 索引字段
+}
+
+// UniqueIndexField 唯一索引字段
+type UniqueIndexField struct {
+	ModelField
+	ParamName string // DAO 方法参数名，与 Go 关键字冲突时追加 Value 后缀
+	seq       int    // 字段在索引中的位置
+}
+
+// buildUniqueIndexes 按列所属的索引还原表的唯一索引，按首次出现的顺序返回，索引字段按其在索引中的位置排序：
+//   - 包含主键的索引跳过，按主键查询已覆盖
+//   - 软删除字段（sys_fields.deleted_at）不参与查询，已软删除的记录由 gorm 默认过滤
+//   - 包含其他系统字段的索引跳过，这些字段不由请求传入，去掉后只校验部分列会误报重复
+func buildUniqueIndexes(fields []ModelField) []UniqueIndex {
+	var indexes []UniqueIndex
+	indexPos := make(map[string]int)
+	skipIndexes := make(map[string]struct{})
+	for _, field := range fields {
+		for _, columnIndex := range field.Indexes {
+			if !columnIndex.IsUnique {
+				continue
+			}
+			if field.IsPrimaryKey || (IsSysField(field.FieldName) && !isSoftDeleteField(field)) {
+				skipIndexes[columnIndex.Name] = struct{}{}
+				continue
+			}
+			if isSoftDeleteField(field) {
+				continue
+			}
+			pos, ok := indexPos[columnIndex.Name]
+			if !ok {
+				pos = len(indexes)
+				indexPos[columnIndex.Name] = pos
+				indexes = append(indexes, UniqueIndex{IndexName: columnIndex.Name})
+			}
+			paramName := gutil.FirstLetterToLower(field.FieldName)
+			if token.IsKeyword(paramName) {
+				paramName += "Value"
+			}
+			indexes[pos].Fields = append(indexes[pos].Fields, UniqueIndexField{ModelField: field, ParamName: paramName, seq: columnIndex.Seq})
+		}
+	}
+
+	result := make([]UniqueIndex, 0, len(indexes))
+	suffixSet := make(map[string]struct{}, len(indexes))
+	for _, index := range indexes {
+		if _, skip := skipIndexes[index.IndexName]; skip {
+			continue
+		}
+		sort.SliceStable(index.Fields, func(i, j int) bool { return index.Fields[i].seq < index.Fields[j].seq })
+		for _, field := range index.Fields {
+			index.FuncSuffix += field.FieldName
+		}
+		// 不同索引的字段组合相同时只保留一个，避免生成同名方法
+		if _, exists := suffixSet[index.FuncSuffix]; exists {
+			continue
+		}
+		suffixSet[index.FuncSuffix] = struct{}{}
+		result = append(result, index)
+	}
+	return result
+}
+
+// isSoftDeleteField 是否 sys_fields 配置的软删除字段，soft_delete 为 none 时没有软删除字段
+func isSoftDeleteField(field ModelField) bool {
+	deletedAt := currentSysFields().DeletedAt
+	return deletedAt != "" && field.ColumnName == deletedAt
+}
+
+// inUniqueIndex 字段是否属于某个唯一索引
+func inUniqueIndex(field ModelField) bool {
+	for _, columnIndex := range field.Indexes {
+		if columnIndex.IsUnique {
+			return true
+		}
+	}
+	return false
+}
+
+// loadColumnHasDefault 查询表中各列是否有默认值（含自增列），key 为列名，SQLite 不经过该查询
+func loadColumnHasDefault(db *gorm.DB, dialect, tableName string) (map[string]bool, error) {
+	var query string
+	switch dialect {
+	case DBTypeMySQL:
+		query = `SELECT COLUMN_NAME AS column_name, (COLUMN_DEFAULT IS NOT NULL OR EXTRA LIKE '%auto_increment%') AS has_default
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`
+	case DBTypePostgres:
+		query = `SELECT column_name, (column_default IS NOT NULL OR is_identity = 'YES' OR is_generated = 'ALWAYS') AS has_default
+FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = ?`
+	default:
+		return nil, nil
+	}
+	var rows []struct {
+		ColumnName string `gorm:"column:column_name"`
+		HasDefault bool   `gorm:"column:has_default"`
+	}
+	if err := db.Raw(query, tableName).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("get table %s column defaults error: %v", tableName, err)
+	}
+	hasDefaults := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		hasDefaults[row.ColumnName] = row.HasDefault
+	}
+	return hasDefaults, nil
+}
+
+// loadColumnIndexes 查询表中各列所属的全部索引（主键除外），key 为列名，SQLite 不经过该查询
+func loadColumnIndexes(db *gorm.DB, dialect, tableName string) (map[string][]ColumnIndex, error) {
+	var query string
+	switch dialect {
+	case DBTypeMySQL:
+		query = `SELECT INDEX_NAME AS index_name, COLUMN_NAME AS column_name, NON_UNIQUE = 0 AS is_unique, SEQ_IN_INDEX AS seq
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY'
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+	case DBTypePostgres:
+		query = `SELECT i.relname AS index_name, a.attname AS column_name, ix.indisunique AS is_unique, k.ord AS seq
+FROM pg_index ix
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE n.nspname = current_schema() AND t.relname = ? AND NOT ix.indisprimary
+ORDER BY i.relname, k.ord`
+	default:
+		return nil, nil
+	}
+	var rows []struct {
+		IndexName  string `gorm:"column:index_name"`
+		ColumnName string `gorm:"column:column_name"`
+		IsUnique   bool   `gorm:"column:is_unique"`
+		Seq        int    `gorm:"column:seq"`
+	}
+	if err := db.Raw(query, tableName).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("get table %s indexes error: %v", tableName, err)
+	}
+	columnIndexes := make(map[string][]ColumnIndex)
+	for _, row := range rows {
+		columnIndexes[row.ColumnName] = append(columnIndexes[row.ColumnName], ColumnIndex{Name: row.IndexName, IsUnique: row.IsUnique, Seq: row.Seq})
+	}
+	return columnIndexes, nil
+}
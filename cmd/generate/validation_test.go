@@ -0,0 +1,213 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestBindingRules(t *testing.T) {
+	tables, err := ParseDDL("CREATE TABLE `account` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `tenant_id` bigint unsigned NOT NULL,\n"+
+		"  `email` varchar(128) NOT NULL,\n"+
+		"  `nickname` varchar(32) NOT NULL DEFAULT '',\n"+
+		"  `code` char(8) DEFAULT NULL,\n"+
+		"  `score` int NOT NULL DEFAULT 0,\n"+
+		"  `balance` int unsigned DEFAULT NULL,\n"+
+		"  `status` tinyint NOT NULL COMMENT '状态: 1-启用,2-禁用',\n"+
+		"  `verified` tinyint NOT NULL COMMENT '是否认证: 0-否,1-是',\n"+
+		"  `sort_order` int NOT NULL,\n"+
+		"  `birthday` date NOT NULL,\n"+
+		"  `remark` text,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");", DBTypeMySQL)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	want := map[string]string{
+		"ID":        "",
+		"TenantID":  "omitempty,min=0",
+		"Email":     "required,max=128",
+		"Nickname":  "omitempty,max=32",
+		"Code":      "omitempty,max=8",
+		"Score":     "",
+		"Balance":   "omitempty,min=0",
+		"Status":    "required,oneof=1 2",
+		"Verified":  "oneof=0 1",
+		"SortOrder": "",
+		"Birthday":  "required",
+		"Remark":    "",
+	}
+	for _, field := range buildModelFields(tables[0].Columns, "Account") {
+		if field.Binding != want[field.FieldName] {
+			t.Errorf("%s binding = %q, want %q", field.FieldName, field.Binding, want[field.FieldName])
+		}
+	}
+
+	if got := bindingRules(ColumnSchema{FieldType: "bool", ColumnType: "boolean"}, nil); got != "" {
+		t.Errorf("bool binding = %q, want empty", got)
+	}
+
+	column := findColumn(t, tables[0], "nickname")
+	if !column.HasDefault || column.DefaultValue != "" {
+		t.Errorf("nickname column = %+v", column)
+	}
+	if column := findColumn(t, tables[0], "code"); column.HasDefault {
+		t.Errorf("DEFAULT NULL should not count as default: %+v", column)
+	}
+}
+
+func TestBuildUniqueIndexes(t *testing.T) {
+	uk := func(name string, seq int) ColumnIndex { return ColumnIndex{Name: name, IsUnique: true, Seq: seq} }
+	fields := []ModelField{
+		{FieldName: "ID", IsPrimaryKey: true, Indexes: []ColumnIndex{uk("uk_id_name", 1)}},
+		{FieldName: "Type", Indexes: []ColumnIndex{uk("uk_tenant_code", 2)}},
+		{FieldName: "TenantID", Indexes: []ColumnIndex{uk("uk_tenant_code", 1)}},
+		{FieldName: "Email", Indexes: []ColumnIndex{uk("uk_email", 1), uk("uk_email_dup", 1), uk("uk_email_creator", 1)}},
+		{FieldName: "DeletedAt", ColumnName: "deleted_at", Indexes: []ColumnIndex{uk("uk_email", 2)}},
+		{FieldName: "CreatedBy", Indexes: []ColumnIndex{uk("uk_email_creator", 2)}},
+		{FieldName: "Name", Indexes: []ColumnIndex{uk("uk_id_name", 2), {Name: "idx_name", Seq: 1}}},
+	}
+	var got []string
+	for _, index := range buildUniqueIndexes(fields) {
+		var params []string
+		for _, field := range index.Fields {
+			params = append(params, field.ParamName)
+		}
+		got = append(got, index.IndexName+":GetBy"+index.FuncSuffix+"("+strings.Join(params, ",")+")")
+	}
+	// uk_id_name 含主键、uk_email_creator 含不由请求传入的 created_by，均跳过；uk_email_dup 与 uk_email 去掉 deleted_at 后重复
+	want := []string{"uk_tenant_code:GetByTenantIDType(tenantID,typeValue)", "uk_email:GetByEmail(email)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unique indexes = %v, want %v", got, want)
+	}
+}
+
+// TestApplyIndexes 列属于多个索引时记录全部索引及其在索引中的位置
+func TestApplyIndexes(t *testing.T) {
+	tables, err := ParseDDL("CREATE TABLE `account` (\n"+
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `tenant_id` bigint unsigned NOT NULL,\n"+
+		"  `email` varchar(128) NOT NULL,\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  KEY `idx_email` (`email`),\n"+
+		"  UNIQUE KEY `uk_tenant_email` (`tenant_id`, `email`)\n"+
+		");", DBTypeMySQL)
+	if err != nil {
+		t.Fatalf("ParseDDL error: %v", err)
+	}
+	column := findColumn(t, tables[0], "email")
+	want := []ColumnIndex{{Name: "idx_email", Seq: 1}, {Name: "uk_tenant_email", IsUnique: true, Seq: 2}}
+	if !reflect.DeepEqual(column.Indexes, want) {
+		t.Errorf("email indexes = %+v, want %+v", column.Indexes, want)
+	}
+	if column.IndexName != "idx_email" || column.IsUniqueIndex {
+		t.Errorf("email index name = %s, unique = %v, want first index idx_email", column.IndexName, column.IsUniqueIndex)
+	}
+	if got := buildUniqueIndexes(buildModelFields(tables[0].Columns, "Account")); len(got) != 1 || got[0].FuncSuffix != "TenantIDEmail" {
+		t.Errorf("unique indexes = %+v, want GetByTenantIDEmail", got)
+	}
+}
+
+// TestGenerateModuleValidation 列约束生成 binding 校验，唯一索引生成 DAO 查询、创建与更新时的重复校验与 AlreadyExist 错误码
+func TestGenerateModuleValidation(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(t.TempDir(), "member.sql")
+	ddl := "CREATE TABLE `member` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',\n" +
+		"  `tenant_id` bigint unsigned NOT NULL COMMENT '租户ID',\n" +
+		"  `email` varchar(128) NOT NULL COMMENT '邮箱',\n" +
+		"  `nickname` varchar(32) NOT NULL DEFAULT '' COMMENT '昵称',\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n" +
+		"  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',\n" +
+		"  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_tenant_email` (`tenant_id`, `email`)\n" +
+		") ENGINE=InnoDB COMMENT='会员表';\n"
+	if err := os.WriteFile(ddlFile, []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdirToExample(t)
+	defer restore()
+	writeCodeGenConfig(t, `
+service_name: mysql
+module:
+  package_name: member
+  description: 会员
+  table_name: member
+`)
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+
+	appDir := filepath.Join("apps", "demoapp")
+	object := readFile(t, filepath.Join(appDir, "object", "objmember", "member.go"))
+	for _, want := range []string{
+		"`json:\"tenantID\" form:\"tenantID\" binding:\"omitempty,min=0\"`",
+		"`json:\"email\" form:\"email\" binding:\"required,max=128\"`",
+		"`json:\"nickname\" form:\"nickname\" binding:\"omitempty,max=32\"`",
+	} {
+		if !strings.Contains(object, want) {
+			t.Errorf("object file missing %q:\n%s", want, object)
+		}
+	}
+
+	dao := readFile(t, filepath.Join(appDir, "dao", "member.go"))
+	for _, want := range []string{
+		"// GetByTenantIDEmail 根据唯一索引 uk_tenant_email 查询，记录不存在时返回 nil",
+		"func (d *MemberDao) GetByTenantIDEmail(ctx context.Context, tenantID uint, email string) (*model.MemberEntity, error) {",
+		`err := dbclient.MysqlDB(ctx).Where("tenant_id = ?", tenantID).Where("email = ?", email).Take(&entity).Error`,
+	} {
+		if !strings.Contains(dao, want) {
+			t.Errorf("dao file missing %q:\n%s", want, dao)
+		}
+	}
+
+	service := readFile(t, filepath.Join(appDir, "internal", "service", "svcmember", "member.go"))
+	for _, want := range []string{
+		"existTenantIDEmailEntity, err := dao.NewMemberDao().GetByTenantIDEmail(ctx, insertEntity.TenantID, insertEntity.Email)",
+		"if existTenantIDEmailEntity != nil {\n\t\treturn nil, code.GetError(code.MemberAlreadyExistError)\n\t}",
+		"existTenantIDEmailEntity, err := dao.NewMemberDao().GetByTenantIDEmail(ctx, updateEntity.TenantID, updateEntity.Email)",
+		"if existTenantIDEmailEntity != nil && existTenantIDEmailEntity.ID != req.MemberID {\n\t\treturn code.GetError(code.MemberAlreadyExistError)\n\t}",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service file missing %q:\n%s", want, service)
+		}
+	}
+
+	// Update 校验的列必须是实际写入的列，否则校验的值与落库的值不一致
+	update := service[strings.Index(service, "func (svc *memberSvc) Update("):]
+	update = update[:strings.Index(update, "\n}\n")]
+	updateMap := update[strings.Index(update, "updateMap := map[string]any{"):]
+	checked := regexp.MustCompile(`updateEntity\.(\w+)[,)]`).FindAllStringSubmatch(update[:strings.Index(update, "updateMap :=")], -1)
+	if len(checked) == 0 {
+		t.Fatalf("Update has no unique check:\n%s", update)
+	}
+	written := make(map[string]bool)
+	for _, match := range regexp.MustCompile(`"\w+":\s+updateEntity\.(\w+),`).FindAllStringSubmatch(updateMap, -1) {
+		written[match[1]] = true
+	}
+	for _, match := range checked {
+		if !written[match[1]] {
+			t.Errorf("Update checks %s but does not write it:\n%s", match[1], update)
+		}
+	}
+	for _, want := range []string{`"tenant_id": updateEntity.TenantID,`, `"email":     updateEntity.Email,`, `"nickname":  updateEntity.Nickname,`} {
+		if !strings.Contains(updateMap, want) {
+			t.Errorf("updateMap missing %q:\n%s", want, updateMap)
+		}
+	}
+
+	codeFile := readFile(t, filepath.Join("pkg", "code", "member.go"))
+	if !strings.Contains(codeFile, `MemberAlreadyExistError: "会员已存在",`) {
+		t.Errorf("code file missing AlreadyExistError:\n%s", codeFile)
+	}
+}
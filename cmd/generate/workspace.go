@@ -0,0 +1,79 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/morehao/gocli/internal/scaffold"
+)
+
+// resolveAppDir 定位 app 目录，支持在仓库根目录、backend/ 或 app 目录内执行：
+//   - 自当前目录向上查找 go.work，在其 use 目录中匹配 apps/<appName>（create project 生成的布局为 backend/apps/<app>）
+//   - 未找到 go.work 或 use 中没有该 app 时，自当前目录向上依次尝试 <dir>/apps/<appName> 与 <dir>/backend/apps/<appName>，
+//     查找到首个包含 go.work 或 go.mod 的目录（项目根目录）为止，不会越过项目根目录匹配到其他项目的 app
+func resolveAppDir(currentDir, appName string) (string, error) {
+	if goWorkPath, ok := findGoWork(currentDir); ok {
+		useDirs, err := scaffold.GoWorkUseDirs(goWorkPath)
+		if err != nil {
+			return "", fmt.Errorf("parse go.work error: %v", err)
+		}
+		for _, useDir := range useDirs {
+			appDir := filepath.FromSlash(useDir)
+			if !filepath.IsAbs(appDir) {
+				appDir = filepath.Join(filepath.Dir(goWorkPath), appDir)
+			}
+			if isAppDir(appDir, appName) {
+				return appDir, nil
+			}
+		}
+	}
+
+	dir := currentDir
+	for {
+		// 在 app 目录内执行，app 自身的 go.mod 不是项目根目录
+		if isAppDir(dir, appName) {
+			return dir, nil
+		}
+		for _, appDir := range []string{
+			filepath.Join(dir, "apps", appName),
+			filepath.Join(dir, "backend", "apps", appName),
+		} {
+			if isDir(appDir) {
+				return appDir, nil
+			}
+		}
+		if scaffold.IsGoProject(dir) {
+			return "", fmt.Errorf("app directory does not exist: apps/%s not found in project %s", appName, dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("project root not found: no go.work or go.mod from %s", currentDir)
+		}
+		dir = parent
+	}
+}
+
+// isAppDir 判断 dir 是否为 apps/<appName> 目录
+func isAppDir(dir, appName string) bool {
+	return filepath.Base(dir) == appName && filepath.Base(filepath.Dir(dir)) == "apps" && isDir(dir)
+}
+
+// findGoWork 自 dir 向上查找最近的 go.work
+func findGoWork(dir string) (string, bool) {
+	for {
+		if scaffold.IsGoWork(dir) {
+			return filepath.Join(dir, "go.work"), true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,158 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestResolveAppDir 在仓库根目录、backend/ 与 app 目录内均能定位 app 目录
+func TestResolveAppDir(t *testing.T) {
+	t.Run("create project layout", func(t *testing.T) {
+		rootDir := t.TempDir()
+		appDir := filepath.Join(rootDir, "backend", "apps", "demo")
+		for _, dir := range []string{filepath.Join(appDir, "internal", "router"), filepath.Join(rootDir, "backend", "pkg")} {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(rootDir, "go.work"),
+			[]byte("go 1.26.1\n\nuse (\n\t./backend/apps/demo\n\t./backend/pkg\n)\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, currentDir := range []string{rootDir, filepath.Join(rootDir, "backend"), filepath.Join(appDir, "internal", "router")} {
+			got, err := resolveAppDir(currentDir, "demo")
+			if err != nil {
+				t.Fatalf("resolveAppDir(%s) error: %v", currentDir, err)
+			}
+			if got != appDir {
+				t.Errorf("resolveAppDir(%s) = %s, want %s", currentDir, got, appDir)
+			}
+		}
+		if _, err := resolveAppDir(rootDir, "notexist"); err == nil {
+			t.Error("resolveAppDir should fail for unknown app")
+		}
+	})
+
+	t.Run("apps without go.work", func(t *testing.T) {
+		rootDir := t.TempDir()
+		appDir := filepath.Join(rootDir, "apps", "demoapp")
+		if err := os.MkdirAll(filepath.Join(appDir, "config"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for _, goModDir := range []string{rootDir, appDir} {
+			if err := os.WriteFile(filepath.Join(goModDir, "go.mod"), []byte("module example.com/demo\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, currentDir := range []string{rootDir, filepath.Join(appDir, "config")} {
+			if got, err := resolveAppDir(currentDir, "demoapp"); err != nil || got != appDir {
+				t.Errorf("resolveAppDir(%s) = %s, %v, want %s", currentDir, got, err, appDir)
+			}
+		}
+	})
+
+	t.Run("stop at project root", func(t *testing.T) {
+		// 上层目录中存在同名 app，查找到项目根目录（go.mod 所在目录）后不再向上
+		outerDir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(outerDir, "apps", "demoapp"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		projectDir := filepath.Join(outerDir, "projects", "other")
+		if err := os.MkdirAll(filepath.Join(projectDir, "apps", "user"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/other\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := resolveAppDir(projectDir, "demoapp"); err == nil {
+			t.Errorf("resolveAppDir should fail outside the project root, got %s", got)
+		}
+	})
+}
+
+// TestGetAppInfoBackendLayout create project 布局下项目根目录为 backend/，项目名取仓库目录名
+func TestGetAppInfoBackendLayout(t *testing.T) {
+	rootDir := filepath.Join(t.TempDir(), "apps", "ark-demo")
+	appDir := filepath.Join(rootDir, "backend", "apps", "demo")
+	if err := os.MkdirAll(appDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "go.mod"),
+		[]byte("module github.com/example/ark-demo/demo\n\ngo 1.26.1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := GetAppInfo(appDir)
+	if err != nil {
+		t.Fatalf("GetAppInfo error: %v", err)
+	}
+	if info.ProjectRootPath != filepath.Join(rootDir, "backend") {
+		t.Errorf("ProjectRootPath = %q, want %q", info.ProjectRootPath, filepath.Join(rootDir, "backend"))
+	}
+	if info.ProjectName != "ark-demo" || info.AppName != "demo" {
+		t.Errorf("ProjectName = %q, AppName = %q", info.ProjectName, info.AppName)
+	}
+	if info.BaseModulePath != "github.com/example/ark-demo" || info.AppModuleName != "demo" {
+		t.Errorf("BaseModulePath = %q, AppModuleName = %q", info.BaseModulePath, info.AppModuleName)
+	}
+}
+
+// TestGenerateModuleFromWorkspaceRoot 在 create project 布局的仓库根目录执行生成，代码与错误码写入 backend/ 下
+func TestGenerateModuleFromWorkspaceRoot(t *testing.T) {
+	resetGenerateState()
+	ddlFile := filepath.Join(repoTemplateDir(t), "ark", "scripts", "sql", "table_init.sql")
+
+	restore := chdirToExample(t)
+	defer restore()
+	exampleDir, _ := os.Getwd()
+
+	// 将示例副本调整为 <root>/backend/{apps,pkg} 布局，go.work 位于仓库根目录
+	rootDir := filepath.Join(filepath.Dir(exampleDir), "ark-demo")
+	backendDir := filepath.Join(rootDir, "backend")
+	if err := os.MkdirAll(rootDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(exampleDir, backendDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(backendDir, "go.work")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootDir, "go.work"),
+		[]byte("go 1.26.1\n\nuse (\n\t./backend/apps/demoapp\n\t./backend/pkg\n)\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(rootDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(backendDir, "apps", "demoapp", "config", "code_gen.yaml"), []byte(`service_name: mysql
+module:
+  package_name: loginlog
+  description: 登录日志
+  table_name: user_login_log
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureStdout(t, func() {
+		if _, err := ExecuteCommand(Cmd, "module", "--app", "demoapp", "--ddl", ddlFile); err != nil {
+			t.Errorf("Failed to execute module command: %v", err)
+		}
+	})
+	assertGenerateSuccess(t, output)
+	if cfg.appInfo.ProjectRootPath != backendDir {
+		t.Errorf("ProjectRootPath = %s, want %s", cfg.appInfo.ProjectRootPath, backendDir)
+	}
+	for _, file := range []string{
+		filepath.Join(backendDir, "apps", "demoapp", "internal", "controller", "ctrloginlog", "user_login_log.go"),
+		filepath.Join(backendDir, "pkg", "code", "loginlog.go"),
+	} {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("generated file not found: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, "pkg")); !os.IsNotExist(err) {
+		t.Errorf("pkg/ should not be created at the repository root")
+	}
+}
@@ -8,7 +8,9 @@ import (
 )
 {{if not .TargetFileExist}}
 type {{.StructName}}Ctr interface {
+{{- range .Endpoints}}
 	{{.FunctionName}}(ctx *gin.Context)
+{{- end}}
 }
 
 type {{.StructNameLowerCamel}}Ctr struct {
@@ -23,48 +25,49 @@ func New{{.StructName}}Ctr() {{.StructName}}Ctr {
 	}
 }
 {{end}}
-{{if eq .HttpMethod "POST"}}
+
+{{- range .Endpoints}}
 // {{.FunctionName}} {{.Description}}
 // @Tags {{.ApiDocTag}}
 // @Summary {{.Description}}
 // @accept application/json
 // @Produce application/json
-// @Param req body dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req true "{{.Description}}"
-// @Success 200 {object} gincontext.DtoRender{data=dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Resp} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "success"}"
-// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{.FunctionNameLowerCamel}} [post]
-func (ctr *{{.StructNameLowerCamel}}Ctr) {{.FunctionName}}(ctx *gin.Context) {
-	var req dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+{{- range .PathParams}}
+// @Param {{.TagName}} path {{.DocType}} true "{{if .Comment}}{{.Comment}}{{else}}{{.TagName}}{{end}}"
+{{- end}}
+{{- if .BindQuery}}
+// @Param req query dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Req true "{{.Description}}"
+{{- end}}
+{{- if .BindBody}}
+// @Param req body dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Req true "{{.Description}}"
+{{- end}}
+// @Success 200 {object} gincontext.DtoRender{data=dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Resp} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "success"}"
+// @Router /v1/{{$.AppName}}/{{toKebabCase (pluralize $.StructNameLowerCamel)}}{{range .PathParams}}/{{`{`}}{{.TagName}}{{`}`}}{{end}}/{{.FunctionNameLowerCamel}} [{{toLower .HttpMethod}}]
+func (ctr *{{$.StructNameLowerCamel}}Ctr) {{.FunctionName}}(ctx *gin.Context) {
+	var req dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Req
+{{- if .PathParams}}
+	if err := gincontext.BindPathParams(ctx, &req); err != nil {
 		gincontext.Fail(ctx, err)
 		return
 	}
-	res, err := ctr.{{.StructNameLowerCamel}}Svc.{{.FunctionName}}(ctx, &req)
-	if err != nil {
+{{- end}}
+{{- if .BindQuery}}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
 		gincontext.Fail(ctx, err)
 		return
 	}
-	gincontext.Success(ctx, res)
-}
-{{else if eq .HttpMethod "GET"}}
-// {{.FunctionName}} {{.Description}}
-// @Tags {{.ApiDocTag}}
-// @Summary {{.Description}}
-// @accept application/json
-// @Produce application/json
-// @Param req query dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req true "{{.Description}}"
-// @Success 200 {object} gincontext.DtoRender{data=dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Resp} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "success"}"
-// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{.FunctionNameLowerCamel}} [get]
-func (ctr *{{.StructNameLowerCamel}}Ctr) {{.FunctionName}}(ctx *gin.Context) {
-	var req dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req
-	if err := ctx.ShouldBindQuery(&req); err != nil {
+{{- end}}
+{{- if .BindBody}}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		gincontext.Fail(ctx, err)
 		return
 	}
-	res, err := ctr.{{.StructNameLowerCamel}}Svc.{{.FunctionName}}(ctx, &req)
+{{- end}}
+	res, err := ctr.{{$.StructNameLowerCamel}}Svc.{{.FunctionName}}(ctx, &req)
 	if err != nil {
 		gincontext.Fail(ctx, err)
 		return
 	}
 	gincontext.Success(ctx, res)
 }
-{{end}}
+{{end}}
\ No newline at end of file
@@ -1,4 +1,21 @@
 package dto{{.PackageName}}
-
-type {{.StructName}}{{.FunctionName}}Req struct {
+{{if .RequestImports}}
+import (
+{{- range .RequestImports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{- range .Endpoints}}
+type {{$.StructName}}{{.FunctionName}}Req struct {
+{{- range .RequestFields}}
+{{- if eq .Source "path"}}
+	{{.FieldName}} {{.FieldType}} `json:"-" uri:"{{.TagName}}" binding:"{{.Binding}}"`{{if .Comment}} // {{.Comment}}{{end}}
+{{- else if eq .Source "query"}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.TagName}}" form:"{{.TagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}`{{if .Comment}} // {{.Comment}}{{end}}
+{{- else}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.TagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}`{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+{{- end}}
 }
+{{end}}
\ No newline at end of file
@@ -1,4 +1,15 @@
 package dto{{.PackageName}}
-
-type {{.StructName}}{{.FunctionName}}Resp struct {
+{{if .ResponseImports}}
+import (
+{{- range .ResponseImports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{- range .Endpoints}}
+type {{$.StructName}}{{.FunctionName}}Resp struct {
+{{- range .ResponseFields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.TagName}}"`{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
 }
+{{end}}
\ No newline at end of file
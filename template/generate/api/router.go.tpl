@@ -11,6 +11,8 @@ func {{.StructNameLowerCamel}}Router(groups *ginserver.RouterGroups) {
 
 	v1RouterGroup := groups.MustGetGroup(ginserver.ApiVersionV1)
 
-	v1RouterGroup.{{.HttpMethod}}("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{.FunctionNameLowerCamel}}", {{.StructNameLowerCamel}}Ctr.{{.FunctionName}})
+{{- range .Endpoints}}
+	v1RouterGroup.{{.HttpMethod}}("/{{toKebabCase (pluralize $.StructNameLowerCamel)}}{{range .PathParams}}/:{{.TagName}}{{end}}/{{.FunctionNameLowerCamel}}", {{$.StructNameLowerCamel}}Ctr.{{.FunctionName}})
+{{- end}}
 }
 {{end}}
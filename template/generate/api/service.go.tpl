@@ -7,7 +7,9 @@ import (
 
 {{if not .TargetFileExist}}
 type {{.StructName}}Svc interface {
-    {{.FunctionName}}(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req) (*dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Resp, error)
+{{- range .Endpoints}}
+    {{.FunctionName}}(ctx *gin.Context, req *dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Req) (*dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Resp, error)
+{{- end}}
 }
 
 type {{.StructNameLowerCamel}}Svc struct {
@@ -20,6 +22,9 @@ func New{{.StructName}}Svc() {{.StructName}}Svc {
     }
 }
 {{end}}
-func (svc *{{.StructNameLowerCamel}}Svc) {{.FunctionName}}(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Req) (*dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Resp, error) {
-    return &dto{{.PackageName}}.{{.StructName}}{{.FunctionName}}Resp{}, nil
+{{- range .Endpoints}}
+// {{.FunctionName}} {{.Description}}
+func (svc *{{$.StructNameLowerCamel}}Svc) {{.FunctionName}}(ctx *gin.Context, req *dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Req) (*dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Resp, error) {
+    return &dto{{$.PackageName}}.{{$.StructName}}{{.FunctionName}}Resp{}, nil
 }
+{{end}}
@@ -0,0 +1,61 @@
+package grpc{{.PackageName}}
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// ginHandlerKey 请求 context 中待执行的 service 调用
+type ginHandlerKey struct{}
+
+// ginEngine 为 service 调用提供 *gin.Context：每次调用经 ServeHTTP 从 engine 的上下文池取得 *gin.Context，
+// 开启 ContextWithFallback 后 Deadline、Done、Value 委托给 gRPC 请求的 context
+var ginEngine = func() *gin.Engine {
+	engine := gin.New()
+	engine.ContextWithFallback = true
+	engine.POST("/", func(ginCtx *gin.Context) {
+		ginCtx.Value(ginHandlerKey{}).(func(*gin.Context))(ginCtx)
+	})
+	return engine
+}()
+
+// withGinContext 将 gRPC 请求的 context 包装为 service 层使用的 *gin.Context 并调用 fn，incoming metadata 转为请求头。
+// *gin.Context 归还上下文池后会被复用，只能在 fn 内使用
+func withGinContext(ctx context.Context, fn func(ginCtx *gin.Context) error) error {
+	header := make(http.Header)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for key, values := range md {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	var err error
+	handler := func(ginCtx *gin.Context) {
+		err = fn(ginCtx)
+	}
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/"},
+		Header: header,
+	}
+	ginEngine.ServeHTTP(&discardResponseWriter{header: make(http.Header)}, req.WithContext(context.WithValue(ctx, ginHandlerKey{}, handler)))
+	return err
+}
+
+// discardResponseWriter service 的结果通过返回值传递，写入 *gin.Context 的响应直接丢弃
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
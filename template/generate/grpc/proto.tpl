@@ -0,0 +1,75 @@
+syntax = "proto3";
+
+package {{.ProtoPackage}};
+
+option go_package = "{{.BaseModulePath}}/{{.AppModuleName}}/proto/pb{{.PackageName}};pb{{.PackageName}}";
+
+import "google/protobuf/empty.proto";
+
+// {{.StructName}}Service {{.Description}}服务
+service {{.StructName}}Service {
+  // Create 创建{{.Description}}
+  rpc Create({{.StructName}}CreateReq) returns ({{.StructName}}CreateResp);
+  // Delete 删除{{.Description}}
+  rpc Delete({{.StructName}}DeleteReq) returns (google.protobuf.Empty);
+  // Update 更新{{.Description}}
+  rpc Update({{.StructName}}UpdateReq) returns (google.protobuf.Empty);
+  // Detail 根据id获取{{.Description}}
+  rpc Detail({{.StructName}}DetailReq) returns ({{.StructName}}DetailResp);
+  // PageList 分页获取{{.Description}}列表
+  rpc PageList({{.StructName}}PageListReq) returns ({{.StructName}}PageListResp);
+}
+
+message {{.StructName}}BaseInfo {
+{{- range .BaseFields}}
+  {{if .Repeated}}repeated {{else if .Optional}}optional {{end}}{{.ProtoType}} {{.ProtoName}} = {{.Number}};{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+message {{.StructName}}CreateReq {
+  {{.StructName}}BaseInfo base_info = 1;
+}
+
+message {{.StructName}}CreateResp {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+}
+
+message {{.StructName}}DeleteReq {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+}
+
+message {{.StructName}}UpdateReq {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+  {{.StructName}}BaseInfo base_info = 2;
+}
+
+message {{.StructName}}DetailReq {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+}
+
+message {{.StructName}}DetailResp {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+  {{.StructName}}BaseInfo base_info = 2;
+  int64 created_at = 3; // 创建时间，Unix 时间戳
+  int64 updated_at = 4; // 更新时间，Unix 时间戳
+}
+
+message {{.StructName}}PageListReq {
+  int32 page = 1; // 页码
+  int32 page_size = 2; // 每页条数
+{{- range .PageListFields}}
+  {{if .Repeated}}repeated {{else if .Optional}}optional {{end}}{{.ProtoType}} {{.ProtoName}} = {{.Number}};{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+message {{.StructName}}PageListItem {
+  {{.PK.ProtoType}} id = 1; // 主键 ID
+  {{.StructName}}BaseInfo base_info = 2;
+  int64 created_at = 3; // 创建时间，Unix 时间戳
+  int64 updated_at = 4; // 更新时间，Unix 时间戳
+}
+
+message {{.StructName}}PageListResp {
+  repeated {{.StructName}}PageListItem list = 1; // 数据列表
+  int64 total = 2; // 数据总条数
+}
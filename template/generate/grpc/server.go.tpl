@@ -0,0 +1,203 @@
+package grpc{{.PackageName}}
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/morehao/golib/gerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/internal/dto/dto{{.PackageName}}"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/internal/service/svc{{.PackageName}}"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/object/obj{{.PackageName}}"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/proto/pb{{.PackageName}}"
+	"{{.BaseModulePath}}/pkg/code"
+)
+
+// {{.StructNameLowerCamel}}Server {{.Description}} gRPC 服务，业务逻辑委托给 svc{{.PackageName}}.{{.StructName}}Svc
+type {{.StructNameLowerCamel}}Server struct {
+	pb{{.PackageName}}.Unimplemented{{.StructName}}ServiceServer
+	svc svc{{.PackageName}}.{{.StructName}}Svc
+}
+
+// Register{{.StructName}}Server 将{{.Description}}服务注册到 gRPC 服务器
+func Register{{.StructName}}Server(registrar grpc.ServiceRegistrar) {
+	pb{{.PackageName}}.Register{{.StructName}}ServiceServer(registrar, &{{.StructNameLowerCamel}}Server{
+		svc: svc{{.PackageName}}.New{{.StructName}}Svc(),
+	})
+}
+
+// Create 创建{{.Description}}
+func (s *{{.StructNameLowerCamel}}Server) Create(ctx context.Context, req *pb{{.PackageName}}.{{.StructName}}CreateReq) (*pb{{.PackageName}}.{{.StructName}}CreateResp, error) {
+	var resp *dto{{.PackageName}}.{{.StructName}}CreateResp
+	err := withGinContext(ctx, func(ginCtx *gin.Context) (err error) {
+		resp, err = s.svc.Create(ginCtx, &dto{{.PackageName}}.{{.StructName}}CreateReq{
+			{{.StructName}}BaseInfo: {{.StructNameLowerCamel}}BaseInfoFromPb(req.GetBaseInfo()),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, {{.StructNameLowerCamel}}StatusError(err)
+	}
+	return &pb{{.PackageName}}.{{.StructName}}CreateResp{
+		Id: {{if .PK.Cast}}{{.PK.PbGoType}}(resp.{{.StructName}}ID){{else}}resp.{{.StructName}}ID{{end}},
+	}, nil
+}
+
+// Delete 删除{{.Description}}
+func (s *{{.StructNameLowerCamel}}Server) Delete(ctx context.Context, req *pb{{.PackageName}}.{{.StructName}}DeleteReq) (*emptypb.Empty, error) {
+	err := withGinContext(ctx, func(ginCtx *gin.Context) error {
+		return s.svc.Delete(ginCtx, &dto{{.PackageName}}.{{.StructName}}DeleteReq{
+			{{.StructName}}ID: {{if .PK.Cast}}{{.PK.FieldType}}(req.GetId()){{else}}req.GetId(){{end}},
+		})
+	})
+	if err != nil {
+		return nil, {{.StructNameLowerCamel}}StatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Update 更新{{.Description}}
+func (s *{{.StructNameLowerCamel}}Server) Update(ctx context.Context, req *pb{{.PackageName}}.{{.StructName}}UpdateReq) (*emptypb.Empty, error) {
+	err := withGinContext(ctx, func(ginCtx *gin.Context) error {
+		return s.svc.Update(ginCtx, &dto{{.PackageName}}.{{.StructName}}UpdateReq{
+			{{.StructName}}ID:       {{if .PK.Cast}}{{.PK.FieldType}}(req.GetId()){{else}}req.GetId(){{end}},
+			{{.StructName}}BaseInfo: {{.StructNameLowerCamel}}BaseInfoFromPb(req.GetBaseInfo()),
+		})
+	})
+	if err != nil {
+		return nil, {{.StructNameLowerCamel}}StatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Detail 根据id获取{{.Description}}
+func (s *{{.StructNameLowerCamel}}Server) Detail(ctx context.Context, req *pb{{.PackageName}}.{{.StructName}}DetailReq) (*pb{{.PackageName}}.{{.StructName}}DetailResp, error) {
+	var resp *dto{{.PackageName}}.{{.StructName}}DetailResp
+	err := withGinContext(ctx, func(ginCtx *gin.Context) (err error) {
+		resp, err = s.svc.Detail(ginCtx, &dto{{.PackageName}}.{{.StructName}}DetailReq{
+			{{.StructName}}ID: {{if .PK.Cast}}{{.PK.FieldType}}(req.GetId()){{else}}req.GetId(){{end}},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, {{.StructNameLowerCamel}}StatusError(err)
+	}
+	return &pb{{.PackageName}}.{{.StructName}}DetailResp{
+		Id:        {{if .PK.Cast}}{{.PK.PbGoType}}(resp.{{.StructName}}ID){{else}}resp.{{.StructName}}ID{{end}},
+		BaseInfo:  {{.StructNameLowerCamel}}BaseInfoToPb(resp.{{.StructName}}BaseInfo),
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}, nil
+}
+
+// PageList 分页获取{{.Description}}列表
+func (s *{{.StructNameLowerCamel}}Server) PageList(ctx context.Context, req *pb{{.PackageName}}.{{.StructName}}PageListReq) (*pb{{.PackageName}}.{{.StructName}}PageListResp, error) {
+	pageListReq := &dto{{.PackageName}}.{{.StructName}}PageListReq{}
+	pageListReq.Page = int(req.GetPage())
+	pageListReq.PageSize = int(req.GetPageSize())
+{{- range .PageListFields}}
+{{- if and .Optional .Cast}}
+	if req.{{.GoName}} != nil {
+		v := {{.FieldType}}(*req.{{.GoName}})
+		pageListReq.{{.FieldName}} = &v
+	}
+{{- else if .Optional}}
+	pageListReq.{{.FieldName}} = req.{{.GoName}}
+{{- else if and .Repeated .Cast}}
+	for _, v := range req.Get{{.GoName}}() {
+		pageListReq.{{.FieldName}} = append(pageListReq.{{.FieldName}}, {{.FieldType}}(v))
+	}
+{{- else if .Cast}}
+	pageListReq.{{.FieldName}} = {{.FieldType}}(req.Get{{.GoName}}())
+{{- else}}
+	pageListReq.{{.FieldName}} = req.Get{{.GoName}}()
+{{- end}}
+{{- end}}
+	var resp *dto{{.PackageName}}.{{.StructName}}PageListResp
+	err := withGinContext(ctx, func(ginCtx *gin.Context) (err error) {
+		resp, err = s.svc.PageList(ginCtx, pageListReq)
+		return err
+	})
+	if err != nil {
+		return nil, {{.StructNameLowerCamel}}StatusError(err)
+	}
+	list := make([]*pb{{.PackageName}}.{{.StructName}}PageListItem, 0, len(resp.List))
+	for _, item := range resp.List {
+		list = append(list, &pb{{.PackageName}}.{{.StructName}}PageListItem{
+			Id:        {{if .PK.Cast}}{{.PK.PbGoType}}(item.{{.StructName}}ID){{else}}item.{{.StructName}}ID{{end}},
+			BaseInfo:  {{.StructNameLowerCamel}}BaseInfoToPb(item.{{.StructName}}BaseInfo),
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return &pb{{.PackageName}}.{{.StructName}}PageListResp{
+		List:  list,
+		Total: resp.Total,
+	}, nil
+}
+
+// {{.StructNameLowerCamel}}StatusError 将 service 返回的业务错误转换为 gRPC status：
+// {{.Description}}不存在为 NotFound{{if .HasUniqueIndex}}，唯一键重复为 AlreadyExists{{end}}，其余为 Internal，错误信息保持不变
+func {{.StructNameLowerCamel}}StatusError(err error) error {
+	var codeErr *gerror.Error
+	if !errors.As(err, &codeErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	switch codeErr.Code {
+	case code.{{.StructName}}NotExistError:
+		return status.Error(codes.NotFound, codeErr.Msg)
+	{{- if .HasUniqueIndex}}
+	case code.{{.StructName}}AlreadyExistError:
+		return status.Error(codes.AlreadyExists, codeErr.Msg)
+	{{- end}}
+	}
+	return status.Error(codes.Internal, codeErr.Msg)
+}
+
+// {{.StructNameLowerCamel}}BaseInfoFromPb 将 pb 消息转换为 obj{{.PackageName}}.{{.StructName}}BaseInfo，info 为 nil 时各字段取零值
+func {{.StructNameLowerCamel}}BaseInfoFromPb(info *pb{{.PackageName}}.{{.StructName}}BaseInfo) obj{{.PackageName}}.{{.StructName}}BaseInfo {
+	baseInfo := obj{{.PackageName}}.{{.StructName}}BaseInfo{
+{{- range .BaseFields}}
+{{- if not .Optional}}
+		{{.FieldName}}: {{if .Cast}}{{.FieldType}}(info.Get{{.GoName}}()){{else}}info.Get{{.GoName}}(){{end}},
+{{- end}}
+{{- end}}
+	}
+{{- range .BaseFields}}
+{{- if .Optional}}
+	if info != nil && info.{{.GoName}} != nil {
+	{{- if .Cast}}
+		v := {{.FieldType}}(*info.{{.GoName}})
+		baseInfo.{{.FieldName}} = &v
+	{{- else}}
+		baseInfo.{{.FieldName}} = info.{{.GoName}}
+	{{- end}}
+	}
+{{- end}}
+{{- end}}
+	return baseInfo
+}
+
+// {{.StructNameLowerCamel}}BaseInfoToPb 将 obj{{.PackageName}}.{{.StructName}}BaseInfo 转换为 pb 消息
+func {{.StructNameLowerCamel}}BaseInfoToPb(info obj{{.PackageName}}.{{.StructName}}BaseInfo) *pb{{.PackageName}}.{{.StructName}}BaseInfo {
+	pbInfo := &pb{{.PackageName}}.{{.StructName}}BaseInfo{
+{{- range .BaseFields}}
+{{- if not (and .Optional .Cast)}}
+		{{.GoName}}: {{if .Cast}}{{.PbGoType}}(info.{{.FieldName}}){{else}}info.{{.FieldName}}{{end}},
+{{- end}}
+{{- end}}
+	}
+{{- range .BaseFields}}
+{{- if and .Optional .Cast}}
+	if info.{{.FieldName}} != nil {
+		v := {{.PbGoType}}(*info.{{.FieldName}})
+		pbInfo.{{.GoName}} = &v
+	}
+{{- end}}
+{{- end}}
+	return pbInfo
+}
@@ -0,0 +1,11 @@
+package {{.DaoPackageName}}
+
+import "strings"
+
+// likeEscaper 转义 LIKE 模式中的通配符，配合 ESCAPE '!' 使用，MySQL、PostgreSQL 与 SQLite 均支持该写法
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// likeContains 返回包含 value 的 LIKE 模式，value 中的 % 与 _ 按字面量匹配
+func likeContains(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
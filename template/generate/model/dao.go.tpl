@@ -1,6 +1,19 @@
 package {{.DaoPackageName}}
 
 import (
+	{{- if or .Relations .UniqueIndexes .CustomDelete .KeyFields .PageList.IsCursor}}
+	"context"
+	{{- end}}
+	{{- if .PageList.IsCursor}}
+	"encoding/base64"
+	"encoding/json"
+	{{- end}}
+	{{- if or .Relations .UniqueIndexes .KeyFields}}
+	"errors"
+	{{- end}}
+	{{- if and .PageList .PageList.SortFields}}
+	"strings"
+	{{- end}}
 	{{- range .FieldImports}}
 	"{{.}}"
 	{{- end}}
@@ -15,10 +28,47 @@ type {{.StructName}}Cond struct {
 	*gormdao.BaseCond
 {{- range .ModelFields}}
 {{- if not (isBuiltInField .FieldName)}}
+	{{.FieldName}} {{if .NullableStyle}}*{{end}}{{.FieldType}}
+{{- end}}
+{{- end}}
+{{- range .Relations}}
+	Preload{{.FieldName}} bool // 预加载 {{.FieldName}}
+{{- end}}
+{{- with .PageList}}
+{{- range .Filters}}
+{{- if and (eq .Op "eq") (isBuiltInField .FieldName)}}
 	{{.FieldName}} {{.FieldType}}
+{{- else if eq .Op "in"}}
+	{{.FieldName}}List []{{.FieldType}} // {{.ColumnName}} 多值匹配
+{{- else if eq .Op "like"}}
+	{{.FieldName}}Like string // {{.ColumnName}} 模糊匹配
+{{- else if eq .Op "range"}}
+	{{.FieldName}}Start {{if ne .FieldType "time.Time"}}*{{end}}{{.FieldType}} // {{.ColumnName}} 起始值（含）{{if ne .FieldType "time.Time"}}，nil 表示不限{{end}}
+	{{.FieldName}}End {{if ne .FieldType "time.Time"}}*{{end}}{{.FieldType}} // {{.ColumnName}} 结束值（含）{{if ne .FieldType "time.Time"}}，nil 表示不限{{end}}
 {{- end}}
 {{- end}}
+{{- if .HasKeyword}}
+	Keyword string // 关键字，模糊匹配 {{range $i, $column := .KeywordColumns}}{{if $i}}、{{end}}{{$column}}{{end}}
+{{- end}}
+{{- if .SortFields}}
+	OrderBy string // 排序字段，取值为 {{$.StructName}}SortColumns 的 key，- 前缀表示降序
+{{- end}}
+{{- if .Cursor}}
+	Cursor *{{$.StructName}}Cursor // 游标分页的起始位置，为 nil 时从第一条开始
+{{- end}}
+{{- end}}
+}
+{{- with .PageList}}
+{{- if .SortFields}}
+
+// {{$.StructName}}SortColumns 允许排序的字段，key 为请求中的排序字段名，value 为列名
+var {{$.StructName}}SortColumns = map[string]string{
+{{- range .SortFields}}
+	"{{.Name}}": "{{.ColumnName}}",
+{{- end}}
 }
+{{- end}}
+{{- end}}
 
 func (c *{{.StructName}}Cond) BuildCondition(db *gorm.DB, tableName string) {
 	if c.BaseCond != nil {
@@ -27,7 +77,11 @@ func (c *{{.StructName}}Cond) BuildCondition(db *gorm.DB, tableName string) {
 {{- range .ModelFields}}
 {{- if not (isBuiltInField .FieldName)}}
 {{- if isBasicType .FieldType}}
-	{{- if eq .FieldType "string"}}
+	{{- if .NullableStyle}}
+	if c.{{.FieldName}} != nil {
+		db.Where(tableName+".{{.ColumnName}} = ?", *c.{{.FieldName}})
+	}
+	{{- else if eq .FieldType "string"}}
 	if c.{{.FieldName}} != "" {
 		db.Where(tableName+".{{.ColumnName}} = ?", c.{{.FieldName}})
 	}
@@ -44,9 +98,87 @@ func (c *{{.StructName}}Cond) BuildCondition(db *gorm.DB, tableName string) {
 {{- end}}
 {{- end}}
 {{- end}}
+{{- range .Relations}}
+	if c.Preload{{.FieldName}} {
+		db.Preload("{{.FieldName}}")
+	}
+{{- end}}
+{{- with .PageList}}
+{{- range .Filters}}
+{{- if and (eq .Op "eq") (isBuiltInField .FieldName)}}
+	{{- if eq .FieldType "time.Time"}}
+	if !c.{{.FieldName}}.IsZero() {
+	{{- else}}
+	if c.{{.FieldName}} != 0 {
+	{{- end}}
+		db.Where(tableName+".{{.ColumnName}} = ?", c.{{.FieldName}})
+	}
+{{- else if eq .Op "in"}}
+	if len(c.{{.FieldName}}List) > 0 {
+		db.Where(tableName+".{{.ColumnName}} IN ?", c.{{.FieldName}}List)
+	}
+{{- else if eq .Op "like"}}
+	if c.{{.FieldName}}Like != "" {
+		db.Where(tableName+".{{.ColumnName}} LIKE ? ESCAPE '!'", likeContains(c.{{.FieldName}}Like))
+	}
+{{- else if eq .Op "range"}}
+	{{- if eq .FieldType "time.Time"}}
+	if !c.{{.FieldName}}Start.IsZero() {
+		db.Where(tableName+".{{.ColumnName}} >= ?", c.{{.FieldName}}Start)
+	}
+	if !c.{{.FieldName}}End.IsZero() {
+		db.Where(tableName+".{{.ColumnName}} <= ?", c.{{.FieldName}}End)
+	}
+	{{- else}}
+	if c.{{.FieldName}}Start != nil {
+		db.Where(tableName+".{{.ColumnName}} >= ?", *c.{{.FieldName}}Start)
+	}
+	if c.{{.FieldName}}End != nil {
+		db.Where(tableName+".{{.ColumnName}} <= ?", *c.{{.FieldName}}End)
+	}
+	{{- end}}
+{{- end}}
+{{- end}}
+{{- if .HasKeyword}}
+	if c.Keyword != "" {
+		keyword := likeContains(c.Keyword)
+		db.Where("({{range $i, $column := .KeywordColumns}}{{if $i}} OR {{end}}"+tableName+".{{$column}} LIKE ? ESCAPE '!'{{end}})"{{range .KeywordColumns}}, keyword{{end}})
+	}
+{{- end}}
+{{- if .SortFields}}
+	if column, ok := {{$.StructName}}SortColumns[strings.TrimPrefix(c.OrderBy, "-")]; ok {
+		if strings.HasPrefix(c.OrderBy, "-") {
+			db.Order(tableName + "." + column + " DESC")
+		} else {
+			db.Order(tableName + "." + column)
+		}
+	}
+	{{- if .DefaultSort}} else {
+		db.Order(tableName + ".{{.DefaultSort}}")
+	}
+	{{- end}}
+{{- else if .DefaultSort}}
+	db.Order(tableName + ".{{.DefaultSort}}")
+{{- end}}
+{{- with .Cursor}}
+	{{- if .SortByPK}}
+	if c.Cursor != nil {
+		db.Where(tableName+".{{.PKField.ColumnName}} < ?", c.Cursor.{{.PKField.FieldName}})
+	}
+	db.Order(tableName + ".{{.PKField.ColumnName}} DESC")
+	{{- else}}
+	if c.Cursor != nil {
+		db.Where("("+tableName+".{{.SortField.ColumnName}} < ? OR ("+tableName+".{{.SortField.ColumnName}} = ? AND "+tableName+".{{.PKField.ColumnName}} < ?))",
+			c.Cursor.{{.SortField.FieldName}}, c.Cursor.{{.SortField.FieldName}}, c.Cursor.{{.PKField.FieldName}})
+	}
+	db.Order(tableName + ".{{.SortField.ColumnName}} DESC").Order(tableName + ".{{.PKField.ColumnName}} DESC")
+	{{- end}}
+{{- end}}
+{{- end}}
 }
 
-type {{.StructName}}Dao struct {
+{{if .KeyFields}}// {{.StructName}}Dao 复合主键表的 gormdao 主键类型参数取首个主键列的类型，按主键定位记录时使用 GetByKey、UpdateMapByKey、DeleteByKey
+{{end}}type {{.StructName}}Dao struct {
 	*gormdao.Dao[{{.ModelLayerName}}.{{.StructName}}Entity, {{.ModelLayerName}}.{{.StructName}}EntityList, {{.PKFieldType}}]
 }
 
@@ -57,4 +189,163 @@ func New{{.StructName}}Dao() *{{.StructName}}Dao {
 			dbclient.{{.DBName}},
 		),
 	}
-}
\ No newline at end of file
+}
+{{- with .PageList}}
+{{- with .Cursor}}
+
+// {{$.StructName}}Cursor 游标分页的位置，即上一页最后一条记录的{{if not .SortByPK}} {{.SortField.ColumnName}} 与{{end}} {{.PKField.ColumnName}}
+type {{$.StructName}}Cursor struct {
+	{{- if not .SortByPK}}
+	{{.SortField.FieldName}} {{.SortField.FieldType}} `json:"{{.SortField.JsonTagName}}"`
+	{{- end}}
+	{{.PKField.FieldName}} {{.PKField.FieldType}} `json:"{{.PKField.JsonTagName}}"`
+}
+
+// Encode 将游标编码为不透明的字符串，作为分页响应中的 nextCursor
+func (c {{$.StructName}}Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Parse{{$.StructName}}Cursor 解析由 Encode 生成的游标字符串
+func Parse{{$.StructName}}Cursor(token string) (*{{$.StructName}}Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var cursor {{$.StructName}}Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// GetCursorListByCond 游标分页查询，按{{if not .SortByPK}} {{.SortField.ColumnName}}、{{end}} {{.PKField.ColumnName}} 倒序返回游标之后的至多 limit 条记录
+func (d *{{$.StructName}}Dao) GetCursorListByCond(ctx context.Context, cond *{{$.StructName}}Cond, limit int) ({{$.ModelLayerName}}.{{$.StructName}}EntityList, error) {
+	db := dbclient.{{$.DBName}}(ctx).Table({{$.ModelLayerName}}.TableName{{$.StructName}})
+	cond.BuildCondition(db, {{$.ModelLayerName}}.TableName{{$.StructName}})
+	var list {{$.ModelLayerName}}.{{$.StructName}}EntityList
+	if err := db.Limit(limit).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+{{- end}}
+{{- end}}
+{{- if .KeyFields}}
+{{- $keyWhere := ""}}
+{{- range $i, $field := .KeyFields}}
+{{- if $i}}{{$keyWhere = printf "%s AND " $keyWhere}}{{end}}
+{{- $keyWhere = printf "%s%s = ?" $keyWhere $field.ColumnName}}
+{{- end}}
+
+// GetByKey 根据复合主键查询，记录不存在时返回 nil
+func (d *{{.StructName}}Dao) GetByKey(ctx context.Context, key {{.ModelLayerName}}.{{.StructName}}Key) (*{{.ModelLayerName}}.{{.StructName}}Entity, error) {
+	var entity {{.ModelLayerName}}.{{.StructName}}Entity
+	err := dbclient.{{.DBName}}(ctx).Where("{{$keyWhere}}"{{range .KeyFields}}, key.{{.FieldName}}{{end}}).Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// UpdateMapByKey 根据复合主键更新指定列
+func (d *{{.StructName}}Dao) UpdateMapByKey(ctx context.Context, key {{.ModelLayerName}}.{{.StructName}}Key, updateMap map[string]any) error {
+	return dbclient.{{.DBName}}(ctx).Model(&{{.ModelLayerName}}.{{.StructName}}Entity{}).Where("{{$keyWhere}}"{{range .KeyFields}}, key.{{.FieldName}}{{end}}).Updates(updateMap).Error
+}
+{{- with .DeletedByField}}
+
+// DeleteByKey 根据复合主键删除并记录删除人，软删除或物理删除由 gorm 按 Entity 的软删除字段决定
+func (d *{{$.StructName}}Dao) DeleteByKey(ctx context.Context, key {{$.ModelLayerName}}.{{$.StructName}}Key, deletedBy {{.FieldType}}) error {
+	return dbclient.{{$.DBName}}(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&{{$.ModelLayerName}}.{{$.StructName}}Entity{}).Where("{{$keyWhere}}"{{range $.KeyFields}}, key.{{.FieldName}}{{end}}).Update("{{.ColumnName}}", deletedBy).Error; err != nil {
+			return err
+		}
+		return tx.Where("{{$keyWhere}}"{{range $.KeyFields}}, key.{{.FieldName}}{{end}}).Delete(&{{$.ModelLayerName}}.{{$.StructName}}Entity{}).Error
+	})
+}
+{{- else}}
+
+// DeleteByKey 根据复合主键删除，软删除或物理删除由 gorm 按 Entity 的软删除字段决定
+func (d *{{.StructName}}Dao) DeleteByKey(ctx context.Context, key {{.ModelLayerName}}.{{.StructName}}Key) error {
+	return dbclient.{{.DBName}}(ctx).Where("{{$keyWhere}}"{{range .KeyFields}}, key.{{.FieldName}}{{end}}).Delete(&{{.ModelLayerName}}.{{.StructName}}Entity{}).Error
+}
+{{- end}}
+{{- range .Relations}}
+
+// GetByKeyWith{{.FieldName}} 根据复合主键查询并预加载 {{.FieldName}}，记录不存在时返回 nil
+func (d *{{$.StructName}}Dao) GetByKeyWith{{.FieldName}}(ctx context.Context, key {{$.ModelLayerName}}.{{$.StructName}}Key) (*{{$.ModelLayerName}}.{{$.StructName}}Entity, error) {
+	var entity {{$.ModelLayerName}}.{{$.StructName}}Entity
+	err := dbclient.{{$.DBName}}(ctx).Preload("{{.FieldName}}").Where("{{$keyWhere}}"{{range $.KeyFields}}, key.{{.FieldName}}{{end}}).Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+{{- end}}
+{{- else if .CustomDelete}}
+{{- $pkColumnName := "id"}}
+{{- range .ModelFields}}
+{{- if .IsPrimaryKey}}{{$pkColumnName = .ColumnName}}{{end}}
+{{- end}}
+{{- with .DeletedByField}}
+
+// Delete 按主键删除并记录删除人，软删除或物理删除由 gorm 按 Entity 的软删除字段决定
+func (d *{{$.StructName}}Dao) Delete(ctx context.Context, id {{$.PKFieldType}}, deletedBy {{.FieldType}}) error {
+	return dbclient.{{$.DBName}}(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&{{$.ModelLayerName}}.{{$.StructName}}Entity{}).Where("{{$pkColumnName}} = ?", id).Update("{{.ColumnName}}", deletedBy).Error; err != nil {
+			return err
+		}
+		return tx.Where("{{$pkColumnName}} = ?", id).Delete(&{{$.ModelLayerName}}.{{$.StructName}}Entity{}).Error
+	})
+}
+{{- else}}
+
+// Delete 按主键删除，软删除或物理删除由 gorm 按 Entity 的软删除字段决定
+func (d *{{.StructName}}Dao) Delete(ctx context.Context, id {{.PKFieldType}}) error {
+	return dbclient.{{.DBName}}(ctx).Where("{{$pkColumnName}} = ?", id).Delete(&{{.ModelLayerName}}.{{.StructName}}Entity{}).Error
+}
+{{- end}}
+{{- end}}
+{{- if and .Relations (not .KeyFields)}}
+{{- $pkColumnName := "id"}}
+{{- range .ModelFields}}
+{{- if .IsPrimaryKey}}{{$pkColumnName = .ColumnName}}{{end}}
+{{- end}}
+{{- range .Relations}}
+
+// GetByIDWith{{.FieldName}} 根据主键查询并预加载 {{.FieldName}}，记录不存在时返回 nil
+func (d *{{$.StructName}}Dao) GetByIDWith{{.FieldName}}(ctx context.Context, id {{$.PKFieldType}}) (*{{$.ModelLayerName}}.{{$.StructName}}Entity, error) {
+	var entity {{$.ModelLayerName}}.{{$.StructName}}Entity
+	err := dbclient.{{$.DBName}}(ctx).Preload("{{.FieldName}}").Where("{{$pkColumnName}} = ?", id).Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+{{- end}}
+{{- end}}
+{{- range .UniqueIndexes}}
+
+// GetBy{{.FuncSuffix}} 根据唯一索引 {{.IndexName}} 查询，记录不存在时返回 nil
+func (d *{{$.StructName}}Dao) GetBy{{.FuncSuffix}}(ctx context.Context{{range .Fields}}, {{.ParamName}} {{if .NullableStyle}}*{{end}}{{if .EnumTypeName}}{{$.ModelLayerName}}.{{.EnumTypeName}}{{else}}{{.FieldType}}{{end}}{{end}}) (*{{$.ModelLayerName}}.{{$.StructName}}Entity, error) {
+	var entity {{$.ModelLayerName}}.{{$.StructName}}Entity
+	err := dbclient.{{$.DBName}}(ctx){{range .Fields}}.Where("{{.ColumnName}} = ?", {{.ParamName}}){{end}}.Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+{{- end}}
@@ -1,19 +1,20 @@
 package {{.ModelLayerName}}
 
 import (
+	{{- if hasEnumField .ModelFields}}
+	"fmt"
+	{{- end}}
 	{{- range .FieldImports}}
 	"{{.}}"
 	{{- end}}
-	{{- if isNumID .PKFieldType}}
+	{{- if .EmbedGormModel}}
 	"gorm.io/gorm"
-	{{- else if hasTimeFieldAny .ModelFields}}
-	"time"
 	{{- end}}
 )
 
 // {{.StructName}}Entity {{.Description}}表结构体
 type {{.StructName}}Entity struct {
-{{- if isNumID .PKFieldType}}
+{{- if .EmbedGormModel}}
     gorm.Model
 {{- range .ModelFields}}
     {{- if isBuiltInField .FieldName}}
@@ -23,13 +24,14 @@ type {{.StructName}}Entity struct {
 	{{- $tagStr := ""}}
 	{{- $tagStr = printf "%scolumn:%s" $tagStr $field.ColumnName}}
 	{{- $tagStr = printf "%s;type:%s" $tagStr $field.ColumnType}}
+	{{- if $field.Serializer}}{{$tagStr = printf "%s;serializer:%s" $tagStr $field.Serializer}}{{end}}
 	{{- if $field.NullableDesc}}{{$tagStr = printf "%s;%s" $tagStr $field.NullableDesc}}{{end}}
 	{{- if $field.DefaultValue}}{{$tagStr = printf "%s;%s" $tagStr $field.DefaultValue}}{{end}}
 	{{- if $field.IndexName}}{{$tagStr = printf "%s;index:%s" $tagStr $field.IndexName}}{{end}}
 	{{- if and $field.IndexName $field.IsUniqueIndex}}{{$tagStr = printf "%s;uniqueIndex" $tagStr}}{{end}}
 	{{- if $field.Comment}}{{$tagStr = printf "%s;comment:%s" $tagStr $field.Comment}}{{end}}
 	{{- if $field.IsPrimaryKey}}{{$tagStr = printf "%s;primaryKey" $tagStr}}{{end}}
-	{{.FieldName}} {{.FieldType}} `gorm:"{{$tagStr}}"`
+	{{.FieldName}} {{if .NullableStyle}}{{.ModelFieldType}}{{else if .EnumTypeName}}{{.EnumTypeName}}{{else}}{{.FieldType}}{{end}} `gorm:"{{$tagStr}}"`
 	{{- end}}
 {{- end}}
 {{- else}}
@@ -38,14 +40,23 @@ type {{.StructName}}Entity struct {
 	{{- $tagStr := ""}}
 	{{- $tagStr = printf "%scolumn:%s" $tagStr $field.ColumnName}}
 	{{- $tagStr = printf "%s;type:%s" $tagStr $field.ColumnType}}
+	{{- if $field.Serializer}}{{$tagStr = printf "%s;serializer:%s" $tagStr $field.Serializer}}{{end}}
 	{{- if $field.NullableDesc}}{{$tagStr = printf "%s;%s" $tagStr $field.NullableDesc}}{{end}}
 	{{- if $field.DefaultValue}}{{$tagStr = printf "%s;%s" $tagStr $field.DefaultValue}}{{end}}
 	{{- if $field.IndexName}}{{$tagStr = printf "%s;index:%s" $tagStr $field.IndexName}}{{end}}
 	{{- if and $field.IndexName $field.IsUniqueIndex}}{{$tagStr = printf "%s;uniqueIndex" $tagStr}}{{end}}
 	{{- if $field.Comment}}{{$tagStr = printf "%s;comment:%s" $tagStr $field.Comment}}{{end}}
 	{{- if $field.IsPrimaryKey}}{{$tagStr = printf "%s;primaryKey" $tagStr}}{{end}}
-	{{.FieldName}} {{.FieldType}} `gorm:"{{$tagStr}}"`
+	{{- if $field.GormTagExtra}}{{$tagStr = printf "%s;%s" $tagStr $field.GormTagExtra}}{{end}}
+	{{.FieldName}} {{if or .NullableStyle .SoftDelete}}{{.ModelFieldType}}{{else if .EnumTypeName}}{{.EnumTypeName}}{{else}}{{.FieldType}}{{end}} `gorm:"{{$tagStr}}"`
+{{- end}}
 {{- end}}
+{{- range .Relations}}
+	{{- if eq .Kind "belongsTo"}}
+	{{.FieldName}} *{{.StructName}}Entity `gorm:"foreignKey:{{.ForeignKey}};references:{{.References}}"` // 关联 {{.TableName}} 表（belongsTo）
+	{{- else}}
+	{{.FieldName}} []{{.StructName}}Entity `gorm:"foreignKey:{{.ForeignKey}};references:{{.References}}"` // 关联 {{.TableName}} 表（hasMany）
+	{{- end}}
 {{- end}}
 }
 
@@ -54,6 +65,32 @@ type {{.StructName}}EntityList []{{.StructName}}Entity
 func ({{.StructName}}Entity ) TableName() string {
   return TableName{{.StructName}}
 }
+{{- if .KeyFields}}
+
+// {{.StructName}}Key {{.Description}}表的复合主键
+type {{.StructName}}Key struct {
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}}{{with .Comment}} // {{.}}{{end}}
+{{- end}}
+}
+
+// Key 记录的复合主键
+func (e {{.StructName}}Entity) Key() {{.StructName}}Key {
+	return {{.StructName}}Key{
+{{- range .KeyFields}}
+		{{.FieldName}}: e.{{.FieldName}},
+{{- end}}
+	}
+}
+
+func (l {{.StructName}}EntityList) ToMap() map[{{.StructName}}Key]{{.StructName}}Entity {
+	m := make(map[{{.StructName}}Key]{{.StructName}}Entity)
+	for _, v := range l {
+		m[v.Key()] = v
+	}
+	return m
+}
+{{- else}}
 
 func (l {{.StructName}}EntityList) ToMap() map[{{.PKFieldType}}]{{.StructName}}Entity {
 	m := make(map[{{.PKFieldType}}]{{.StructName}}Entity)
@@ -62,3 +99,36 @@ func (l {{.StructName}}EntityList) ToMap() map[{{.PKFieldType}}]{{.StructName}}E
 	}
 	return m
 }
+{{- end}}
+{{- range .ModelFields}}
+{{- if .EnumTypeName}}
+{{- $enum := .}}
+
+// {{.EnumTypeName}}{{with .EnumDesc}} {{.}}{{end}}
+type {{.EnumTypeName}} {{.FieldType}}
+
+const (
+{{- range .EnumItems}}
+	{{.ConstName}} {{$enum.EnumTypeName}} = {{.Value}} // {{.Label}}
+{{- end}}
+)
+
+// {{.EnumTypeName}}Map 取值与说明的映射，用于 DTO 文档与展示
+var {{.EnumTypeName}}Map = map[{{.EnumTypeName}}]string{
+{{- range .EnumItems}}
+	{{.ConstName}}: {{printf "%q" .Label}},
+{{- end}}
+}
+
+func (e {{.EnumTypeName}}) String() string {
+	if label, ok := {{.EnumTypeName}}Map[e]; ok {
+		return label
+	}
+	{{- if eq .FieldType "string"}}
+	return string(e)
+	{{- else}}
+	return fmt.Sprint({{.FieldType}}(e))
+	{{- end}}
+}
+{{- end}}
+{{- end}}
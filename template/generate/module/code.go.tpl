@@ -3,12 +3,15 @@ package code
 import "github.com/morehao/golib/gerror"
 
 const (
-    {{.StructName}}CreateError      = 100100
-    {{.StructName}}DeleteError      = 100101
-    {{.StructName}}UpdateError      = 100102
-    {{.StructName}}GetDetailError   = 100103
-    {{.StructName}}GetPageListError = 100104
-    {{.StructName}}NotExistError    = 100105
+    {{.StructName}}CreateError      = {{.ErrorCodeBase}} + iota
+    {{.StructName}}DeleteError
+    {{.StructName}}UpdateError
+    {{.StructName}}GetDetailError
+    {{.StructName}}GetPageListError
+    {{.StructName}}NotExistError
+    {{- if .UniqueIndexes}}
+    {{.StructName}}AlreadyExistError
+    {{- end}}
 )
 
 var {{.StructNameLowerCamel}}ErrorMsgMap = gerror.CodeMsgMap{
@@ -18,4 +21,7 @@ var {{.StructNameLowerCamel}}ErrorMsgMap = gerror.CodeMsgMap{
     {{.StructName}}GetDetailError:   "查看{{.Description}}失败",
     {{.StructName}}GetPageListError: "查看{{.Description}}列表失败",
     {{.StructName}}NotExistError:    "{{.Description}}不存在",
+    {{- if .UniqueIndexes}}
+    {{.StructName}}AlreadyExistError: "{{.Description}}已存在",
+    {{- end}}
 }
\ No newline at end of file
@@ -21,6 +21,15 @@ type {{.StructNameLowerCamel}}Ctr struct {
 
 var _ {{.StructName}}Ctr = (*{{.StructNameLowerCamel}}Ctr)(nil)
 
+{{- $itemPath := printf "{%sID}" .StructNameLowerCamel}}
+{{- if .KeyFields}}
+{{- $itemPath = ""}}
+{{- range $i, $field := .KeyFields}}
+{{- if $i}}{{$itemPath = printf "%s/" $itemPath}}{{end}}
+{{- $itemPath = printf "%s{%s}" $itemPath $field.JsonTagName}}
+{{- end}}
+{{- end}}
+
 func New{{.StructName}}Ctr() {{.StructName}}Ctr {
 	return &{{.StructNameLowerCamel}}Ctr{
 		{{.StructNameLowerCamel}}Svc: svc{{.PackageName}}.New{{.StructName}}Svc(),
@@ -54,9 +63,15 @@ func (ctr *{{.StructNameLowerCamel}}Ctr) Create(ctx *gin.Context) {
 // @Summary 删除{{.Description}}
 // @accept application/json
 // @Produce application/json
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+// @Param {{.JsonTagName}} path {{if eq .FieldType "string"}}string{{else}}int{{end}} true "{{.JsonTagName}}"
+{{- end}}
+{{- else}}
 // @Param {{.StructNameLowerCamel}}ID path {{if isStringID .PKFieldType}}string{{else}}int{{end}} true "{{.StructNameLowerCamel}}ID"
+{{- end}}
 // @Success 200 {object} gincontext.DtoRender{data=string} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "success"}"
-// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{`{`}}{{.StructNameLowerCamel}}ID{{`}`}} [delete]
+// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}} [delete]
 func (ctr *{{.StructNameLowerCamel}}Ctr) Delete(ctx *gin.Context) {
 	var req dto{{.PackageName}}.{{.StructName}}DeleteReq
 	if err := gincontext.BindPathParams(ctx, &req); err != nil {
@@ -76,10 +91,16 @@ func (ctr *{{.StructNameLowerCamel}}Ctr) Delete(ctx *gin.Context) {
 // @Summary 修改{{.Description}}
 // @accept application/json
 // @Produce application/json
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+// @Param {{.JsonTagName}} path {{if eq .FieldType "string"}}string{{else}}int{{end}} true "{{.JsonTagName}}"
+{{- end}}
+{{- else}}
 // @Param {{.StructNameLowerCamel}}ID path {{if isStringID .PKFieldType}}string{{else}}int{{end}} true "{{.StructNameLowerCamel}}ID"
+{{- end}}
 // @Param req body dto{{.PackageName}}.{{.StructName}}UpdateReq true "修改{{.Description}}"
 // @Success 200 {object} gincontext.DtoRender{data=string} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "修改成功"}"
-// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{`{`}}{{.StructNameLowerCamel}}ID{{`}`}} [put]
+// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}} [put]
 func (ctr *{{.StructNameLowerCamel}}Ctr) Update(ctx *gin.Context) {
 	var req dto{{.PackageName}}.{{.StructName}}UpdateReq
 	if err := gincontext.BindPathParams(ctx, &req); err != nil {
@@ -102,9 +123,15 @@ func (ctr *{{.StructNameLowerCamel}}Ctr) Update(ctx *gin.Context) {
 // @Summary {{.Description}}详情
 // @accept application/json
 // @Produce application/json
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+// @Param {{.JsonTagName}} path {{if eq .FieldType "string"}}string{{else}}int{{end}} true "{{.JsonTagName}}"
+{{- end}}
+{{- else}}
 // @Param {{.StructNameLowerCamel}}ID path {{if isStringID .PKFieldType}}string{{else}}int{{end}} true "{{.StructNameLowerCamel}}ID"
+{{- end}}
 // @Success 200 {object} gincontext.DtoRender{data=dto{{.PackageName}}.{{.StructName}}DetailResp} "{"code": 0, "requestID": "xxx", "data": "ok", "msg": "success"}"
-// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{`{`}}{{.StructNameLowerCamel}}ID{{`}`}} [get]
+// @Router /v1/{{.AppName}}/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}} [get]
 func (ctr *{{.StructNameLowerCamel}}Ctr) Detail(ctx *gin.Context) {
 	var req dto{{.PackageName}}.{{.StructName}}DetailReq
 	if err := gincontext.BindPathParams(ctx, &req); err != nil {
@@ -0,0 +1,123 @@
+package ctr{{.PackageName}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	{{- range sampleImports .ModelFields}}
+	"{{.}}"
+	{{- end}}
+	"testing"
+
+	"{{.BaseModulePath}}/{{.AppModuleName}}/internal/dto/dto{{.PackageName}}"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/object/obj{{.PackageName}}"
+	"{{.BaseModulePath}}/pkg/testsetup"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// {{.StructNameLowerCamel}}TestPath {{.Description}}路由路径，与 router 中注册的路径一致
+const {{.StructNameLowerCamel}}TestPath = "/{{toKebabCase (pluralize .StructNameLowerCamel)}}"
+
+// {{.StructNameLowerCamel}}TestResponse 接口统一响应结构
+type {{.StructNameLowerCamel}}TestResponse struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// new{{.StructName}}TestRouter 按 router 中的定义注册{{.Description}}的五个路由
+func new{{.StructName}}TestRouter() *gin.Engine {
+	router := gin.New()
+	{{.StructNameLowerCamel}}Ctr := New{{.StructName}}Ctr()
+	{{- $itemPath := printf ":%sID" .StructNameLowerCamel}}
+	{{- if .KeyFields}}
+	{{- $itemPath = ""}}
+	{{- range $i, $field := .KeyFields}}
+	{{- if $i}}{{$itemPath = printf "%s/" $itemPath}}{{end}}
+	{{- $itemPath = printf "%s:%s" $itemPath $field.JsonTagName}}
+	{{- end}}
+	{{- end}}
+	router.POST({{.StructNameLowerCamel}}TestPath, {{.StructNameLowerCamel}}Ctr.Create)
+	router.GET({{.StructNameLowerCamel}}TestPath, {{.StructNameLowerCamel}}Ctr.PageList)
+	router.GET({{.StructNameLowerCamel}}TestPath+"/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Detail)
+	router.PUT({{.StructNameLowerCamel}}TestPath+"/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Update)
+	router.DELETE({{.StructNameLowerCamel}}TestPath+"/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Delete)
+	return router
+}
+
+// do{{.StructName}}Request 发起请求并校验 HTTP 状态码与业务码
+func do{{.StructName}}Request(t *testing.T, router *gin.Engine, method, path string, body any) {{.StructNameLowerCamel}}TestResponse {
+	t.Helper()
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.Nil(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, "%s %s", method, path)
+
+	var resp {{.StructNameLowerCamel}}TestResponse
+	require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, 0, resp.Code, "%s %s: %s", method, path, resp.Msg)
+	return resp
+}
+
+// Test{{.StructName}}Routes 通过 HTTP 依次调用{{.Description}}的创建、详情、更新、分页列表与删除接口
+func Test{{.StructName}}Routes(t *testing.T) {
+	testsetup.Initialize(testsetup.AppName{{firstLetterToUpper .AppName}})
+	defer testsetup.Close(testsetup.AppName{{firstLetterToUpper .AppName}})
+
+	router := new{{.StructName}}TestRouter()
+	{{- if sampleImports .ModelFields}}
+	// 每次运行使用不同的示例值，上次运行失败残留的记录不会触发唯一索引冲突
+	sampleRunID := time.Now().UnixNano()
+	{{- end}}
+	baseInfo := obj{{.PackageName}}.{{.StructName}}BaseInfo{
+{{- range .ModelFields}}
+	{{- if or (isSysField .FieldName) .IsKeyField}}
+		{{- continue}}
+	{{- end}}
+	{{- $value := sampleValue .}}
+	{{- if $value}}
+		{{.FieldName}}: {{$value}},
+	{{- end}}
+{{- end}}
+	}
+
+	createResp := do{{.StructName}}Request(t, router, http.MethodPost, {{.StructNameLowerCamel}}TestPath, dto{{.PackageName}}.{{.StructName}}CreateReq{
+{{- range .KeyFields}}
+		{{.FieldName}}: {{sampleValue .}},
+{{- end}}
+		{{.StructName}}BaseInfo: baseInfo,
+	})
+	var createData dto{{.PackageName}}.{{.StructName}}CreateResp
+	require.Nil(t, json.Unmarshal(createResp.Data, &createData))
+{{- if .KeyFields}}
+	itemPath := fmt.Sprintf("%s{{range .KeyFields}}/%v{{end}}", {{.StructNameLowerCamel}}TestPath{{range .KeyFields}}, createData.{{.FieldName}}{{end}})
+{{- else}}
+	itemPath := fmt.Sprintf("%s/%v", {{.StructNameLowerCamel}}TestPath, createData.{{.StructName}}ID)
+{{- end}}
+
+	detailResp := do{{.StructName}}Request(t, router, http.MethodGet, itemPath, nil)
+	var detailData dto{{.PackageName}}.{{.StructName}}DetailResp
+	require.Nil(t, json.Unmarshal(detailResp.Data, &detailData))
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+	assert.Equal(t, createData.{{.FieldName}}, detailData.{{.FieldName}})
+	{{- end}}
+{{- else}}
+	assert.Equal(t, createData.{{.StructName}}ID, detailData.{{.StructName}}ID)
+{{- end}}
+
+	do{{.StructName}}Request(t, router, http.MethodPut, itemPath, dto{{.PackageName}}.{{.StructName}}UpdateReq{
+		{{.StructName}}BaseInfo: baseInfo,
+	})
+	do{{.StructName}}Request(t, router, http.MethodGet, {{.StructNameLowerCamel}}TestPath+"?{{if not .PageList.IsCursor}}page=1&{{end}}pageSize=10", nil)
+	do{{.StructName}}Request(t, router, http.MethodDelete, itemPath, nil)
+}
@@ -10,14 +10,18 @@ import (
 
 type {{.StructName}}BaseInfo struct {
 {{- range .ModelFields}}
-{{- if isSysField .FieldName}}
+{{- if or (isSysField .FieldName) .IsKeyField}}
     {{- continue}}
 {{- end}}
 
-{{- if eq .FieldType "time.Time"}}
-    {{.FieldName}} int64 `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"` // {{.Comment}}
+{{- if and .NullableStyle (eq .FieldType "time.Time")}}
+    {{.FieldName}} *int64 `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}` // {{.Comment}}
+{{- else if .NullableStyle}}
+    {{.FieldName}} *{{.FieldType}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}{{if .EnumTypeName}} enums:"{{.EnumList}}"{{end}}` // {{.Comment}}
+{{- else if eq .FieldType "time.Time"}}
+    {{.FieldName}} int64 `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}` // {{.Comment}}
 {{- else}}
-    {{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"` // {{.Comment}}
+    {{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"{{if .Binding}} binding:"{{.Binding}}"{{end}}{{if .EnumTypeName}} enums:"{{.EnumList}}"{{end}}` // {{.Comment}}
 {{- end}}
 {{- end}}
 }
@@ -6,22 +6,68 @@ import (
 )
 
 type {{.StructName}}CreateReq struct {
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}" binding:"required"` // {{.Comment}}
+{{- end}}
 	obj{{.PackageName}}.{{.StructName}}BaseInfo
 }
 
 type {{.StructName}}UpdateReq struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"-" uri:"{{.JsonTagName}}" binding:"required"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"-" uri:"{{.StructNameLowerCamel}}ID" binding:"required"` // 主键 ID
+{{- end}}
 	obj{{.PackageName}}.{{.StructName}}BaseInfo
 }
 
 type {{.StructName}}DetailReq struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"-" uri:"{{.JsonTagName}}" binding:"required"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"-" uri:"{{.StructNameLowerCamel}}ID" binding:"required"` // 主键 ID
+{{- end}}
 }
 
 type {{.StructName}}PageListReq struct {
+{{- if .PageList.IsCursor}}
+	Cursor   string `json:"cursor" form:"cursor"`                                  // 游标，取上一页响应中的 nextCursor，为空时查询第一页
+	PageSize int    `json:"pageSize" form:"pageSize" binding:"required,min=1,max=100"` // 每页条数，最多 100 条
+{{- else}}
 	gobject.PageQuery
+{{- end}}
+{{- with .PageList}}
+{{- range .Filters}}
+{{- if eq .Op "in"}}
+	{{.FieldName}}List []{{.FieldType}} `json:"{{.JsonTagName}}List" form:"{{.JsonTagName}}List"` // {{.Comment}}（多值匹配）
+{{- else if eq .Op "range"}}
+	{{.FieldName}}Start {{if eq .FieldType "time.Time"}}int64{{else}}*{{.FieldType}}{{end}} `json:"{{.JsonTagName}}Start" form:"{{.JsonTagName}}Start"` // {{.Comment}}起始值（含）{{if eq .FieldType "time.Time"}}，Unix 时间戳{{end}}
+	{{.FieldName}}End {{if eq .FieldType "time.Time"}}int64{{else}}*{{.FieldType}}{{end}} `json:"{{.JsonTagName}}End" form:"{{.JsonTagName}}End"` // {{.Comment}}结束值（含）{{if eq .FieldType "time.Time"}}，Unix 时间戳{{end}}
+{{- else if eq .Op "like"}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"` // {{.Comment}}（模糊匹配）
+{{- else}}
+	{{.FieldName}} {{if eq .FieldType "time.Time"}}int64{{else}}{{if .NullableStyle}}*{{end}}{{.FieldType}}{{end}} `json:"{{.JsonTagName}}" form:"{{.JsonTagName}}"` // {{.Comment}}
+{{- end}}
+{{- end}}
+{{- if .HasKeyword}}
+	Keyword string `json:"keyword" form:"keyword"` // 关键字，模糊匹配 {{range $i, $column := .KeywordColumns}}{{if $i}}、{{end}}{{$column}}{{end}}
+{{- end}}
+{{- if .SortFields}}
+	OrderBy string `json:"orderBy" form:"orderBy" binding:"omitempty,oneof={{.SortOneOf}}"` // 排序字段，- 前缀表示降序
+{{- end}}
+{{- end}}
 }
 
 type {{.StructName}}DeleteReq struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"-" uri:"{{.JsonTagName}}" binding:"required"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"-" uri:"{{.StructNameLowerCamel}}ID" binding:"required"` // 主键 ID
+{{- end}}
 }
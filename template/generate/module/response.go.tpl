@@ -6,23 +6,45 @@ import (
 )
 
 type {{.StructName}}CreateResp struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"{{.StructNameLowerCamel}}ID"` // 主键 ID
+{{- end}}
 }
 
 type {{.StructName}}DetailResp struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"{{.StructNameLowerCamel}}ID" binding:"required"` // 主键 ID
+{{- end}}
 	obj{{.PackageName}}.{{.StructName}}BaseInfo
 	gobject.OperatorBaseInfo
 
 }
 
 type {{.StructName}}PageListItem struct {
+{{- if .KeyFields}}
+{{- range .KeyFields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.JsonTagName}}"` // {{.Comment}}
+{{- end}}
+{{- else}}
 	{{.StructName}}ID {{.PKFieldType}} `json:"{{.StructNameLowerCamel}}ID" binding:"required"` // 主键 ID
+{{- end}}
 	obj{{.PackageName}}.{{.StructName}}BaseInfo
 	gobject.OperatorBaseInfo
 }
 
 type {{.StructName}}PageListResp struct {
 	List []{{.StructName}}PageListItem `json:"list"` // 数据列表
+{{- if .PageList.IsCursor}}
+	NextCursor string `json:"nextCursor"` // 下一页游标，为空时没有更多数据
+{{- else}}
 	Total int64          `json:"total"` // 数据总条数
+{{- end}}
 }
@@ -9,11 +9,20 @@ import (
 func {{.StructNameLowerCamel}}Router(groups *ginserver.RouterGroups) {
 	{{.StructNameLowerCamel}}Ctr := ctr{{.PackageName}}.New{{.StructName}}Ctr()
 
+	{{- $itemPath := printf ":%sID" .StructNameLowerCamel}}
+	{{- if .KeyFields}}
+	{{- $itemPath = ""}}
+	{{- range $i, $field := .KeyFields}}
+	{{- if $i}}{{$itemPath = printf "%s/" $itemPath}}{{end}}
+	{{- $itemPath = printf "%s:%s" $itemPath $field.JsonTagName}}
+	{{- end}}
+	{{- end}}
+
 	v1RouterGroup := groups.MustGetGroup(ginserver.ApiVersionV1)
 
 	v1RouterGroup.POST("/{{toKebabCase (pluralize .StructNameLowerCamel)}}", {{.StructNameLowerCamel}}Ctr.Create)
 	v1RouterGroup.GET("/{{toKebabCase (pluralize .StructNameLowerCamel)}}", {{.StructNameLowerCamel}}Ctr.PageList)
-	v1RouterGroup.GET("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/:{{.StructNameLowerCamel}}ID", {{.StructNameLowerCamel}}Ctr.Detail)
-	v1RouterGroup.PUT("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/:{{.StructNameLowerCamel}}ID", {{.StructNameLowerCamel}}Ctr.Update)
-	v1RouterGroup.DELETE("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/:{{.StructNameLowerCamel}}ID", {{.StructNameLowerCamel}}Ctr.Delete)
+	v1RouterGroup.GET("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Detail)
+	v1RouterGroup.PUT("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Update)
+	v1RouterGroup.DELETE("/{{toKebabCase (pluralize .StructNameLowerCamel)}}/{{$itemPath}}", {{.StructNameLowerCamel}}Ctr.Delete)
 }
@@ -6,8 +6,12 @@ import (
 	{{- end}}
 
 	"github.com/gin-gonic/gin"
+	{{- if or (and (not .CustomDelete) (not .KeyFields)) .CreatedByField .UpdatedByField .DeletedByField}}
 	"github.com/morehao/golib/biz/gcontext/gincontext"
+	{{- end}}
+	{{- if not .PageList.IsCursor}}
 	"github.com/morehao/golib/dbaccess/gormdao"
+	{{- end}}
 	"github.com/morehao/golib/biz/gobject"
 	"github.com/morehao/golib/glog"
 	"github.com/morehao/golib/gutil"
@@ -42,29 +46,72 @@ func New{{.StructName}}Svc() {{.StructName}}Svc {
 // Create 创建{{.Description}}
 func (svc *{{.StructNameLowerCamel}}Svc) Create(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}CreateReq) (*dto{{.PackageName}}.{{.StructName}}CreateResp, error) {
 	insertEntity := &{{.ModelLayerName}}.{{.StructName}}Entity{
+{{- range .KeyFields}}
+		{{.FieldName}}: req.{{.FieldName}},
+{{- end}}
 {{- range .ModelFields}}
-	{{- if isSysField .FieldName}}
+	{{- if or (isSysField .FieldName) .IsKeyField}}
 		{{- continue}}
 	{{- end}}
-	{{- if eq .FieldType "time.Time"}}
+	{{- if .NullableStyle}}
+		{{.FieldName}}: {{nullableToModel . $.ModelLayerName (printf "req.%s" .FieldName)}},
+	{{- else if eq .FieldType "time.Time"}}
 		{{.FieldName}}: time.Unix(req.{{.FieldName}}, 0),
+	{{- else if .EnumTypeName}}
+		{{.FieldName}}: {{$.ModelLayerName}}.{{.EnumTypeName}}(req.{{.FieldName}}),
 	{{- else}}
 		{{.FieldName}}: req.{{.FieldName}},
 	{{- end}}
+{{- end}}
+{{- with .CreatedByField}}
+		{{.FieldName}}: {{operatorID .}},
+{{- end}}
+{{- with .UpdatedByField}}
+		{{.FieldName}}: {{operatorID .}},
 {{- end}}
 	}
+{{- range .UniqueIndexes}}
+
+	exist{{.FuncSuffix}}Entity, err := {{$.DaoPackageName}}.New{{$.StructName}}Dao().GetBy{{.FuncSuffix}}(ctx{{range .Fields}}, {{if .NullableStyle}}{{nullableModelPtr .ModelField (printf "insertEntity.%s" .FieldName)}}{{else}}insertEntity.{{.FieldName}}{{end}}{{end}})
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{$.PackageName}}.{{$.StructName}}Create] {{$.DaoPackageName}} GetBy{{.FuncSuffix}} fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return nil, code.GetError(code.{{$.StructName}}CreateError)
+	}
+	if exist{{.FuncSuffix}}Entity != nil {
+		return nil, code.GetError(code.{{$.StructName}}AlreadyExistError)
+	}
+{{- end}}
 
 	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().Insert(ctx, insertEntity); err != nil {
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Create] {{.DaoPackageName}} Create fail, err:%v, req:%s", err, gutil.ToJsonString(req))
 		return nil, code.GetError(code.{{.StructName}}CreateError)
 	}
 	return &dto{{.PackageName}}.{{.StructName}}CreateResp{
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: insertEntity.{{.FieldName}},
+	{{- end}}
+{{- else}}
 		{{.StructName}}ID: insertEntity.ID,
+{{- end}}
 	}, nil
 }
 
 // Delete 删除{{.Description}}
 func (svc *{{.StructNameLowerCamel}}Svc) Delete(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}DeleteReq) error {
+{{- if .KeyFields}}
+	key := {{.ModelLayerName}}.{{.StructName}}Key{
+	{{- range .KeyFields}}
+		{{.FieldName}}: req.{{.FieldName}},
+	{{- end}}
+	}
+	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByKey(ctx, key)
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Delete] {{.DaoPackageName}} GetByKey fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return code.GetError(code.{{.StructName}}DeleteError)
+	}
+	if {{.StructNameLowerCamel}}Entity == nil {
+{{- else}}
 	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByID(ctx, req.{{.StructName}}ID)
 	if err != nil {
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Delete] {{.DaoPackageName}} GetByID fail, err:%v, req:%s", err, gutil.ToJsonString(req))
@@ -75,11 +122,16 @@ func (svc *{{.StructNameLowerCamel}}Svc) Delete(ctx *gin.Context, req *dto{{.Pac
 	{{- else}}
 	if {{.StructNameLowerCamel}}Entity == nil || {{.StructNameLowerCamel}}Entity.ID == 0 {
 	{{- end}}
+{{- end}}
 		return code.GetError(code.{{.StructName}}NotExistError)
 	}
 
 
-	{{- if isStringID .PKFieldType}}
+	{{- if or .CustomDelete .KeyFields}}
+	{{- with .DeletedByField}}
+	deletedBy := {{operatorID .}}
+	{{- end}}
+	{{- else if isStringID .PKFieldType}}
 	deletedBy := gincontext.GetUserIDString(ctx)
 	{{- else}}
 	userID := gincontext.GetUserID(ctx)
@@ -90,7 +142,11 @@ func (svc *{{.StructNameLowerCamel}}Svc) Delete(ctx *gin.Context, req *dto{{.Pac
 	{{- end}}
 	{{- end}}
 
-	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().Delete(ctx, req.{{.StructName}}ID, deletedBy); err != nil {
+	{{- if .KeyFields}}
+	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().DeleteByKey(ctx, key{{if .DeletedByField}}, deletedBy{{end}}); err != nil {
+	{{- else}}
+	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().Delete(ctx, req.{{.StructName}}ID{{if or (not .CustomDelete) .DeletedByField}}, deletedBy{{end}}); err != nil {
+	{{- end}}
 		glog.Errorf(ctx, "[svc{{.PackageName}}.Delete] {{.DaoPackageName}} Delete fail, err:%v, req:%s", err, gutil.ToJsonString(req))
 		return code.GetError(code.{{.StructName}}DeleteError)
 	}
@@ -99,6 +155,19 @@ func (svc *{{.StructNameLowerCamel}}Svc) Delete(ctx *gin.Context, req *dto{{.Pac
 
 // Update 更新{{.Description}}
 func (svc *{{.StructNameLowerCamel}}Svc) Update(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}UpdateReq) error {
+{{- if .KeyFields}}
+	key := {{.ModelLayerName}}.{{.StructName}}Key{
+	{{- range .KeyFields}}
+		{{.FieldName}}: req.{{.FieldName}},
+	{{- end}}
+	}
+	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByKey(ctx, key)
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Update] {{.DaoPackageName}} GetByKey fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return code.GetError(code.{{.StructName}}UpdateError)
+	}
+	if {{.StructNameLowerCamel}}Entity == nil {
+{{- else}}
 	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByID(ctx, req.{{.StructName}}ID)
 	if err != nil {
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Update] {{.DaoPackageName}} GetByID fail, err:%v, req:%s", err, gutil.ToJsonString(req))
@@ -109,11 +178,70 @@ func (svc *{{.StructNameLowerCamel}}Svc) Update(ctx *gin.Context, req *dto{{.Pac
 	{{- else}}
 	if {{.StructNameLowerCamel}}Entity == nil || {{.StructNameLowerCamel}}Entity.ID == 0 {
 	{{- end}}
+{{- end}}
 		return code.GetError(code.{{.StructName}}NotExistError)
 	}
+{{- $hasBaseInfo := false}}
+{{- range .ModelFields}}
+	{{- if not (or (isSysField .FieldName) .IsKeyField)}}
+		{{- $hasBaseInfo = true}}
+	{{- end}}
+{{- end}}
+{{- if $hasBaseInfo}}
 
-	updateMap := map[string]any{}
+	updateEntity := &{{.ModelLayerName}}.{{.StructName}}Entity{
+{{- range .ModelFields}}
+	{{- if or (isSysField .FieldName) .IsKeyField}}
+		{{- continue}}
+	{{- end}}
+	{{- if .NullableStyle}}
+		{{.FieldName}}: {{nullableToModel . $.ModelLayerName (printf "req.%s" .FieldName)}},
+	{{- else if eq .FieldType "time.Time"}}
+		{{.FieldName}}: time.Unix(req.{{.FieldName}}, 0),
+	{{- else if .EnumTypeName}}
+		{{.FieldName}}: {{$.ModelLayerName}}.{{.EnumTypeName}}(req.{{.FieldName}}),
+	{{- else}}
+		{{.FieldName}}: req.{{.FieldName}},
+	{{- end}}
+{{- end}}
+	}
+{{- end}}
+{{- range .UniqueIndexes}}
+
+	exist{{.FuncSuffix}}Entity, err := {{$.DaoPackageName}}.New{{$.StructName}}Dao().GetBy{{.FuncSuffix}}(ctx{{range .Fields}}, {{if .NullableStyle}}{{nullableModelPtr .ModelField (printf "updateEntity.%s" .FieldName)}}{{else}}updateEntity.{{.FieldName}}{{end}}{{end}})
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{$.PackageName}}.{{$.StructName}}Update] {{$.DaoPackageName}} GetBy{{.FuncSuffix}} fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return code.GetError(code.{{$.StructName}}UpdateError)
+	}
+	{{- if $.KeyFields}}
+	if exist{{.FuncSuffix}}Entity != nil && exist{{.FuncSuffix}}Entity.Key() != key {
+	{{- else}}
+	if exist{{.FuncSuffix}}Entity != nil && exist{{.FuncSuffix}}Entity.ID != req.{{$.StructName}}ID {
+	{{- end}}
+		return code.GetError(code.{{$.StructName}}AlreadyExistError)
+	}
+{{- end}}
+
+	updateMap := map[string]any{
+{{- range .ModelFields}}
+	{{- if or (isSysField .FieldName) .IsKeyField}}
+		{{- continue}}
+	{{- end}}
+	{{- if eq .Serializer "json"}}
+		"{{.ColumnName}}": gutil.ToJsonString(updateEntity.{{.FieldName}}),
+	{{- else}}
+		"{{.ColumnName}}": updateEntity.{{.FieldName}},
+	{{- end}}
+{{- end}}
+	}
+	{{- with .UpdatedByField}}
+	updateMap["{{.ColumnName}}"] = {{operatorID .}}
+	{{- end}}
+	{{- if .KeyFields}}
+	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().UpdateMapByKey(ctx, key, updateMap); err != nil {
+	{{- else}}
 	if err := {{.DaoPackageName}}.New{{.StructName}}Dao().UpdateMap(ctx, req.{{.StructName}}ID, updateMap); err != nil {
+	{{- end}}
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Update] {{.DaoPackageName}} UpdateMap fail, err:%v, req:%s", err, gutil.ToJsonString(req))
 		return code.GetError(code.{{.StructName}}UpdateError)
 	}
@@ -122,6 +250,19 @@ func (svc *{{.StructNameLowerCamel}}Svc) Update(ctx *gin.Context, req *dto{{.Pac
 
 // Detail 根据id获取{{.Description}}
 func (svc *{{.StructNameLowerCamel}}Svc) Detail(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}DetailReq) (*dto{{.PackageName}}.{{.StructName}}DetailResp, error) {
+{{- if .KeyFields}}
+	key := {{.ModelLayerName}}.{{.StructName}}Key{
+	{{- range .KeyFields}}
+		{{.FieldName}}: req.{{.FieldName}},
+	{{- end}}
+	}
+	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByKey(ctx, key)
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Detail] {{.DaoPackageName}} GetByKey fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return nil, code.GetError(code.{{.StructName}}GetDetailError)
+	}
+	if {{.StructNameLowerCamel}}Entity == nil {
+{{- else}}
 	{{.StructNameLowerCamel}}Entity, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetByID(ctx, req.{{.StructName}}ID)
 	if err != nil {
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}Detail] {{.DaoPackageName}} GetByID fail, err:%v, req:%s", err, gutil.ToJsonString(req))
@@ -132,25 +273,40 @@ func (svc *{{.StructNameLowerCamel}}Svc) Detail(ctx *gin.Context, req *dto{{.Pac
 	{{- else}}
 	if {{.StructNameLowerCamel}}Entity == nil || {{.StructNameLowerCamel}}Entity.ID == 0 {
 	{{- end}}
+{{- end}}
 		return nil, code.GetError(code.{{.StructName}}NotExistError)
 	}
 	resp := &dto{{.PackageName}}.{{.StructName}}DetailResp{
+	{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: {{.StructNameLowerCamel}}Entity.{{.FieldName}},
+	{{- end}}
+	{{- else}}
 		{{.StructName}}ID:   {{.StructNameLowerCamel}}Entity.ID,
+	{{- end}}
 		{{.StructName}}BaseInfo: obj{{.PackageName}}.{{.StructName}}BaseInfo{
 	{{- range .ModelFields}}
-		{{- if isSysField .FieldName}}
+		{{- if or (isSysField .FieldName) .IsKeyField}}
 			{{- continue}}
 		{{- end}}
-		{{- if eq .FieldType "time.Time"}}
+		{{- if .NullableStyle}}
+			{{.FieldName}}: {{nullableToObject . (printf "%sEntity.%s" .StructNameLowerCamel .FieldName)}},
+		{{- else if eq .FieldType "time.Time"}}
 			{{.FieldName}}: {{.StructNameLowerCamel}}Entity.{{.FieldName}}.Unix(),
+		{{- else if .EnumTypeName}}
+			{{.FieldName}}: {{.FieldType}}({{.StructNameLowerCamel}}Entity.{{.FieldName}}),
 		{{- else}}
 			{{.FieldName}}: {{.StructNameLowerCamel}}Entity.{{.FieldName}},
 		{{- end}}
 	{{- end}}
 		},
 		OperatorBaseInfo: gobject.OperatorBaseInfo{
-			CreatedAt: {{.StructNameLowerCamel}}Entity.CreatedAt.Unix(),
-			UpdatedAt: {{.StructNameLowerCamel}}Entity.UpdatedAt.Unix(),
+		{{- with .CreatedAtField}}
+			CreatedAt: {{unixTime . (printf "%sEntity.%s" $.StructNameLowerCamel .FieldName)}},
+		{{- end}}
+		{{- with .UpdatedAtField}}
+			UpdatedAt: {{unixTime . (printf "%sEntity.%s" $.StructNameLowerCamel .FieldName)}},
+		{{- end}}
 		},
 	}
 	return resp, nil
@@ -159,40 +315,134 @@ func (svc *{{.StructNameLowerCamel}}Svc) Detail(ctx *gin.Context, req *dto{{.Pac
 // PageList 分页获取{{.Description}}列表
 func (svc *{{.StructNameLowerCamel}}Svc) PageList(ctx *gin.Context, req *dto{{.PackageName}}.{{.StructName}}PageListReq) (*dto{{.PackageName}}.{{.StructName}}PageListResp, error) {
 	cond := &{{.DaoPackageName}}.{{.StructName}}Cond{
+{{- if not .PageList.IsCursor}}
 		BaseCond: &gormdao.BaseCond{
 			Page:     req.Page,
 			PageSize: req.PageSize,
 		},
+{{- end}}
+{{- with .PageList}}
+{{- range .Filters}}
+	{{- if eq .FieldType "time.Time"}}
+		{{- continue}}
+	{{- end}}
+	{{- if eq .Op "in"}}
+		{{.FieldName}}List: req.{{.FieldName}}List,
+	{{- else if eq .Op "like"}}
+		{{.FieldName}}Like: req.{{.FieldName}},
+	{{- else if eq .Op "range"}}
+		{{.FieldName}}Start: req.{{.FieldName}}Start,
+		{{.FieldName}}End: req.{{.FieldName}}End,
+	{{- else}}
+		{{.FieldName}}: req.{{.FieldName}},
+	{{- end}}
+{{- end}}
+{{- if .HasKeyword}}
+		Keyword: req.Keyword,
+{{- end}}
+{{- if .SortFields}}
+		OrderBy: req.OrderBy,
+{{- end}}
+{{- end}}
+	}
+{{- with .PageList}}
+{{- range .Filters}}
+{{- if eq .FieldType "time.Time"}}
+{{- if eq .Op "range"}}
+	if req.{{.FieldName}}Start > 0 {
+		cond.{{.FieldName}}Start = time.Unix(req.{{.FieldName}}Start, 0)
+	}
+	if req.{{.FieldName}}End > 0 {
+		cond.{{.FieldName}}End = time.Unix(req.{{.FieldName}}End, 0)
+	}
+{{- else if .NullableStyle}}
+	if req.{{.FieldName}} > 0 {
+		{{.FieldLowerCaseName}} := time.Unix(req.{{.FieldName}}, 0)
+		cond.{{.FieldName}} = &{{.FieldLowerCaseName}}
+	}
+{{- else}}
+	if req.{{.FieldName}} > 0 {
+		cond.{{.FieldName}} = time.Unix(req.{{.FieldName}}, 0)
+	}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .PageList.IsCursor}}
+	if req.Cursor != "" {
+		cursor, err := {{.DaoPackageName}}.Parse{{.StructName}}Cursor(req.Cursor)
+		if err != nil {
+			glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}PageList] {{.DaoPackageName}} Parse{{.StructName}}Cursor fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+			return nil, code.GetError(code.{{.StructName}}GetPageListError)
+		}
+		cond.Cursor = cursor
 	}
+	// 多查询一条记录判断是否存在下一页
+	{{.StructNameLowerCamel}}EntityList, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetCursorListByCond(ctx, cond, req.PageSize+1)
+	if err != nil {
+		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}PageList] {{.DaoPackageName}} GetCursorListByCond fail, err:%v, req:%s", err, gutil.ToJsonString(req))
+		return nil, code.GetError(code.{{.StructName}}GetPageListError)
+	}
+	var nextCursor string
+	if len({{.StructNameLowerCamel}}EntityList) > req.PageSize {
+		{{.StructNameLowerCamel}}EntityList = {{.StructNameLowerCamel}}EntityList[:req.PageSize]
+		last := {{.StructNameLowerCamel}}EntityList[len({{.StructNameLowerCamel}}EntityList)-1]
+		{{- with .PageList.Cursor}}
+		nextCursor = {{$.DaoPackageName}}.{{$.StructName}}Cursor{
+			{{- if not .SortByPK}}
+			{{.SortField.FieldName}}: {{if .SortField.EnumTypeName}}{{.SortField.FieldType}}(last.{{.SortField.FieldName}}){{else}}last.{{.SortField.FieldName}}{{end}},
+			{{- end}}
+			{{.PKField.FieldName}}: last.{{.PKField.FieldName}},
+		}.Encode()
+		{{- end}}
+	}
+{{- else}}
 	{{.StructNameLowerCamel}}EntityList, total, err := {{.DaoPackageName}}.New{{.StructName}}Dao().GetPageListByCond(ctx, cond)
 	if err != nil {
 		glog.Errorf(ctx, "[svc{{.PackageName}}.{{.StructName}}PageList] {{.DaoPackageName}} GetPageListByCond fail, err:%v, req:%s", err, gutil.ToJsonString(req))
 		return nil, code.GetError(code.{{.StructName}}GetPageListError)
 	}
+{{- end}}
 	list := make([]dto{{.PackageName}}.{{.StructName}}PageListItem, 0, len({{.StructNameLowerCamel}}EntityList))
 	for _, v := range {{.StructNameLowerCamel}}EntityList {
 		list = append(list, dto{{.PackageName}}.{{.StructName}}PageListItem{
+		{{- if .KeyFields}}
+		{{- range .KeyFields}}
+			{{.FieldName}}: v.{{.FieldName}},
+		{{- end}}
+		{{- else}}
 			{{.StructName}}ID:   v.ID,
+		{{- end}}
 			{{.StructName}}BaseInfo: obj{{.PackageName}}.{{.StructName}}BaseInfo{
 		{{- range .ModelFields}}
-			{{- if isSysField .FieldName}}
+			{{- if or (isSysField .FieldName) .IsKeyField}}
 				{{- continue}}
 			{{- end}}
-			{{- if eq .FieldType "time.Time"}}
+			{{- if .NullableStyle}}
+				{{.FieldName}}: {{nullableToObject . (printf "v.%s" .FieldName)}},
+			{{- else if eq .FieldType "time.Time"}}
 				{{.FieldName}}: v.{{.FieldName}}.Unix(),
+			{{- else if .EnumTypeName}}
+				{{.FieldName}}: {{.FieldType}}(v.{{.FieldName}}),
 			{{- else}}
 				{{.FieldName}}: v.{{.FieldName}},
 			{{- end}}
 		{{- end}}
 			},
 			OperatorBaseInfo: gobject.OperatorBaseInfo{
-				UpdatedAt: v.UpdatedAt.Unix(),
+			{{- with .UpdatedAtField}}
+				UpdatedAt: {{unixTime . (printf "v.%s" .FieldName)}},
+			{{- end}}
 			},
 		})
 	}
 	return &dto{{.PackageName}}.{{.StructName}}PageListResp{
 		List:  list,
+	{{- if .PageList.IsCursor}}
+		NextCursor: nextCursor,
+	{{- else}}
 		Total: total,
+	{{- end}}
 	}, nil
 }
 
@@ -0,0 +1,116 @@
+package svc{{.PackageName}}
+
+import (
+	{{- range sampleImports .ModelFields}}
+	"{{.}}"
+	{{- end}}
+	"testing"
+
+	"{{.BaseModulePath}}/{{.AppModuleName}}/internal/dto/dto{{.PackageName}}"
+	"{{.BaseModulePath}}/{{.AppModuleName}}/object/obj{{.PackageName}}"
+	"{{.BaseModulePath}}/pkg/testsetup"
+	{{- if not .PageList.IsCursor}}
+	"github.com/morehao/golib/biz/gobject"
+	{{- end}}
+	"github.com/morehao/golib/gutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test{{.StructName}}CRUD 依次执行创建、详情、更新、分页列表与删除{{.Description}}
+func Test{{.StructName}}CRUD(t *testing.T) {
+	testsetup.Initialize(testsetup.AppName{{firstLetterToUpper .AppName}})
+	defer testsetup.Close(testsetup.AppName{{firstLetterToUpper .AppName}})
+
+	ctx := testsetup.NewContext()
+	{{- if sampleImports .ModelFields}}
+	// 每次运行使用不同的示例值，上次运行失败残留的记录不会触发唯一索引冲突
+	sampleRunID := time.Now().UnixNano()
+	{{- end}}
+	svc := New{{.StructName}}Svc()
+	baseInfo := obj{{.PackageName}}.{{.StructName}}BaseInfo{
+{{- range .ModelFields}}
+	{{- if or (isSysField .FieldName) .IsKeyField}}
+		{{- continue}}
+	{{- end}}
+	{{- $value := sampleValue .}}
+	{{- if $value}}
+		{{.FieldName}}: {{$value}},
+	{{- end}}
+{{- end}}
+	}
+
+	createResp, err := svc.Create(ctx, &dto{{.PackageName}}.{{.StructName}}CreateReq{
+{{- range .KeyFields}}
+		{{.FieldName}}: {{sampleValue .}},
+{{- end}}
+		{{.StructName}}BaseInfo: baseInfo,
+	})
+	require.Nil(t, err)
+{{- if not .KeyFields}}
+	{{.StructNameLowerCamel}}ID := createResp.{{.StructName}}ID
+{{- end}}
+
+	detailResp, err := svc.Detail(ctx, &dto{{.PackageName}}.{{.StructName}}DetailReq{
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: createResp.{{.FieldName}},
+	{{- end}}
+{{- else}}
+		{{.StructName}}ID: {{.StructNameLowerCamel}}ID,
+{{- end}}
+	})
+	require.Nil(t, err)
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+	assert.Equal(t, createResp.{{.FieldName}}, detailResp.{{.FieldName}})
+	{{- end}}
+{{- else}}
+	assert.Equal(t, {{.StructNameLowerCamel}}ID, detailResp.{{.StructName}}ID)
+{{- end}}
+
+	err = svc.Update(ctx, &dto{{.PackageName}}.{{.StructName}}UpdateReq{
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: createResp.{{.FieldName}},
+	{{- end}}
+{{- else}}
+		{{.StructName}}ID:       {{.StructNameLowerCamel}}ID,
+{{- end}}
+		{{.StructName}}BaseInfo: baseInfo,
+	})
+	assert.Nil(t, err)
+
+	pageListResp, err := svc.PageList(ctx, &dto{{.PackageName}}.{{.StructName}}PageListReq{
+	{{- if .PageList.IsCursor}}
+		PageSize: 10,
+	{{- else}}
+		PageQuery: gobject.PageQuery{Page: 1, PageSize: 10},
+	{{- end}}
+	})
+	require.Nil(t, err)
+	assert.NotEmpty(t, pageListResp.List)
+	t.Logf("pageListResp: %s", gutil.ToJsonString(pageListResp))
+
+	err = svc.Delete(ctx, &dto{{.PackageName}}.{{.StructName}}DeleteReq{
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: createResp.{{.FieldName}},
+	{{- end}}
+{{- else}}
+		{{.StructName}}ID: {{.StructNameLowerCamel}}ID,
+{{- end}}
+	})
+	assert.Nil(t, err)
+
+	_, err = svc.Detail(ctx, &dto{{.PackageName}}.{{.StructName}}DetailReq{
+{{- if .KeyFields}}
+	{{- range .KeyFields}}
+		{{.FieldName}}: createResp.{{.FieldName}},
+	{{- end}}
+{{- else}}
+		{{.StructName}}ID: {{.StructNameLowerCamel}}ID,
+{{- end}}
+	})
+	assert.NotNil(t, err, "{{.Description}}删除后不应再查询到详情")
+}
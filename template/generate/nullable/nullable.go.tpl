@@ -0,0 +1,42 @@
+package svc{{.PackageName}}
+
+import (
+	"time"
+)
+
+// 可空字段在 model 与 object 层之间的转换函数，object 层可空字段统一为指针，nil 对应 NULL
+
+// nullPtr 将 sql.Null 类型的值转换为指针，Valid 为 false 时返回 nil
+func nullPtr[T any](value T, valid bool) *T {
+	if !valid {
+		return nil
+	}
+	return &value
+}
+
+// valueOf 取指针指向的值，nil 时返回零值
+func valueOf[T any](ptr *T) T {
+	if ptr == nil {
+		var zero T
+		return zero
+	}
+	return *ptr
+}
+
+// unixPtr 将时间指针转换为 Unix 时间戳指针，nil 时返回 nil
+func unixPtr(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	ts := t.Unix()
+	return &ts
+}
+
+// timePtr 将 Unix 时间戳指针转换为时间指针，nil 时返回 nil
+func timePtr(ts *int64) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := time.Unix(*ts, 0)
+	return &t
+}